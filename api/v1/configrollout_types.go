@@ -0,0 +1,143 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="STATE",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="WAVE",type=integer,JSONPath=`.status.currentWave`
+//+kubebuilder:printcolumn:name="SOURCE",type=string,JSONPath=`.spec.source.name`
+//+kubebuilder:printcolumn:name="PAUSED",type=boolean,JSONPath=`.spec.paused`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ConfigRollout is the Schema for the configrollouts API.
+// It rolls out a change of a configuration resource to the Runtimes in waves.
+type ConfigRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConfigRolloutSpec   `json:"spec"`
+	Status ConfigRolloutStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ConfigRolloutList contains a list of ConfigRollout
+type ConfigRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConfigRollout `json:"items"`
+}
+
+// ConfigRolloutSpec defines the desired state of ConfigRollout
+type ConfigRolloutSpec struct {
+	// Source is the configuration resource whose change is rolled out.
+	Source ConfigRolloutSource `json:"source"`
+
+	// Revision identifies the rolled out content of the configuration resource.
+	Revision string `json:"revision"`
+
+	// Waves are rolled out one after another. Runtimes which are not selected by any wave are rolled out in a final wave.
+	// +optional
+	Waves []ConfigRolloutWave `json:"waves,omitempty"`
+
+	// HealthTimeout is the time in which the Runtimes of a wave must become Ready again before the next wave starts.
+	// +kubebuilder:default="30m"
+	// +optional
+	HealthTimeout metav1.Duration `json:"healthTimeout,omitempty"`
+
+	// Paused stops the rollout. It is set by KIM when the Runtimes of a wave don't become Ready.
+	// When the rollout is resumed, the current wave is rolled out again.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ConfigRolloutSource defines the configuration resource whose change is rolled out
+type ConfigRolloutSource struct {
+	// +kubebuilder:validation:Enum=ConfigMap;Secret;ClusterTrustBundle
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ConfigRolloutWave selects the Runtimes rolled out together.
+// A wave without a selector, regions, and percentage selects all remaining Runtimes.
+type ConfigRolloutWave struct {
+	Name string `json:"name"`
+
+	// Selector selects the Runtimes by labels, for example, canary Runtimes.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Regions selects the Runtimes by the region of the Shoot.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// Percentage limits the share of all Runtimes which are rolled out after this wave completes.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percentage *int32 `json:"percentage,omitempty"`
+}
+
+type ConfigRolloutState string
+
+const (
+	ConfigRolloutStateProgressing ConfigRolloutState = "Progressing"
+	ConfigRolloutStatePaused      ConfigRolloutState = "Paused"
+	ConfigRolloutStateCompleted   ConfigRolloutState = "Completed"
+	ConfigRolloutStateSuperseded  ConfigRolloutState = "Superseded"
+)
+
+// ConfigRolloutStatus defines the observed state of ConfigRollout
+type ConfigRolloutStatus struct {
+	// State signifies current state of the rollout.
+	// Value can be one of ("Progressing", "Paused", "Completed", "Superseded").
+	State ConfigRolloutState `json:"state,omitempty"`
+
+	// Message describes the current state of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CurrentWave is the index of the wave being rolled out.
+	// +optional
+	CurrentWave int32 `json:"currentWave,omitempty"`
+
+	// WaveStartTime is the time at which the Runtimes of the current wave were annotated for reconciliation.
+	// +optional
+	WaveStartTime *metav1.Time `json:"waveStartTime,omitempty"`
+
+	// TotalRuntimes is the number of Runtimes affected by the rollout when its first wave started.
+	// The percentages of the waves are calculated from it.
+	// +optional
+	TotalRuntimes int32 `json:"totalRuntimes,omitempty"`
+
+	// Waves shows the progress of the waves which were started.
+	// +optional
+	Waves []ConfigRolloutWaveStatus `json:"waves,omitempty"`
+}
+
+// ConfigRolloutWaveStatus shows the progress of a wave
+type ConfigRolloutWaveStatus struct {
+	Name string `json:"name"`
+	// Runtimes is the number of Runtimes in the wave.
+	Runtimes int32 `json:"runtimes"`
+	// Members lists the Runtimes which were annotated for reconciliation when the wave started.
+	// +optional
+	Members []string `json:"members,omitempty"`
+	// ReadyRuntimes is the number of Runtimes in the wave which became Ready after they were reconciled.
+	ReadyRuntimes int32 `json:"readyRuntimes"`
+	// FailedRuntimes lists the Runtimes which blocked the wave.
+	// +optional
+	FailedRuntimes []string `json:"failedRuntimes,omitempty"`
+	// CompletionTime is the time at which all Runtimes of the wave were Ready.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ConfigRollout{}, &ConfigRolloutList{})
+}
@@ -0,0 +1,4 @@
+package v1
+
+// Hub marks v1 as the hub version of the Runtime API; other versions are converted to and from it.
+func (*Runtime) Hub() {}
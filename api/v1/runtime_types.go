@@ -34,21 +34,25 @@ import (
 const (
 	Finalizer                              = "runtime-controller.infrastructure-manager.kyma-project.io/deletion-hook"
 	AnnotationGardenerCloudDelConfirmation = "confirmation.gardener.cloud/deletion"
+	// AnnotationGardenerProject is set by KIM when the Runtime is placed and can't be changed afterwards
+	AnnotationGardenerProject = "operator.kyma-project.io/gardener-project"
 )
 
 const (
-	LabelKymaInstanceID      = "kyma-project.io/instance-id"
-	LabelKymaRuntimeID       = "kyma-project.io/runtime-id"
-	LabelKymaShootName       = "kyma-project.io/shoot-name"
-	LabelKymaRegion          = "kyma-project.io/region"
-	LabelKymaName            = "operator.kyma-project.io/kyma-name"
-	LabelKymaBrokerPlanID    = "kyma-project.io/broker-plan-id"
-	LabelKymaBrokerPlanName  = "kyma-project.io/broker-plan-name"
-	LabelKymaGlobalAccountID = "kyma-project.io/global-account-id"
-	LabelKymaSubaccountID    = "kyma-project.io/subaccount-id"
-	LabelKymaManagedBy       = "operator.kyma-project.io/managed-by"
-	LabelKymaInternal        = "operator.kyma-project.io/internal"
-	LabelKymaPlatformRegion  = "kyma-project.io/platform-region"
+	LabelKymaInstanceID        = "kyma-project.io/instance-id"
+	LabelKymaRuntimeID         = "kyma-project.io/runtime-id"
+	LabelKymaShootName         = "kyma-project.io/shoot-name"
+	LabelKymaRegion            = "kyma-project.io/region"
+	LabelKymaName              = "operator.kyma-project.io/kyma-name"
+	LabelKymaBrokerPlanID      = "kyma-project.io/broker-plan-id"
+	LabelKymaBrokerPlanName    = "kyma-project.io/broker-plan-name"
+	LabelKymaGlobalAccountID   = "kyma-project.io/global-account-id"
+	LabelKymaSubaccountID      = "kyma-project.io/subaccount-id"
+	LabelKymaManagedBy         = "operator.kyma-project.io/managed-by"
+	LabelKymaInternal          = "operator.kyma-project.io/internal"
+	LabelKymaPlatformRegion    = "kyma-project.io/platform-region"
+	LabelKymaGardenerProject   = "operator.kyma-project.io/gardener-project"
+	LabelKymaGardenerLandscape = "kyma-project.io/gardener-landscape"
 )
 
 const (
@@ -56,6 +60,8 @@ const (
 	RuntimeStateFailed      = "Failed"
 	RuntimeStatePending     = "Pending"
 	RuntimeStateTerminating = "Terminating"
+
+	RuntimeStateDeletionScheduled = "DeletionScheduled"
 )
 
 type RuntimeConditionType string
@@ -71,6 +77,11 @@ const (
 	ConditionTypeRuntimeBootstrapperReady  RuntimeConditionType = "RuntimeBootstrapperReady"
 	ConditionTypeCustomAuditLogConfigured  RuntimeConditionType = "CustomAuditLogConfigured"
 	ConditionTypeAuditLogCredentialsCopied RuntimeConditionType = "AuditLogCredentialsCopied"
+	ConditionTypeRuntimeHibernated         RuntimeConditionType = "Hibernated"
+	ConditionTypeRuntimeWakingUp           RuntimeConditionType = "WakingUp"
+	ConditionTypeRuntimePatchDryRun        RuntimeConditionType = "PatchDryRun"
+	ConditionTypeRuntimeShootDrifted       RuntimeConditionType = "ShootDrifted"
+	ConditionTypeRuntimeFailureRecovery    RuntimeConditionType = "FailureRecovery"
 )
 
 type RuntimeConditionReason string
@@ -109,9 +120,48 @@ const (
 	ConditionReasonKymaSystemNSError        = RuntimeConditionReason("KymaSystemNSError")
 	ConditionReasonKymaSystemNSReady        = RuntimeConditionReason("KymaSystemNSReady")
 	ConditionReasonSeedNotFound             = RuntimeConditionReason("SeedNotFound")
+	ConditionReasonShootNotFound            = RuntimeConditionReason("ShootNotFound")
+	ConditionReasonProjectPlacementError    = RuntimeConditionReason("ProjectPlacementErr")
+
+	ConditionReasonKubernetesVersionUnavailable = RuntimeConditionReason("KubernetesVersionUnavailable")
+	ConditionReasonMachineImageUnavailable      = RuntimeConditionReason("MachineImageUnavailable")
+	ConditionReasonMachineTypeUnavailable       = RuntimeConditionReason("MachineTypeUnavailable")
+	ConditionReasonRegionUnavailable            = RuntimeConditionReason("RegionUnavailable")
+	ConditionReasonZoneUnavailable              = RuntimeConditionReason("ZoneUnavailable")
+
+	// reasons of the Gardener error catalog, used when the last operation of the Shoot failed
+	ConditionReasonInfrastructureUnauthenticated   = RuntimeConditionReason("InfrastructureUnauthenticated")
+	ConditionReasonInfrastructureUnauthorized      = RuntimeConditionReason("InfrastructureUnauthorized")
+	ConditionReasonInfrastructureQuotaExceeded     = RuntimeConditionReason("InfrastructureQuotaExceeded")
+	ConditionReasonInfrastructureRateLimited       = RuntimeConditionReason("InfrastructureRateLimited")
+	ConditionReasonInfrastructureDependencies      = RuntimeConditionReason("InfrastructureDependencies")
+	ConditionReasonInfrastructureResourcesDepleted = RuntimeConditionReason("InfrastructureResourcesDepleted")
+	ConditionReasonShootConfigurationProblem       = RuntimeConditionReason("ShootConfigurationProblem")
+	ConditionReasonProblematicWebhook              = RuntimeConditionReason("ProblematicWebhook")
+	ConditionReasonClusterResourcesCleanup         = RuntimeConditionReason("ClusterResourcesCleanup")
 
 	ConditionReasonRegistryCacheConfigured = RuntimeConditionReason("RegistryCacheConfigured")
 
+	ConditionReasonHibernationInProgress = RuntimeConditionReason("HibernationInProgress")
+	ConditionReasonHibernationCompleted  = RuntimeConditionReason("HibernationCompleted")
+	ConditionReasonWakeUpInProgress      = RuntimeConditionReason("WakeUpInProgress")
+	ConditionReasonWakeUpCompleted       = RuntimeConditionReason("WakeUpCompleted")
+
+	ConditionReasonDeletionProtected = RuntimeConditionReason("DeletionProtected")
+	ConditionReasonDeletionScheduled = RuntimeConditionReason("DeletionScheduled")
+
+	ConditionReasonDryRunCompleted = RuntimeConditionReason("DryRunCompleted")
+	ConditionReasonDryRunFailed    = RuntimeConditionReason("DryRunFailed")
+
+	ConditionReasonShootInSync               = RuntimeConditionReason("ShootInSync")
+	ConditionReasonShootDriftDetected        = RuntimeConditionReason("ShootDriftDetected")
+	ConditionReasonShootDriftCorrected       = RuntimeConditionReason("ShootDriftCorrected")
+	ConditionReasonShootDriftDetectionFailed = RuntimeConditionReason("ShootDriftDetectionFailed")
+
+	ConditionReasonRetryScheduled   = RuntimeConditionReason("RetryScheduled")
+	ConditionReasonRetryInProgress  = RuntimeConditionReason("RetryInProgress")
+	ConditionReasonRetriesExhausted = RuntimeConditionReason("RetriesExhausted")
+
 	ConditionReasonRegistryCacheError                            = RuntimeConditionReason("RegistryCacheError")
 	ConditionReasonRegistryCacheGardenClusterConfigurationFailed = RuntimeConditionReason("RegistryCacheGardenClusterConfigurationFailed")
 	ConditionReasonRegistryCacheGardenClusterCleanupFailed       = RuntimeConditionReason("RegistryCacheGardenClusterCleanupFailed")
@@ -127,6 +177,7 @@ const (
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.shoot.provider.type"
 //+kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.shoot.region"
 //+kubebuilder:printcolumn:name="STATE",type=string,JSONPath=`.status.state`
@@ -171,7 +222,7 @@ type ImageRegistryCache struct {
 type RuntimeStatus struct {
 	// State signifies current state of Runtime
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=Pending;Ready;Terminating;Failed
+	// +kubebuilder:validation:Enum=Pending;Ready;Terminating;Failed;DeletionScheduled
 	State State `json:"state,omitempty"`
 
 	// List of status conditions to indicate the status of a ServiceInstance.
@@ -187,8 +238,70 @@ type RuntimeStatus struct {
 	// LastError indicates the last occurred error for an operation on a Gardener's `shoot` resource.
 	ShootLastErrors []gardener.LastError `json:"shootLastErrors,omitempty" protobuf:"bytes,6,rep,name=lastErrors"`
 
+	// ShootCreationTime is the time at which the Shoot of the Runtime was created.
+	// A missing Shoot of a Runtime with this time set is not created again.
+	ShootCreationTime *metav1.Time `json:"shootCreationTime,omitempty"`
+
 	// AuditLogCR holds the name of the AuditLog custom resource chosen for this Runtime
 	AuditLogCR string `json:"auditLogCR,omitempty"`
+
+	// ShootDriftCheckTime is the time when the Shoot was last compared with the Runtime by the drift detection
+	ShootDriftCheckTime *metav1.Time `json:"shootDriftCheckTime,omitempty"`
+
+	// MachineImage is the machine image of the main worker pool requested from Gardener with the last create or patch of the Shoot
+	MachineImage *RuntimeMachineImage `json:"machineImage,omitempty"`
+
+	// FailureRecovery tracks the automatic retries of a Runtime in the Failed state
+	FailureRecovery *RuntimeFailureRecovery `json:"failureRecovery,omitempty"`
+
+	// FailureClassification is the classification of the Gardener errors the Runtime failed with, it is not set for other failures
+	FailureClassification *RuntimeFailureClassification `json:"failureClassification,omitempty"`
+
+	// NotificationSequence is the sequence number of the last notification of a lifecycle transition of the Runtime
+	NotificationSequence int64 `json:"notificationSequence,omitempty"`
+
+	// PendingNotifications are the notifications of lifecycle transitions which aren't stored in the notification outbox yet
+	PendingNotifications []RuntimeNotification `json:"pendingNotifications,omitempty"`
+}
+
+// RuntimeNotification is the notification of a lifecycle transition of the Runtime.
+type RuntimeNotification struct {
+	// Sequence orders the notifications of the Runtime, the notifications are delivered in its order
+	Sequence int64 `json:"sequence"`
+	// EventType is the type of the lifecycle transition
+	EventType string `json:"eventType"`
+	// Reason is the condition reason of the lifecycle transition
+	Reason string `json:"reason,omitempty"`
+}
+
+// RuntimeFailureRecovery describes the automatic retries of a failed Runtime.
+type RuntimeFailureRecovery struct {
+	// Attempts is the number of retries scheduled since the Runtime was last Ready or changed
+	Attempts int `json:"attempts"`
+	// Generation is the generation of the Runtime CR the retries were scheduled for, the retries are reset when it changes
+	Generation int64 `json:"generation,omitempty"`
+	// Reason is the condition reason of the failure which triggered the last retry
+	Reason string `json:"reason,omitempty"`
+	// NextRetryTime is the time of the next retry, it is not set when no retry is scheduled
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// RuntimeFailureClassification is the classification of the Gardener errors of a failed Runtime by the Gardener error catalog.
+type RuntimeFailureClassification struct {
+	// Reason is the condition reason the Gardener errors were classified with
+	Reason string `json:"reason"`
+	// Retryable is true when the failure is retried automatically
+	Retryable bool `json:"retryable"`
+	// InitialBackoff is the delay before the first retry, the delays of the failure recovery are used when it is not set
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	// MaxBackoff caps the delay between the retries
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// RuntimeMachineImage identifies a machine image version offered by the CloudProfile.
+type RuntimeMachineImage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
 }
 
 type RuntimeShoot struct {
@@ -204,6 +317,45 @@ type RuntimeShoot struct {
 	Provider              Provider               `json:"provider"`
 	Networking            Networking             `json:"networking"`
 	ControlPlane          *gardener.ControlPlane `json:"controlPlane,omitempty"`
+	Hibernation           *Hibernation           `json:"hibernation,omitempty"`
+	Maintenance           *Maintenance           `json:"maintenance,omitempty"`
+}
+
+// Hibernation defines when the cluster is hibernated to save costs.
+type Hibernation struct {
+	// Enabled requests the cluster to be hibernated (true) or woken up (false).
+	// When Schedules are defined, Gardener switches this value according to the schedules;
+	// the value set by Gardener is retained when the Shoot is patched.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Schedules defines the times when the cluster is hibernated and woken up.
+	Schedules []HibernationSchedule `json:"schedules,omitempty"`
+}
+
+// HibernationSchedule defines a cron schedule for hibernating and waking up the cluster.
+type HibernationSchedule struct {
+	// Start is a cron expression defining when the cluster is hibernated.
+	Start *string `json:"start,omitempty"`
+	// End is a cron expression defining when the cluster is woken up.
+	End *string `json:"end,omitempty"`
+	// Location is the time zone of the cron expressions, for example "Europe/Berlin". Defaults to UTC.
+	Location *string `json:"location,omitempty"`
+}
+
+// Maintenance overrides the maintenance settings which KIM applies to the Shoot by default.
+type Maintenance struct {
+	// TimeWindow defines the daily time window in which Gardener performs the maintenance of the cluster.
+	// It takes precedence over the regional maintenance window configured for production clusters.
+	TimeWindow *gardener.MaintenanceTimeWindow `json:"timeWindow,omitempty"`
+	// AutoUpdate defines which versions are updated automatically during the maintenance.
+	AutoUpdate *MaintenanceAutoUpdate `json:"autoUpdate,omitempty"`
+}
+
+// MaintenanceAutoUpdate overrides the automatic version updates configured in `converter_config.json`.
+type MaintenanceAutoUpdate struct {
+	// KubernetesVersion enables the automatic update of the Kubernetes patch version.
+	KubernetesVersion *bool `json:"kubernetesVersion,omitempty"`
+	// MachineImageVersion enables the automatic update of the machine image versions.
+	MachineImageVersion *bool `json:"machineImageVersion,omitempty"`
 }
 
 type Kubernetes struct {
@@ -275,6 +427,11 @@ func init() {
 
 func (k *Runtime) UpdateStateReady(c RuntimeConditionType, r RuntimeConditionReason, msg string) {
 	k.Status.State = RuntimeStateReady
+	// a later failure gets the full number of retries
+	k.Status.FailureRecovery = nil
+	k.Status.FailureClassification = nil
+	meta.RemoveStatusCondition(&k.Status.Conditions, string(ConditionTypeRuntimeFailureRecovery))
+
 	condition := metav1.Condition{
 		Type:               string(c),
 		Status:             "True",
@@ -298,6 +455,19 @@ func (k *Runtime) UpdateStateDeletion(c RuntimeConditionType, r RuntimeCondition
 	meta.SetStatusCondition(&k.Status.Conditions, condition)
 }
 
+func (k *Runtime) UpdateStateDeletionScheduled(c RuntimeConditionType, r RuntimeConditionReason, status metav1.ConditionStatus, msg string) {
+	k.Status.State = RuntimeStateDeletionScheduled
+
+	condition := metav1.Condition{
+		Type:               string(c),
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(r),
+		Message:            msg,
+	}
+	meta.SetStatusCondition(&k.Status.Conditions, condition)
+}
+
 func (k *Runtime) UpdateStateFailed(c RuntimeConditionType, r RuntimeConditionReason, msg string) {
 	k.Status.State = RuntimeStateFailed
 	condition := metav1.Condition{
@@ -323,6 +493,18 @@ func (k *Runtime) UpdateStatePending(c RuntimeConditionType, r RuntimeConditionR
 	meta.SetStatusCondition(&k.Status.Conditions, condition)
 }
 
+// SetCondition sets the status condition without changing the state of the Runtime.
+func (k *Runtime) SetCondition(c RuntimeConditionType, r RuntimeConditionReason, status metav1.ConditionStatus, msg string) {
+	condition := metav1.Condition{
+		Type:               string(c),
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(r),
+		Message:            msg,
+	}
+	meta.SetStatusCondition(&k.Status.Conditions, condition)
+}
+
 func (k *Runtime) UpdateStateProvisioningCompleted() {
 	k.Status.ProvisioningCompleted = true
 }
@@ -0,0 +1,292 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+var _ conversion.Convertible = &Runtime{}
+
+// ConvertTo converts this Runtime to the hub version (v1).
+// Provider settings which are not part of the v2 API are restored from the AnnotationConversionData annotation.
+func (r *Runtime) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*imv1.Runtime)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+
+	src := r.DeepCopy()
+
+	var stored imv1.Provider
+	if data, found := src.Annotations[AnnotationConversionData]; found {
+		if err := json.Unmarshal([]byte(data), &stored); err != nil {
+			return fmt.Errorf("failed to decode %s annotation: %w", AnnotationConversionData, err)
+		}
+
+		delete(src.Annotations, AnnotationConversionData)
+		if len(src.Annotations) == 0 {
+			src.Annotations = nil
+		}
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = imv1.RuntimeSpec{
+		Shoot:                 convertShootToV1(src.Spec.Shoot, stored),
+		Security:              src.Spec.Security,
+		Caching:               src.Spec.Caching,
+		AuditLogAccessEnabled: src.Spec.AuditLogAccessEnabled,
+	}
+	dst.Status = src.Status
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1) to this Runtime.
+// Provider settings which cannot be expressed with the v2 API are kept in the AnnotationConversionData annotation.
+func (r *Runtime) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*imv1.Runtime)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+
+	src = src.DeepCopy()
+
+	r.ObjectMeta = src.ObjectMeta
+	r.Spec = RuntimeSpec{
+		Shoot:                 convertShootFromV1(src.Spec.Shoot),
+		Security:              src.Spec.Security,
+		Caching:               src.Spec.Caching,
+		AuditLogAccessEnabled: src.Spec.AuditLogAccessEnabled,
+	}
+	r.Status = src.Status
+	delete(r.Annotations, AnnotationConversionData)
+
+	restored := convertProviderToV1(r.Spec.Shoot.Provider, imv1.Provider{})
+	if equality.Semantic.DeepEqual(restored, src.Spec.Shoot.Provider) {
+		return nil
+	}
+
+	data, err := json.Marshal(src.Spec.Shoot.Provider)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s annotation: %w", AnnotationConversionData, err)
+	}
+
+	if r.Annotations == nil {
+		r.Annotations = map[string]string{}
+	}
+	r.Annotations[AnnotationConversionData] = string(data)
+
+	return nil
+}
+
+func convertShootToV1(shoot RuntimeShoot, storedProvider imv1.Provider) imv1.RuntimeShoot {
+	return imv1.RuntimeShoot{
+		Name:                  shoot.Name,
+		Purpose:               gardener.ShootPurpose(shoot.Purpose),
+		PlatformRegion:        shoot.PlatformRegion,
+		Region:                shoot.Region,
+		LicenceType:           shoot.LicenceType,
+		SecretBindingName:     shoot.SecretBindingName,
+		EnforceSeedLocation:   shoot.EnforceSeedLocation,
+		EnableNvidiaOpenshell: shoot.EnableNvidiaOpenshell,
+		Kubernetes:            shoot.Kubernetes,
+		Provider:              convertProviderToV1(shoot.Provider, storedProvider),
+		Networking:            imv1.Networking(shoot.Networking),
+		ControlPlane:          convertControlPlaneToV1(shoot.ControlPlane),
+		Hibernation:           shoot.Hibernation,
+		Maintenance:           shoot.Maintenance,
+	}
+}
+
+func convertShootFromV1(shoot imv1.RuntimeShoot) RuntimeShoot {
+	return RuntimeShoot{
+		Name:                  shoot.Name,
+		Purpose:               string(shoot.Purpose),
+		PlatformRegion:        shoot.PlatformRegion,
+		Region:                shoot.Region,
+		LicenceType:           shoot.LicenceType,
+		SecretBindingName:     shoot.SecretBindingName,
+		EnforceSeedLocation:   shoot.EnforceSeedLocation,
+		EnableNvidiaOpenshell: shoot.EnableNvidiaOpenshell,
+		Kubernetes:            shoot.Kubernetes,
+		Provider:              convertProviderFromV1(shoot.Provider),
+		Networking:            Networking(shoot.Networking),
+		ControlPlane:          convertControlPlaneFromV1(shoot.ControlPlane),
+		Hibernation:           shoot.Hibernation,
+		Maintenance:           shoot.Maintenance,
+	}
+}
+
+func convertProviderToV1(provider Provider, stored imv1.Provider) imv1.Provider {
+	result := imv1.Provider{
+		Type:                 provider.Type,
+		Workers:              convertWorkerPoolsToV1(provider.Workers, stored.Workers),
+		InfrastructureConfig: stored.InfrastructureConfig,
+		ControlPlaneConfig:   stored.ControlPlaneConfig,
+	}
+
+	if len(provider.AdditionalWorkers) > 0 || stored.AdditionalWorkers != nil {
+		var storedAdditionalWorkers []gardener.Worker
+		if stored.AdditionalWorkers != nil {
+			storedAdditionalWorkers = *stored.AdditionalWorkers
+		}
+
+		additionalWorkers := convertWorkerPoolsToV1(provider.AdditionalWorkers, storedAdditionalWorkers)
+		if additionalWorkers == nil {
+			additionalWorkers = []gardener.Worker{}
+		}
+		result.AdditionalWorkers = &additionalWorkers
+	}
+
+	return result
+}
+
+func convertProviderFromV1(provider imv1.Provider) Provider {
+	result := Provider{
+		Type:    provider.Type,
+		Workers: convertWorkerPoolsFromV1(provider.Workers),
+	}
+
+	if provider.AdditionalWorkers != nil {
+		result.AdditionalWorkers = convertWorkerPoolsFromV1(*provider.AdditionalWorkers)
+	}
+
+	return result
+}
+
+// convertWorkerPoolsToV1 converts the worker pools to Gardener workers.
+// Stored workers with the same name are used as a base, so that Gardener settings not available in v2 are retained.
+func convertWorkerPoolsToV1(pools []WorkerPool, storedWorkers []gardener.Worker) []gardener.Worker {
+	if pools == nil {
+		return nil
+	}
+
+	storedByName := make(map[string]gardener.Worker, len(storedWorkers))
+	for _, worker := range storedWorkers {
+		storedByName[worker.Name] = worker
+	}
+
+	workers := make([]gardener.Worker, 0, len(pools))
+	for _, pool := range pools {
+		workers = append(workers, convertWorkerPoolToV1(pool, storedByName[pool.Name]))
+	}
+
+	return workers
+}
+
+func convertWorkerPoolToV1(pool WorkerPool, base gardener.Worker) gardener.Worker {
+	worker := base
+	worker.Name = pool.Name
+	worker.Machine.Type = pool.MachineType
+	worker.Zones = pool.Zones
+	worker.Minimum = pool.Autoscaler.Minimum
+	worker.Maximum = pool.Autoscaler.Maximum
+	worker.MaxSurge = pool.Autoscaler.MaxSurge
+	worker.MaxUnavailable = pool.Autoscaler.MaxUnavailable
+	worker.Labels = pool.Labels
+	worker.Taints = pool.Taints
+
+	if pool.Image == nil {
+		worker.Machine.Image = nil
+	} else {
+		if worker.Machine.Image == nil {
+			worker.Machine.Image = &gardener.ShootMachineImage{}
+		}
+		worker.Machine.Image.Name = pool.Image.Name
+		worker.Machine.Image.Version = pool.Image.Version
+	}
+
+	if pool.Volume == nil {
+		worker.Volume = nil
+	} else {
+		if worker.Volume == nil {
+			worker.Volume = &gardener.Volume{}
+		}
+		worker.Volume.Type = pool.Volume.Type
+		worker.Volume.VolumeSize = pool.Volume.Size
+	}
+
+	return worker
+}
+
+func convertWorkerPoolsFromV1(workers []gardener.Worker) []WorkerPool {
+	if workers == nil {
+		return nil
+	}
+
+	pools := make([]WorkerPool, 0, len(workers))
+	for _, worker := range workers {
+		pools = append(pools, convertWorkerPoolFromV1(worker))
+	}
+
+	return pools
+}
+
+func convertWorkerPoolFromV1(worker gardener.Worker) WorkerPool {
+	pool := WorkerPool{
+		Name:        worker.Name,
+		MachineType: worker.Machine.Type,
+		Zones:       worker.Zones,
+		Autoscaler: Autoscaler{
+			Minimum:        worker.Minimum,
+			Maximum:        worker.Maximum,
+			MaxSurge:       worker.MaxSurge,
+			MaxUnavailable: worker.MaxUnavailable,
+		},
+		Labels: worker.Labels,
+		Taints: worker.Taints,
+	}
+
+	if worker.Machine.Image != nil {
+		pool.Image = &MachineImage{
+			Name:    worker.Machine.Image.Name,
+			Version: worker.Machine.Image.Version,
+		}
+	}
+
+	if worker.Volume != nil {
+		pool.Volume = &Volume{
+			Type: worker.Volume.Type,
+			Size: worker.Volume.VolumeSize,
+		}
+	}
+
+	return pool
+}
+
+func convertControlPlaneToV1(controlPlane *ControlPlane) *gardener.ControlPlane {
+	if controlPlane == nil {
+		return nil
+	}
+
+	result := &gardener.ControlPlane{}
+	if controlPlane.HighAvailability != nil {
+		result.HighAvailability = &gardener.HighAvailability{
+			FailureTolerance: gardener.FailureTolerance{
+				Type: gardener.FailureToleranceType(controlPlane.HighAvailability.FailureToleranceType),
+			},
+		}
+	}
+
+	return result
+}
+
+func convertControlPlaneFromV1(controlPlane *gardener.ControlPlane) *ControlPlane {
+	if controlPlane == nil {
+		return nil
+	}
+
+	result := &ControlPlane{}
+	if controlPlane.HighAvailability != nil {
+		result.HighAvailability = &HighAvailability{
+			FailureToleranceType: string(controlPlane.HighAvailability.FailureTolerance.Type),
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,244 @@
+package v2
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestRuntimeConversion_RoundTrip(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenRuntime          *imv1.Runtime
+		expectedConversionAnn bool
+	}{
+		"Should convert Runtime using only settings available in v2 without annotation": {
+			givenRuntime: fixV1Runtime(),
+		},
+		"Should convert maintenance settings without annotation": {
+			givenRuntime: func() *imv1.Runtime {
+				rt := fixV1Runtime()
+				rt.Spec.Shoot.Maintenance = &imv1.Maintenance{
+					TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "220000+0100", End: "230000+0100"},
+					AutoUpdate: &imv1.MaintenanceAutoUpdate{KubernetesVersion: ptr.To(false)},
+				}
+				return rt
+			}(),
+		},
+		"Should keep Gardener specific worker settings in annotation": {
+			givenRuntime: func() *imv1.Runtime {
+				rt := fixV1Runtime()
+				rt.Spec.Shoot.Provider.Workers[0].CRI = &gardener.CRI{
+					Name:              gardener.CRINameContainerD,
+					ContainerRuntimes: []gardener.ContainerRuntime{{Type: "gvisor"}},
+				}
+				rt.Spec.Shoot.Provider.Workers[0].MachineControllerManagerSettings = &gardener.MachineControllerManagerSettings{
+					MachineDrainTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+				}
+				return rt
+			}(),
+			expectedConversionAnn: true,
+		},
+		"Should keep infrastructure and control plane config in annotation": {
+			givenRuntime: func() *imv1.Runtime {
+				rt := fixV1Runtime()
+				rt.Spec.Shoot.Provider.InfrastructureConfig = &runtime.RawExtension{Raw: []byte(`{"kind":"InfrastructureConfig"}`)}
+				rt.Spec.Shoot.Provider.ControlPlaneConfig = &runtime.RawExtension{Raw: []byte(`{"kind":"ControlPlaneConfig"}`)}
+				return rt
+			}(),
+			expectedConversionAnn: true,
+		},
+		"Should keep empty additional workers list": {
+			givenRuntime: func() *imv1.Runtime {
+				rt := fixV1Runtime()
+				rt.Spec.Shoot.Provider.AdditionalWorkers = &[]gardener.Worker{}
+				return rt
+			}(),
+			expectedConversionAnn: true,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			spoke := &Runtime{}
+			hub := &imv1.Runtime{}
+
+			// when
+			require.NoError(t, spoke.ConvertFrom(tcase.givenRuntime.DeepCopy()))
+			require.NoError(t, spoke.ConvertTo(hub))
+
+			// then
+			_, found := spoke.Annotations[AnnotationConversionData]
+			assert.Equal(t, tcase.expectedConversionAnn, found)
+			assert.Equal(t, tcase.givenRuntime, hub)
+		})
+	}
+}
+
+func TestRuntimeConversion_ConvertFrom(t *testing.T) {
+	// given
+	hub := fixV1Runtime()
+	spoke := &Runtime{}
+
+	// when
+	err := spoke.ConvertFrom(hub)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, hub.ObjectMeta, spoke.ObjectMeta)
+	assert.Equal(t, "production", spoke.Spec.Shoot.Purpose)
+	assert.Equal(t, "zone", spoke.Spec.Shoot.ControlPlane.HighAvailability.FailureToleranceType)
+	assert.Equal(t, []WorkerPool{{
+		Name:        "cpu-worker-0",
+		MachineType: "m6i.large",
+		Image:       &MachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")},
+		Volume:      &Volume{Type: ptr.To("gp3"), Size: "50Gi"},
+		Zones:       []string{"eu-central-1a", "eu-central-1b"},
+		Autoscaler: Autoscaler{
+			Minimum:        1,
+			Maximum:        3,
+			MaxSurge:       ptr.To(intstr.FromInt32(3)),
+			MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+		},
+		Labels: map[string]string{"worker": "main"},
+		Taints: []corev1.Taint{{Key: "dedicated", Value: "kyma", Effect: corev1.TaintEffectNoSchedule}},
+	}}, spoke.Spec.Shoot.Provider.Workers)
+	assert.Equal(t, hub.Spec.Shoot.Networking, imv1.Networking(spoke.Spec.Shoot.Networking))
+}
+
+func TestRuntimeConversion_ConvertTo(t *testing.T) {
+	t.Run("Should convert worker pools to Gardener workers", func(t *testing.T) {
+		// given
+		spoke := &Runtime{}
+		require.NoError(t, spoke.ConvertFrom(fixV1Runtime()))
+		hub := &imv1.Runtime{}
+
+		// when
+		err := spoke.ConvertTo(hub)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, fixV1Runtime().Spec.Shoot.Provider.Workers, hub.Spec.Shoot.Provider.Workers)
+		assert.Nil(t, hub.Spec.Shoot.Provider.AdditionalWorkers)
+	})
+
+	t.Run("Should apply v2 changes on top of settings kept in annotation", func(t *testing.T) {
+		// given
+		original := fixV1Runtime()
+		original.Spec.Shoot.Provider.Workers[0].CRI = &gardener.CRI{Name: gardener.CRINameContainerD}
+		spoke := &Runtime{}
+		require.NoError(t, spoke.ConvertFrom(original))
+
+		spoke.Spec.Shoot.Provider.Workers[0].MachineType = "m6i.xlarge"
+		spoke.Spec.Shoot.Provider.AdditionalWorkers = []WorkerPool{{
+			Name:        "additional",
+			MachineType: "m6i.large",
+			Zones:       []string{"eu-central-1a"},
+			Autoscaler:  Autoscaler{Minimum: 0, Maximum: 2},
+		}}
+		hub := &imv1.Runtime{}
+
+		// when
+		err := spoke.ConvertTo(hub)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "m6i.xlarge", hub.Spec.Shoot.Provider.Workers[0].Machine.Type)
+		assert.Equal(t, original.Spec.Shoot.Provider.Workers[0].CRI, hub.Spec.Shoot.Provider.Workers[0].CRI)
+		require.NotNil(t, hub.Spec.Shoot.Provider.AdditionalWorkers)
+		assert.Equal(t, []gardener.Worker{{
+			Name:    "additional",
+			Machine: gardener.Machine{Type: "m6i.large"},
+			Zones:   []string{"eu-central-1a"},
+			Maximum: 2,
+		}}, *hub.Spec.Shoot.Provider.AdditionalWorkers)
+		assert.NotContains(t, hub.Annotations, AnnotationConversionData)
+	})
+
+	t.Run("Should return error for malformed annotation", func(t *testing.T) {
+		// given
+		spoke := &Runtime{}
+		require.NoError(t, spoke.ConvertFrom(fixV1Runtime()))
+		spoke.Annotations = map[string]string{AnnotationConversionData: "{"}
+
+		// when
+		err := spoke.ConvertTo(&imv1.Runtime{})
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func fixV1Runtime() *imv1.Runtime {
+	return &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runtime-id",
+			Namespace: "kcp-system",
+			Labels: map[string]string{
+				imv1.LabelKymaRuntimeID: "runtime-id",
+			},
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name:              "test-shoot",
+				Purpose:           gardener.ShootPurposeProduction,
+				PlatformRegion:    "cf-eu10",
+				Region:            "eu-central-1",
+				SecretBindingName: "secret-binding",
+				Kubernetes: imv1.Kubernetes{
+					Version: ptr.To("1.33"),
+				},
+				Provider: imv1.Provider{
+					Type: "aws",
+					Workers: []gardener.Worker{
+						{
+							Name: "cpu-worker-0",
+							Machine: gardener.Machine{
+								Type: "m6i.large",
+								Image: &gardener.ShootMachineImage{
+									Name:    "gardenlinux",
+									Version: ptr.To("1592.1.0"),
+								},
+							},
+							Volume: &gardener.Volume{
+								Type:       ptr.To("gp3"),
+								VolumeSize: "50Gi",
+							},
+							Minimum:        1,
+							Maximum:        3,
+							MaxSurge:       ptr.To(intstr.FromInt32(3)),
+							MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+							Zones:          []string{"eu-central-1a", "eu-central-1b"},
+							Labels:         map[string]string{"worker": "main"},
+							Taints:         []corev1.Taint{{Key: "dedicated", Value: "kyma", Effect: corev1.TaintEffectNoSchedule}},
+						},
+					},
+				},
+				Networking: imv1.Networking{
+					Nodes:     "10.250.0.0/16",
+					Pods:      "100.64.0.0/12",
+					Services:  "100.104.0.0/13",
+					DualStack: ptr.To(false),
+				},
+				ControlPlane: &gardener.ControlPlane{
+					HighAvailability: &gardener.HighAvailability{
+						FailureTolerance: gardener.FailureTolerance{Type: gardener.FailureToleranceTypeZone},
+					},
+				},
+			},
+			Security: imv1.Security{
+				Administrators: []string{"admin@example.com"},
+			},
+		},
+		Status: imv1.RuntimeStatus{
+			State: imv1.RuntimeStateReady,
+		},
+	}
+}
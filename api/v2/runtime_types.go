@@ -0,0 +1,144 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v2
+
+import (
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// AnnotationConversionData holds the v1 provider settings which cannot be expressed with the v2 API.
+// It is set when a Runtime is read in v2, and used to restore the settings when the Runtime is written back.
+const AnnotationConversionData = "infrastructuremanager.kyma-project.io/v1-provider"
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".spec.shoot.provider.type"
+//+kubebuilder:printcolumn:name="Region",type="string",JSONPath=".spec.shoot.region"
+//+kubebuilder:printcolumn:name="STATE",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Runtime is the Schema for the runtimes API
+type Runtime struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuntimeSpec        `json:"spec,omitempty"`
+	Status imv1.RuntimeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RuntimeList contains a list of Runtime
+type RuntimeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Runtime `json:"items"`
+}
+
+// RuntimeSpec defines the desired state of Runtime
+type RuntimeSpec struct {
+	Shoot    RuntimeShoot              `json:"shoot"`
+	Security imv1.Security             `json:"security"`
+	Caching  []imv1.ImageRegistryCache `json:"imageRegistryCache,omitempty"`
+
+	// AuditLogAccessEnabled indicates whether the client requires access to their audit log data
+	AuditLogAccessEnabled *bool `json:"auditLogAccessEnabled,omitempty"`
+}
+
+type RuntimeShoot struct {
+	Name                  string            `json:"name"`
+	Purpose               string            `json:"purpose"`
+	PlatformRegion        string            `json:"platformRegion"`
+	Region                string            `json:"region"`
+	LicenceType           *string           `json:"licenceType,omitempty"`
+	SecretBindingName     string            `json:"secretBindingName"`
+	EnforceSeedLocation   *bool             `json:"enforceSeedLocation,omitempty"`
+	EnableNvidiaOpenshell *bool             `json:"enableNvidiaOpenshell,omitempty"`
+	Kubernetes            imv1.Kubernetes   `json:"kubernetes,omitempty"`
+	Provider              Provider          `json:"provider"`
+	Networking            Networking        `json:"networking"`
+	ControlPlane          *ControlPlane     `json:"controlPlane,omitempty"`
+	Hibernation           *imv1.Hibernation `json:"hibernation,omitempty"`
+	Maintenance           *imv1.Maintenance `json:"maintenance,omitempty"`
+}
+
+// Provider describes the hyperscaler and the worker pools of the cluster.
+// Provider specific infrastructure and control plane configuration is generated by KIM.
+type Provider struct {
+	//+kubebuilder:validation:Enum=aws;azure;gcp;openstack;alicloud;gdch
+	Type string `json:"type"`
+	// Workers contains the main worker pool of the cluster; exactly one pool is expected
+	Workers []WorkerPool `json:"workers"`
+	// AdditionalWorkers contains worker pools created on customer request
+	AdditionalWorkers []WorkerPool `json:"additionalWorkers,omitempty"`
+}
+
+// WorkerPool is a provider-neutral definition of a group of worker nodes.
+type WorkerPool struct {
+	Name        string        `json:"name"`
+	MachineType string        `json:"machineType"`
+	Image       *MachineImage `json:"image,omitempty"`
+	Volume      *Volume       `json:"volume,omitempty"`
+	Zones       []string      `json:"zones,omitempty"`
+	Autoscaler  Autoscaler    `json:"autoscaler"`
+	// Labels are applied to all nodes of the worker pool
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are applied to all nodes of the worker pool
+	Taints []corev1.Taint `json:"taints,omitempty"`
+}
+
+type MachineImage struct {
+	Name    string  `json:"name,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+type Volume struct {
+	Type *string `json:"type,omitempty"`
+	Size string  `json:"size"`
+}
+
+// Autoscaler defines the bounds and the rolling update settings of a worker pool.
+type Autoscaler struct {
+	Minimum        int32               `json:"min"`
+	Maximum        int32               `json:"max"`
+	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+type Networking struct {
+	Type       *string `json:"type,omitempty"`
+	Pods       string  `json:"pods"`
+	Nodes      string  `json:"nodes"`
+	Services   string  `json:"services"`
+	DualStack  *bool   `json:"dualStack,omitempty"`
+	VPCNetwork *string `json:"vpcNetwork,omitempty"`
+}
+
+type ControlPlane struct {
+	HighAvailability *HighAvailability `json:"highAvailability,omitempty"`
+}
+
+type HighAvailability struct {
+	//+kubebuilder:validation:Enum=node;zone
+	FailureToleranceType string `json:"failureToleranceType"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Runtime{}, &RuntimeList{})
+}
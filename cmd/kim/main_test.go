@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	const testdata = "../../internal/render/testdata/"
+
+	for _, tc := range []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "Should print the usage without a command",
+			expectedCode:   2,
+			expectedStderr: "Usage:",
+		},
+		{
+			name:           "Should reject an unknown command",
+			args:           []string{"unknown"},
+			expectedCode:   2,
+			expectedStderr: `unknown command "unknown"`,
+		},
+		{
+			name:           "Should require the Runtime and the converter configuration",
+			args:           []string{"render", "-runtime", testdata + "runtime.yaml"},
+			expectedCode:   2,
+			expectedStderr: "the -runtime and -converter-config flags are required",
+		},
+		{
+			name: "Should render the Shoot",
+			args: []string{"render",
+				"-runtime", testdata + "runtime.yaml",
+				"-converter-config", testdata + "converter_config.json",
+				"-audit-log-config", testdata + "auditConfig.json",
+				"-maintenance-window-config", testdata + "maintenanceWindow.json",
+			},
+			expectedCode:   0,
+			expectedStdout: "kind: Shoot",
+		},
+		{
+			name: "Should fail when the Runtime cannot be converted",
+			args: []string{"render",
+				"-runtime", testdata + "runtime.yaml",
+				"-converter-config", testdata + "converter_config.json",
+			},
+			expectedCode:   1,
+			expectedStderr: "audit log configuration not provided",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var stdout, stderr bytes.Buffer
+
+			// when
+			code := run(tc.args, &stdout, &stderr)
+
+			// then
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Contains(t, stdout.String(), tc.expectedStdout)
+			assert.Contains(t, stderr.String(), tc.expectedStderr)
+			assert.NotContains(t, stdout.String(), "status:")
+		})
+	}
+}
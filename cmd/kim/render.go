@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/internal/render"
+	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+type renderFlags struct {
+	runtimePath                     string
+	converterConfigPath             string
+	auditLogConfigPath              string
+	auditLogMandatory               bool
+	maintenanceWindowConfigPath     string
+	shootPath                       string
+	cloudProfilePath                string
+	kcpResourcePaths                fileList
+	gardenResourcePaths             fileList
+	apiServerAclEnabled             bool
+	networkRestrictionGlobalEnabled bool
+	registryCacheEnabled            bool
+	output                          string
+}
+
+func runRender(args []string, stdout, stderr io.Writer) int {
+	var flags renderFlags
+
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&flags.runtimePath, "runtime", "", "Path to the Runtime CR (required)")
+	fs.StringVar(&flags.converterConfigPath, "converter-config", "", "Path to the gardener shoot converter configuration, for example converter_config.json (required)")
+	fs.StringVar(&flags.auditLogConfigPath, "audit-log-config", "", "Path to the audit log tenant configuration")
+	fs.BoolVar(&flags.auditLogMandatory, "audit-log-mandatory", true, "Fail when no audit log tenant is configured for the provider and region of the Runtime, as KIM does in strict mode")
+	fs.StringVar(&flags.maintenanceWindowConfigPath, "maintenance-window-config", "", "Path to the regional maintenance window configuration. Overrides the path from the converter configuration")
+	fs.StringVar(&flags.shootPath, "shoot", "", "Path to the existing Shoot. When set, the Shoot is rendered as patched by KIM instead of created")
+	fs.StringVar(&flags.cloudProfilePath, "cloud-profile", "", "Path to the CloudProfile. Required when the machine image version strategy is not pinned")
+	fs.Var(&flags.kcpResourcePaths, "kcp-resources", "Path to KCP resources read by KIM, for example the API server ACL ConfigMap. Can be repeated")
+	fs.Var(&flags.gardenResourcePaths, "garden-resources", "Path to Gardener resources read by KIM, for example the registry cache Secrets or the bindings of a Gardener project. Can be repeated")
+	fs.BoolVar(&flags.apiServerAclEnabled, "api-server-acl-enabled", false, "Render the Shoot API server ACL extension, as KIM does with the same flag")
+	fs.BoolVar(&flags.networkRestrictionGlobalEnabled, "network-restriction-enabled", true, "Render the network restriction, as KIM does with the same flag")
+	fs.BoolVar(&flags.registryCacheEnabled, "registry-cache-enabled", false, "Reference the registry cache Secrets when patching, as KIM does with -registry-cache-config-controller-enabled")
+	fs.StringVar(&flags.output, "output", "yaml", "Output format, yaml or json")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if flags.runtimePath == "" || flags.converterConfigPath == "" {
+		fmt.Fprintln(stderr, "the -runtime and -converter-config flags are required")
+		fs.Usage()
+		return 2
+	}
+
+	if flags.output != "yaml" && flags.output != "json" {
+		fmt.Fprintf(stderr, "unsupported output format %q\n", flags.output)
+		return 2
+	}
+
+	if err := renderShoot(context.Background(), flags, stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func renderShoot(ctx context.Context, flags renderFlags, stdout, stderr io.Writer) error {
+	opts, err := loadRenderOptions(flags)
+	if err != nil {
+		return err
+	}
+
+	result, err := render.Shoot(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(stderr, "warning: %s\n", warning)
+	}
+
+	out, err := marshalShoot(result.Shoot, flags.output)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(out)
+	return err
+}
+
+// marshalShoot prints the Shoot without the status and the metadata which are set by the Gardener API server.
+func marshalShoot(shoot gardener.Shoot, output string) ([]byte, error) {
+	object, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(&shoot)
+	if err != nil {
+		return nil, err
+	}
+	delete(object, "status")
+	unstructured.RemoveNestedField(object, "metadata", "creationTimestamp")
+
+	if output == "json" {
+		out, err := json.MarshalIndent(object, "", "  ")
+		return append(out, '\n'), err
+	}
+	return yaml.Marshal(object)
+}
+
+func loadRenderOptions(flags renderFlags) (render.Options, error) {
+	opts := render.Options{
+		AuditLogMandatory:               flags.auditLogMandatory,
+		ApiServerAclEnabled:             flags.apiServerAclEnabled,
+		NetworkRestrictionGlobalEnabled: flags.networkRestrictionGlobalEnabled,
+		RegistryCacheEnabled:            flags.registryCacheEnabled,
+	}
+
+	runtime, err := render.LoadRuntime(flags.runtimePath)
+	if err != nil {
+		return opts, err
+	}
+	opts.Runtime = runtime
+
+	cfg, err := render.LoadConverterConfig(flags.converterConfigPath)
+	if err != nil {
+		return opts, fmt.Errorf("failed to load converter configuration: %w", err)
+	}
+	opts.ConverterConfig = cfg.ConverterConfig
+
+	if flags.maintenanceWindowConfigPath != "" {
+		opts.ConverterConfig.MaintenanceWindow.WindowMapPath = flags.maintenanceWindowConfigPath
+	}
+
+	if flags.auditLogConfigPath != "" {
+		opts.AuditLogConfiguration, err = auditlog.LoadConfiguration(flags.auditLogConfigPath)
+		if err != nil {
+			return opts, fmt.Errorf("failed to load audit log configuration: %w", err)
+		}
+	}
+
+	if flags.shootPath != "" {
+		opts.ExistingShoot, err = render.LoadShoot(flags.shootPath)
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	if flags.cloudProfilePath != "" {
+		opts.CloudProfile, err = render.LoadCloudProfile(flags.cloudProfilePath)
+		if err != nil {
+			return opts, err
+		}
+	}
+
+	for _, path := range flags.kcpResourcePaths {
+		var objects []client.Object
+		objects, err = render.LoadObjects(path)
+		if err != nil {
+			return opts, err
+		}
+		opts.KcpObjects = append(opts.KcpObjects, objects...)
+	}
+
+	for _, path := range flags.gardenResourcePaths {
+		var objects []client.Object
+		objects, err = render.LoadObjects(path)
+		if err != nil {
+			return opts, err
+		}
+		opts.GardenObjects = append(opts.GardenObjects, objects...)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyma-project/infrastructure-manager/internal/adoption"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"sigs.k8s.io/yaml"
+)
+
+type keyValueList map[string]string
+
+func (l keyValueList) String() string {
+	var pairs []string
+	for key, value := range l {
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (l keyValueList) Set(value string) error {
+	key, val, found := strings.Cut(value, "=")
+	if !found || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	l[key] = val
+	return nil
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type adoptFlags struct {
+	kubeconfig         string
+	gardenerKubeconfig string
+	gardenerProject    string
+	namespace          string
+	shoot              string
+	runtimeID          string
+	platformRegion     string
+	labels             keyValueList
+	administrators     stringList
+	dryRun             bool
+}
+
+func runAdopt(args []string, stdout, stderr io.Writer, newClient clientFactory) int {
+	flags := adoptFlags{labels: keyValueList{}}
+
+	fs := flag.NewFlagSet("adopt", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&flags.kubeconfig, "kubeconfig", "", "Path to the KCP kubeconfig. Defaults to the KUBECONFIG environment variable or the in-cluster configuration")
+	fs.StringVar(&flags.gardenerKubeconfig, "gardener-kubeconfig", "", "Path to the kubeconfig of the Gardener project (required)")
+	fs.StringVar(&flags.gardenerProject, "gardener-project", "", "Name of the Gardener project (required)")
+	fs.StringVar(&flags.namespace, "namespace", defaultNamespace, "Namespace of the Runtime CR")
+	fs.StringVar(&flags.shoot, "shoot", "", "Name of the Shoot to adopt (required)")
+	fs.StringVar(&flags.runtimeID, "runtime-id", "", "Runtime ID, used as the name of the Runtime CR (required)")
+	fs.StringVar(&flags.platformRegion, "platform-region", "", "Platform region of the Runtime")
+	fs.Var(flags.labels, "label", "Label of the Runtime CR as key=value, for example kyma-project.io/global-account-id=<ID>. Can be repeated")
+	fs.Var(&flags.administrators, "administrator", "Administrator of the Runtime. Can be repeated")
+	fs.BoolVar(&flags.dryRun, "dry-run", true, "Only print the Runtime CR")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if flags.gardenerKubeconfig == "" || flags.gardenerProject == "" || flags.shoot == "" || flags.runtimeID == "" {
+		fmt.Fprintln(stderr, "the -gardener-kubeconfig, -gardener-project, -shoot and -runtime-id flags are required")
+		fs.Usage()
+		return 2
+	}
+
+	kcpClient, err := newClient(flags.kubeconfig)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to create the KCP client: %v\n", err)
+		return 1
+	}
+
+	gardenClient, err := newClient(flags.gardenerKubeconfig)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to create the Gardener client: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	result, err := adoption.Adopt(ctx, gardenClient, kcpClient, adoption.Options{
+		ShootName:         flags.shoot,
+		GardenerNamespace: project.Namespace(flags.gardenerProject),
+		Namespace:         flags.namespace,
+		RuntimeID:         flags.runtimeID,
+		PlatformRegion:    flags.platformRegion,
+		Labels:            flags.labels,
+		Administrators:    flags.administrators,
+		DryRun:            flags.dryRun,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to adopt shoot %s: %v\n", flags.shoot, err)
+		return 1
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(stderr, "Warning: %s\n", warning)
+	}
+
+	if flags.dryRun {
+		output, err := yaml.Marshal(result.Runtime)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		fmt.Fprint(stdout, string(output))
+		return 0
+	}
+
+	fmt.Fprintf(stdout, "Runtime %s/%s created for shoot %s.\n", result.Runtime.Namespace, result.Runtime.Name, flags.shoot)
+	fmt.Fprintf(stdout, "Review the changes KIM would apply to the shoot in ConfigMap %s/%s-patch-dry-run, then remove the %s annotation to start managing the shoot.\n",
+		result.Runtime.Namespace, result.Runtime.Name, reconciler.DryRunPatchAnnotation)
+	return 0
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/fleet"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const usage = `kimctl runs bulk operations on the Runtime CRs of a KCP cluster.
+
+Usage:
+  kimctl <command> [flags]
+
+Commands:
+  list                List the selected Runtimes
+  summary             Print the state counts, the oldest Pending Runtimes and the top Shoot errors
+  force-patch         Force KIM to patch the Shoots of the selected Runtimes
+  suspend             Suspend the reconciliation of the selected Runtimes
+  resume              Resume the reconciliation of the selected Runtimes
+  rotate-kubeconfig   Force the rotation of the kubeconfigs of the selected Runtimes
+  adopt               Create a Runtime CR for an existing Shoot which was not created by KIM
+
+Commands changing Runtimes only print the planned changes unless -dry-run=false is set.
+Use "kimctl <command> -h" for the flags of a command.
+`
+
+const (
+	defaultNamespace = "kcp-system"
+	defaultQPS       = 1.0
+	defaultBurst     = 5
+	defaultTop       = 10
+	commandTimeout   = 30 * time.Minute
+)
+
+var operations = map[string]fleet.Operation{
+	fleet.ForcePatch.Name:       fleet.ForcePatch,
+	fleet.Suspend.Name:          fleet.Suspend,
+	fleet.Resume.Name:           fleet.Resume,
+	fleet.RotateKubeconfig.Name: fleet.RotateKubeconfig,
+}
+
+type clientFactory func(kubeconfig string) (client.Client, error)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, newClient))
+}
+
+func run(args []string, stdout, stderr io.Writer, newClient clientFactory) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	command := args[0]
+	switch command {
+	case "list", "summary":
+	case "adopt":
+		return runAdopt(args[1:], stdout, stderr, newClient)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		if _, found := operations[command]; !found {
+			fmt.Fprintf(stderr, "unknown command %q\n\n%s", command, usage)
+			return 2
+		}
+	}
+
+	flags, code := parseFlags(command, args[1:], stderr)
+	if code >= 0 {
+		return code
+	}
+
+	c, err := newClient(flags.kubeconfig)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to create the KCP client: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	runtimes, err := fleet.List(ctx, c, flags.namespace, flags.selector)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to list Runtimes: %v\n", err)
+		return 1
+	}
+
+	switch command {
+	case "list":
+		err = fleet.PrintList(stdout, runtimes)
+	case "summary":
+		err = fleet.Summarize(runtimes, flags.top).Print(stdout, time.Now())
+	default:
+		err = runOperation(ctx, c, operations[command], runtimes, flags, stdout)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func runOperation(ctx context.Context, c client.Client, operation fleet.Operation, runtimes []imv1.Runtime, flags commandFlags, stdout io.Writer) error {
+	executor := fleet.Executor{
+		Client:      c,
+		DryRun:      flags.dryRun,
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(flags.qps, flags.burst),
+		Out:         stdout,
+	}
+
+	report, err := executor.Run(ctx, operation, runtimes)
+	if err != nil {
+		return err
+	}
+
+	verb := "changed"
+	if flags.dryRun {
+		verb = "would change"
+	}
+	fmt.Fprintf(stdout, "\n%s: %s %d, unchanged %d, failed %d\n", operation.Name, verb, report.Changed, report.Unchanged, report.Failed)
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%s failed for %d Runtimes", operation.Name, report.Failed)
+	}
+	return nil
+}
+
+type commandFlags struct {
+	kubeconfig string
+	namespace  string
+	selector   fleet.Selector
+	all        bool
+	dryRun     bool
+	qps        float32
+	burst      int
+	top        int
+}
+
+// parseFlags returns the exit code when the command must not be run, or -1 otherwise.
+func parseFlags(command string, args []string, stderr io.Writer) (commandFlags, int) {
+	var flags commandFlags
+	var labelSelector, providers, regions, states, conditionReasons string
+	var qps float64
+
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&flags.kubeconfig, "kubeconfig", "", "Path to the KCP kubeconfig. Defaults to the KUBECONFIG environment variable or the in-cluster configuration")
+	fs.StringVar(&flags.namespace, "namespace", defaultNamespace, "Namespace of the Runtime CRs")
+	fs.StringVar(&labelSelector, "selector", "", "Label selector of the Runtime CRs, for example kyma-project.io/platform-region=cf-eu10")
+	fs.StringVar(&providers, "provider", "", "Comma separated list of provider types, for example aws,gcp")
+	fs.StringVar(&regions, "region", "", "Comma separated list of regions")
+	fs.StringVar(&states, "state", "", "Comma separated list of Runtime states, for example Failed,Pending")
+	fs.StringVar(&conditionReasons, "condition-reason", "", "Comma separated list of condition reasons, for example ConversionError")
+
+	_, isOperation := operations[command]
+	if isOperation {
+		fs.BoolVar(&flags.all, "all", false, "Allow the operation on all Runtimes when no selection criteria are set")
+		fs.BoolVar(&flags.dryRun, "dry-run", true, "Only print the planned changes")
+		fs.Float64Var(&qps, "qps", defaultQPS, "Maximum number of changed Runtimes per second")
+		fs.IntVar(&flags.burst, "burst", defaultBurst, "Maximum number of Runtimes changed at once before -qps applies")
+	}
+	if command == "summary" {
+		fs.IntVar(&flags.top, "top", defaultTop, "Number of the oldest Pending Runtimes and of the top Shoot errors to print")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return flags, 0
+		}
+		return flags, 2
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid label selector: %v\n", err)
+		return flags, 2
+	}
+
+	flags.qps = float32(qps)
+	flags.selector = fleet.Selector{
+		Labels:           selector,
+		Providers:        splitList(providers),
+		Regions:          splitList(regions),
+		ConditionReasons: splitList(conditionReasons),
+	}
+	for _, state := range splitList(states) {
+		flags.selector.States = append(flags.selector.States, imv1.State(state))
+	}
+
+	if isOperation {
+		if flags.selector.IsEmpty() && !flags.all {
+			fmt.Fprintln(stderr, "no selection criteria set, use -all to run the operation on all Runtimes")
+			return flags, 2
+		}
+		if flags.qps <= 0 || flags.burst <= 0 {
+			fmt.Fprintln(stderr, "the -qps and -burst flags must be positive")
+			return flags, 2
+		}
+	}
+
+	return flags, -1
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func newClient(kubeconfig string) (client.Client, error) {
+	var restConfig *rest.Config
+	var err error
+	if kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		restConfig, err = config.GetConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := k8sruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(imv1.AddToScheme(scheme))
+	utilruntime.Must(gardener.AddToScheme(scheme))
+
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
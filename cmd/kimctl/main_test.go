@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name                string
+		args                []string
+		expectedCode        int
+		expectedStdout      string
+		expectedStderr      string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:           "Should print the usage without a command",
+			expectedCode:   2,
+			expectedStderr: "Usage:",
+		},
+		{
+			name:           "Should reject an unknown command",
+			args:           []string{"unknown"},
+			expectedCode:   2,
+			expectedStderr: `unknown command "unknown"`,
+		},
+		{
+			name:           "Should reject an invalid label selector",
+			args:           []string{"list", "-selector", "a=b=c"},
+			expectedCode:   2,
+			expectedStderr: "invalid label selector",
+		},
+		{
+			name:           "Should require selection criteria or -all for operations",
+			args:           []string{"suspend"},
+			expectedCode:   2,
+			expectedStderr: "use -all to run the operation on all Runtimes",
+		},
+		{
+			name:           "Should reject a rate limit which is not positive",
+			args:           []string{"suspend", "-all", "-qps", "0"},
+			expectedCode:   2,
+			expectedStderr: "the -qps and -burst flags must be positive",
+		},
+		{
+			name:           "Should require the Shoot and the Gardener project for adoption",
+			args:           []string{"adopt", "-runtime-id", "runtime-b"},
+			expectedCode:   2,
+			expectedStderr: "the -gardener-kubeconfig, -gardener-project, -shoot and -runtime-id flags are required",
+		},
+		{
+			name:           "Should reject a label without value",
+			args:           []string{"adopt", "-label", "tier"},
+			expectedCode:   2,
+			expectedStderr: `expected key=value, got "tier"`,
+		},
+		{
+			name:           "Should fail the adoption of a missing Shoot",
+			args:           []string{"adopt", "-gardener-kubeconfig", "gardener.yaml", "-gardener-project", "kyma", "-shoot", "shoot-b", "-runtime-id", "runtime-b"},
+			expectedCode:   1,
+			expectedStderr: "failed to adopt shoot shoot-b",
+		},
+		{
+			name:           "Should list the selected Runtimes",
+			args:           []string{"list", "-provider", "aws"},
+			expectedCode:   0,
+			expectedStdout: "runtime-a",
+		},
+		{
+			name:           "Should print the summary",
+			args:           []string{"summary"},
+			expectedCode:   0,
+			expectedStdout: "Ready  1",
+		},
+		{
+			name:           "Should only print the planned changes by default",
+			args:           []string{"force-patch", "-all"},
+			expectedCode:   0,
+			expectedStdout: "force-patch: would change 1, unchanged 0, failed 0",
+		},
+		{
+			name:                "Should change the Runtimes without dry run",
+			args:                []string{"suspend", "-state", "Ready", "-dry-run=false"},
+			expectedCode:        0,
+			expectedStdout:      "suspend: changed 1, unchanged 0, failed 0",
+			expectedAnnotations: map[string]string{reconciler.SuspendReconcileAnnotation: "true"},
+		},
+		{
+			name:           "Should fail when the operation fails for a Runtime",
+			args:           []string{"rotate-kubeconfig", "-all", "-dry-run=false"},
+			expectedCode:   1,
+			expectedStderr: "rotate-kubeconfig failed for 1 Runtimes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var stdout, stderr bytes.Buffer
+			c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(fixRuntime()).Build()
+
+			// when
+			code := run(tc.args, &stdout, &stderr, func(string) (client.Client, error) {
+				return c, nil
+			})
+
+			// then
+			assert.Equal(t, tc.expectedCode, code)
+			assert.Contains(t, stdout.String(), tc.expectedStdout)
+			assert.Contains(t, stderr.String(), tc.expectedStderr)
+
+			var runtime imv1.Runtime
+			require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "runtime-a", Namespace: defaultNamespace}, &runtime))
+			assert.Equal(t, tc.expectedAnnotations, runtime.Annotations)
+		})
+	}
+
+	t.Run("Should fail when the client cannot be created", func(t *testing.T) {
+		// given
+		var stdout, stderr bytes.Buffer
+
+		// when
+		code := run([]string{"list"}, &stdout, &stderr, func(string) (client.Client, error) {
+			return nil, errors.New("no kubeconfig")
+		})
+
+		// then
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "no kubeconfig")
+	})
+}
+
+func newScheme(t *testing.T) *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+	require.NoError(t, gardener.AddToScheme(scheme))
+	return scheme
+}
+
+func fixRuntime() *imv1.Runtime {
+	return &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runtime-a",
+			Namespace: defaultNamespace,
+			Labels:    map[string]string{imv1.LabelKymaRuntimeID: "runtime-a"},
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name:     "shoot-a",
+				Region:   "eu-central-1",
+				Provider: imv1.Provider{Type: "aws"},
+			},
+		},
+		Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady},
+	}
+}
@@ -22,14 +22,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
-	gardenerapis "github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1"
 	"github.com/go-logr/logr"
 	validator "github.com/go-playground/validator/v10"
 	configctrl "github.com/kyma-project/infrastructure-manager/internal/controller/configreload"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/configrollout"
 	"github.com/kyma-project/infrastructure-manager/internal/rtbootstrapper"
+	webhookv1 "github.com/kyma-project/infrastructure-manager/internal/webhook/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/extensions"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -48,20 +51,31 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	gardeneroidc "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
 	infrastructuremanagerv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	infrastructuremanagerv2 "github.com/kyma-project/infrastructure-manager/api/v2"
 	kubeconfigcontroller "github.com/kyma-project/infrastructure-manager/internal/controller/kubeconfig"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	notificationcontroller "github.com/kyma-project/infrastructure-manager/internal/controller/notification"
 	registrycachecontroller "github.com/kyma-project/infrastructure-manager/internal/controller/registrycache"
 	runtimecontroller "github.com/kyma-project/infrastructure-manager/internal/controller/runtime"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/sharding"
 	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
 	auditlogv1 "github.com/kyma-project/infrastructure-manager/pkg/auditlog/v1beta1"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/errorcatalog"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/kubeconfig"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/landscape"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/token"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shootcache"
+	"github.com/kyma-project/infrastructure-manager/pkg/notification"
+	"github.com/kyma-project/infrastructure-manager/pkg/tracing"
 	kyma "github.com/kyma-project/lifecycle-manager/api/v1beta2"
 	registrycacheapi "github.com/kyma-project/registry-cache/api/v1beta1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -75,6 +89,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(infrastructuremanagerv1.AddToScheme(scheme))
+	utilruntime.Must(infrastructuremanagerv2.AddToScheme(scheme))
 	utilruntime.Must(rbacv1.AddToScheme(scheme))
 	utilruntime.Must(auditlogv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -98,7 +113,14 @@ const (
 	defaultStatusRequeueDelay                 = 1 * time.Second
 	defaultRegistryCacheListenerComponentName = "infrastructure-manager-registry-cache"
 	defaultRegistryCacheReconcilePeriod       = 60 * time.Minute
+	defaultConfigRolloutHealthTimeout         = 30 * time.Minute
 	defaultControlPlaneSystemNamespace        = "kcp-system"
+	defaultWebhookPort                        = 9443
+	defaultShardLeaseDuration                 = 30 * time.Second
+	defaultFailedRuntimeRetryInitialDelay     = 5 * time.Minute
+	defaultFailedRuntimeRetryMaxDelay         = 2 * time.Hour
+	defaultShootWatchResyncPeriod             = 10 * time.Minute
+	defaultTracingSamplingRatio               = 1.0
 )
 
 func main() {
@@ -107,6 +129,7 @@ func main() {
 	var probeAddr string
 	var gardenerKubeconfigPath string
 	var gardenerProjectName string
+	var gardenerLandscapesConfigPath string
 	var minimalRotationTimeRatio float64
 	var expirationTime time.Duration
 	var gardenerCtrlReconciliationTimeout time.Duration
@@ -115,7 +138,17 @@ func main() {
 	var runtimeCtrlGardenerRateLimiterBurst int
 	var runtimeCtrlWorkersCnt int
 	var gardenerClusterCtrlWorkersCnt int
+	var runtimeShards int
+	var shardIdentity string
+	var shardLeaseDuration time.Duration
 	var converterConfigFilepath string
+	var gardenerErrorCatalogPath string
+	var converterConfigReloadEnabled bool
+	var converterConfigMapName string
+	var configRolloutEnabled bool
+	var configRolloutCanarySelector string
+	var configRolloutWavePercentages string
+	var configRolloutHealthTimeout time.Duration
 	var auditLogMandatory bool
 	var dedicatedAuditLoggingEnabled bool
 	var registryCacheConfigControllerEnabled bool
@@ -134,6 +167,22 @@ func main() {
 	var runtimeBootstrapperSKRNamespace string
 	var registryCacheReconcilePeriod time.Duration
 	var statusRequeueDelay time.Duration
+	var deletionGracePeriod time.Duration
+	var shootDriftDetectionInterval time.Duration
+	var shootWatchEnabled bool
+	var shootWatchResyncPeriod time.Duration
+	var failedRuntimeRetryAttempts int
+	var failedRuntimeRetryInitialDelay time.Duration
+	var failedRuntimeRetryMaxDelay time.Duration
+	var cloudProfileValidationEnabled bool
+	var runtimeValidatingWebhookEnabled bool
+	var runtimeDefaultingWebhookEnabled bool
+	var runtimeConversionWebhookEnabled bool
+	var webhookPort int
+	var webhookCertDir string
+	var tracingOTLPEndpoint string
+	var notificationConfigPath string
+	var tracingSamplingRatio float64
 
 	//Kubebuilder related parameters:
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to. Monitoring and alerting tools can use this endpoint to collect application specific metrics during runtime")
@@ -143,7 +192,8 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	//Gardener related parameters:
 	flag.StringVar(&gardenerKubeconfigPath, "gardener-kubeconfig-path", "/gardener/kubeconfig/kubeconfig", "Path to the kubeconfig file by KIM to access the for Gardener cluster")
-	flag.StringVar(&gardenerProjectName, "gardener-project-name", "gardener-project", "Name of the Gardener project which is used for storing Shoot definitions")
+	flag.StringVar(&gardenerProjectName, "gardener-project-name", "gardener-project", "Name of the Gardener project which is used for storing Shoot definitions. The GardenerCluster controller uses it for GardenerCluster CRs without the Gardener project label")
+	flag.StringVar(&gardenerLandscapesConfigPath, "gardener-landscapes-config-path", "", "Path to the JSON file listing additional Gardener landscapes with their kubeconfig path, project, and client settings. Runtime CRs select a landscape with the kyma-project.io/gardener-landscape label. By default only the landscape configured with the gardener flags is used")
 
 	// Kubeconfig Controller specific parameters:
 	flag.Float64Var(&minimalRotationTimeRatio, "minimal-rotation-time", defaultMinimalRotationTimeRatio, "The ratio determines what is the minimal time that needs to pass to rotate the kubeconfig of Shoot clusters. "+
@@ -158,7 +208,26 @@ func main() {
 	flag.IntVar(&runtimeCtrlGardenerRateLimiterQPS, "gardener-ratelimiter-qps", defaultGardenerRateLimiterQPS, "Gardener client rate limiter QPS (queries per seconds) for Runtime Controller. The queries per second has direct impact on the load produced for the Gardener cluster (see https://cloud.google.com/config-connector/docs/how-to/customize-controller-manager-rate-limit)")
 	flag.IntVar(&runtimeCtrlGardenerRateLimiterBurst, "gardener-ratelimiter-burst", defaultGardenerRateLimiterBurst, "Gardener client rate limiter burst for Runtime Controller. The burst value allows for more requests than the qps limit for short periods (see https://cloud.google.com/config-connector/docs/how-to/customize-controller-manager-rate-limit)")
 	flag.IntVar(&runtimeCtrlWorkersCnt, "runtime-ctrl-workers-cnt", defaultRuntimeCtrlWorkersCnt, "Number of workers running in parallel for Runtime Controller. The number of parallel workers has an impact on the amount of requests send to the Gardener cluster")
+	flag.IntVar(&runtimeShards, "runtime-shards", 0, "Number of shards the runtime IDs are distributed to. When greater than zero, every replica reconciles the Runtime and GardenerCluster CRs of the shards it owns, regardless of the leader election. Must be the same for all replicas. By default, the leader reconciles all runtimes")
+	flag.StringVar(&shardIdentity, "shard-identity", "", "Identity of the replica in the shard coordination. Defaults to the host name")
+	flag.DurationVar(&shardLeaseDuration, "shard-lease-duration", defaultShardLeaseDuration, "Duration after which the shards of a replica which stopped renewing its Leases are taken over by the other replicas")
 	flag.StringVar(&converterConfigFilepath, "converter-config-filepath", "/converter-config/converter_config.json", "File path to the gardener shoot converter configuration.")
+	flag.StringVar(&gardenerErrorCatalogPath, "gardener-error-catalog-path", "", "File path to the Gardener error catalog which maps the errors of failed Shoot operations to Runtime condition reasons, remediation texts, and retry policies. By default, the built-in catalog is used")
+	flag.BoolVar(&converterConfigReloadEnabled, "converter-config-reload-enabled", false, "Feature flag to reload the gardener shoot converter configuration when its ConfigMap is updated. Invalid updates are rejected and the current configuration is kept")
+	flag.StringVar(&converterConfigMapName, "converter-config-map-name", "infrastructure-manager-converter-config", "Name of the ConfigMap containing the gardener shoot converter configuration. The key of the configuration is the file name from --converter-config-filepath")
+	flag.BoolVar(&configRolloutEnabled, "config-rollout-enabled", false, "Feature flag to roll out changes of the watched configuration resources in waves. When enabled, the config reload watcher creates a ConfigRollout instead of reconciling all runtimes at once")
+	flag.StringVar(&configRolloutCanarySelector, "config-rollout-canary-selector", "", "Label selector of the runtimes reconciled in the first wave of a configuration rollout")
+	flag.StringVar(&configRolloutWavePercentages, "config-rollout-wave-percentages", "", "Comma separated list of the cumulative percentages of runtimes reconciled in the waves following the canary wave of a configuration rollout, for example 10,50")
+	flag.DurationVar(&configRolloutHealthTimeout, "config-rollout-health-timeout", defaultConfigRolloutHealthTimeout, "Time in which the runtimes of a configuration rollout wave must become Ready again. The rollout is paused when the timeout is exceeded")
+	flag.DurationVar(&deletionGracePeriod, "deletion-grace-period", 0, "Time which has to pass after a Runtime CR was deleted before its Shoot is deleted. During this period the Runtime is kept in the DeletionScheduled state and the deletion can be blocked with the deletion protection annotation. By default the Shoot is deleted immediately")
+	flag.DurationVar(&shootDriftDetectionInterval, "shoot-drift-detection-interval", 0, "Interval in which the Shoots of Ready runtimes are compared with the Shoots generated for the Runtime CRs. Drifted fields are reported with the ShootDrifted condition. By default the drift detection is disabled")
+	flag.BoolVar(&shootWatchEnabled, "shoot-watch-enabled", false, "Feature flag to watch the Shoots in the Gardener projects with an informer. When enabled, Runtime CRs are reconciled when the last operation or the generation of their Shoot changes, and the Shoots are read from the informer cache instead of the Gardener API")
+	flag.DurationVar(&shootWatchResyncPeriod, "shoot-watch-resync-period", defaultShootWatchResyncPeriod, "Period in which runtimes waiting for the creation, reconciliation, or deletion of their Shoot are reconciled when the Shoot watch is enabled. It's a safety net for missed watch events and replaces the fixed polling intervals of the Shoots in the Gardener projects watched since the start")
+	flag.IntVar(&failedRuntimeRetryAttempts, "failed-runtime-retry-attempts", 0, "Maximal number of automatic retries of a Runtime in the Failed state with a retryable failure reason. The counter is reset when the Runtime becomes Ready or its Runtime CR is changed. By default failed runtimes are not retried")
+	flag.DurationVar(&failedRuntimeRetryInitialDelay, "failed-runtime-retry-initial-delay", defaultFailedRuntimeRetryInitialDelay, "Delay before the first automatic retry of a failed Runtime. The delay is doubled with every retry")
+	flag.DurationVar(&failedRuntimeRetryMaxDelay, "failed-runtime-retry-max-delay", defaultFailedRuntimeRetryMaxDelay, "Maximal delay between the automatic retries of a failed Runtime")
+	flag.StringVar(&notificationConfigPath, "notification-config-path", "", "Path to the JSON file listing the HTTP sinks which receive CloudEvents about the lifecycle transitions of Runtime CRs, with their signing keys and the retry policy. By default no notifications are sent")
+	flag.BoolVar(&cloudProfileValidationEnabled, "cloud-profile-validation-enabled", false, "Feature flag to validate the Kubernetes version, region, zones, machine types and machine images of Shoots against their CloudProfile before the Shoots are created or patched")
 	flag.DurationVar(&statusRequeueDelay, "status-requeue-delay", defaultStatusRequeueDelay, "Delay applied when the FSM re-enqueues itself after writing Runtime status. A small non-zero value lets the informer cache observe the status write before the next reconcile, avoiding 409 conflicts caused by reading a stale resourceVersion. Must be greater than zero: setting this to 0 disables re-enqueue and stalls the FSM.")
 
 	// Registry cache specific parameters:
@@ -172,6 +241,17 @@ func main() {
 	flag.BoolVar(&runtimeBootstrapperEnabled, "runtime-bootstrapper-enabled", false, "Feature flag to enable runtime bootstrapper")
 	flag.BoolVar(&apiServerAclEnabled, "api-server-acl-enabled", false, "Feature flag to enable the shoot API server ACL extender which restricts access to the API server to a defined set of CIDRs")
 	flag.BoolVar(&networkRestrictionGlobalEnabled, "network-restriction-enabled", true, "Feature flag to enable network restriction on the project scope")
+	flag.BoolVar(&runtimeValidatingWebhookEnabled, "runtime-validating-webhook-enabled", false, "Feature flag to enable the validating admission webhook for Runtime CRs. When enabled, Runtime CRs which cannot be converted into a Gardener Shoot are rejected at admission time")
+	flag.BoolVar(&runtimeDefaultingWebhookEnabled, "runtime-defaulting-webhook-enabled", false, "Feature flag to enable the defaulting admission webhook for Runtime CRs. When enabled, defaults from the converter configuration are written into the spec of newly created Runtime CRs")
+	flag.BoolVar(&runtimeConversionWebhookEnabled, "runtime-conversion-webhook-enabled", false, "Feature flag to enable the conversion webhook serving the v2 version of the Runtime API. Must be enabled when the Runtime CRD uses the Webhook conversion strategy")
+
+	// Tracing configuration
+	flag.StringVar(&tracingOTLPEndpoint, "tracing-otlp-endpoint", "", "URL of the OTLP/HTTP endpoint the traces of the Runtime reconciliations are exported to, for example http://otel-collector.kyma-system:4318. By default the tracing is disabled")
+	flag.Float64Var(&tracingSamplingRatio, "tracing-sampling-ratio", defaultTracingSamplingRatio, "Ratio of the Runtime reconciliations which are traced, between 0 and 1")
+
+	// Webhook server configuration
+	flag.IntVar(&webhookPort, "webhook-port", defaultWebhookPort, "Port the webhook server listens on")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "Directory containing the TLS certificate (tls.crt) and key (tls.key) of the webhook server")
 
 	// Runtime bootstrapper configuration
 	flag.StringVar(&runtimeBootstrapperManifestsConfigMapName, "runtime-bootstrapper-manifests-config-map-name", "runtime-bootstrapper-manifests", "Config map with Runtime Bootstrapper manifests.")
@@ -199,7 +279,47 @@ func main() {
 		os.Exit(1)
 	}
 
-	restConfig := ctrl.GetConfigOrDie()
+	if runtimeShards < 0 {
+		setupLog.Error(nil, "invalid --runtime-shards; must not be negative", "value", runtimeShards)
+		os.Exit(1)
+	}
+
+	if runtimeShards > 0 && shardLeaseDuration < time.Second {
+		setupLog.Error(nil, "invalid --shard-lease-duration; must be at least one second", "value", shardLeaseDuration)
+		os.Exit(1)
+	}
+
+	if shootWatchEnabled && shootWatchResyncPeriod <= 0 {
+		setupLog.Error(nil, "invalid --shoot-watch-resync-period; must be greater than zero", "value", shootWatchResyncPeriod)
+		os.Exit(1)
+	}
+
+	if failedRuntimeRetryAttempts < 0 {
+		setupLog.Error(nil, "invalid --failed-runtime-retry-attempts; must not be negative", "value", failedRuntimeRetryAttempts)
+		os.Exit(1)
+	}
+
+	if failedRuntimeRetryAttempts > 0 && (failedRuntimeRetryInitialDelay <= 0 || failedRuntimeRetryMaxDelay < failedRuntimeRetryInitialDelay) {
+		setupLog.Error(nil, "invalid failed runtime retry delays; the initial delay must be greater than zero and not greater than the maximal delay",
+			"initialDelay", failedRuntimeRetryInitialDelay, "maxDelay", failedRuntimeRetryMaxDelay)
+		os.Exit(1)
+	}
+
+	if tracingSamplingRatio < 0 || tracingSamplingRatio > 1 {
+		setupLog.Error(nil, "invalid --tracing-sampling-ratio; must be between 0 and 1", "value", tracingSamplingRatio)
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		OTLPEndpoint:  tracingOTLPEndpoint,
+		SamplingRatio: tracingSamplingRatio,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to initialize tracing")
+		os.Exit(1)
+	}
+
+	restConfig := tracing.InstrumentRestConfig(ctrl.GetConfigOrDie())
 
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Metrics: metricsserver.Options{
@@ -211,6 +331,10 @@ func main() {
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "f1c68560.kyma-project.io",
 		Cache:                  restrictWatchedNamespace(dedicatedAuditLoggingEnabled),
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -228,58 +352,45 @@ func main() {
 		os.Exit(1)
 	}
 
-	gardenerNamespace := fmt.Sprintf("garden-%s", gardenerProjectName)
-	gardenerClient, shootClient, dynamicKubeconfigClient, err := initGardenerClients(gardenerKubeconfigPath, gardenerNamespace, runtimeCtrlGardenerRequestTimeout, runtimeCtrlGardenerRateLimiterQPS, runtimeCtrlGardenerRateLimiterBurst)
-
-	if err != nil {
-		setupLog.Error(err, "unable to initialize gardener clients", "controller", "GardenerCluster")
-		os.Exit(1)
-	}
-
-	kubeconfigProvider := kubeconfig.NewKubeconfigProvider(
-		shootClient,
-		dynamicKubeconfigClient,
-		gardenerNamespace,
-		int64(expirationTime.Seconds()))
-
-	rotationPeriod := time.Duration(minimalRotationTimeRatio*expirationTime.Minutes()) * time.Minute
 	metrics := metrics.NewMetrics()
-	if err = kubeconfigcontroller.NewGardenerClusterController(
-		mgr,
-		kubeconfigProvider,
-		logger,
-		rotationPeriod,
-		minimalRotationTimeRatio,
-		gardenerCtrlReconciliationTimeout,
-		metrics,
-	).SetupWithManager(mgr, gardenerClusterCtrlWorkersCnt); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "GardenerCluster")
-		os.Exit(1)
-	}
 
 	// load converter configuration
 	getReader := func() (io.Reader, error) {
 		return os.Open(converterConfigFilepath)
 	}
-	var config config.Config
-	if err = config.Load(getReader); err != nil {
-		setupLog.Error(err, "unable to load converter configuration")
-		os.Exit(1)
-	}
 
-	validate := validator.New(validator.WithRequiredStructEnabled())
-	if err = validate.Struct(config); err != nil {
-		setupLog.Error(err, "invalid converter configuration")
-		os.Exit(1)
+	var configMutators []func(*config.Config)
+	if runtimeBootstrapperEnabled && runtimeBootstrapperKCPClusterTrustBundle != "" {
+		// ClusterTrustBundle is a beta feature and needs to be explicitly enabled in the converter config
+		// When the feature is generally available, this code can be removed
+		// As of the time of writing (December 2025) there is no GA release date announced
+		// Details: https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#cluster-trust-bundles
+		configMutators = append(configMutators, func(cfg *config.Config) {
+			enableClusterTrustBundleFeatureForSKR(&cfg.ConverterConfig)
+		})
 	}
 
-	if apiServerAclEnabled {
-		if config.ConverterConfig.Kubernetes.KubeApiServer.ACL.ConfigMapName == "" {
-			setupLog.Error(fmt.Errorf("acl configMap name need to be set when API server ACL is enabled"), "invalid API server ACL configuration")
-			os.Exit(1)
+	var startupConfig *config.Config
+	configHolder := config.NewHolder(func(cfg config.Config) error {
+		if err := validateConverterConfig(cfg, apiServerAclEnabled); err != nil {
+			return err
+		}
+
+		if startupConfig == nil {
+			return nil
 		}
+		return validateConverterConfigReload(*startupConfig, cfg, cloudProfileValidationEnabled)
+	}, configMutators...)
+
+	if err = configHolder.Load(getReader); err != nil {
+		setupLog.Error(err, "unable to load converter configuration")
+		os.Exit(1)
 	}
 
+	config := configHolder.Get()
+	startupConfig = &config
+	metrics.SetConverterConfigHash(configHolder.Hash())
+
 	auditLogSharedConfig, err := auditlog.LoadConfiguration(config.ConverterConfig.AuditLog.TenantConfigPath)
 	if err != nil {
 		setupLog.Error(err, "invalid audit log tenant configuration")
@@ -294,10 +405,15 @@ func main() {
 		defaultControlPlaneSystemNamespace,
 	)
 
-	_, err = token.ValidateTokenExpirationTime(config.ConverterConfig.Kubernetes.KubeApiServer.MaxTokenExpiration)
-	if err != nil {
-		setupLog.Error(err, "invalid token expiration format in converter configuration")
-		os.Exit(1)
+	if runtimeValidatingWebhookEnabled || runtimeDefaultingWebhookEnabled || runtimeConversionWebhookEnabled {
+		webhookOpts := webhookv1.WebhookOptions{
+			ValidationEnabled: runtimeValidatingWebhookEnabled,
+			DefaultingEnabled: runtimeDefaultingWebhookEnabled,
+		}
+		if err = webhookv1.SetupRuntimeWebhookWithManager(mgr, configHolder, webhookOpts); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Runtime")
+			os.Exit(1)
+		}
 	}
 
 	// build a shared scheme used for runtime clients to avoid concurrent AddToScheme calls
@@ -308,7 +424,16 @@ func main() {
 
 	var runtimeBootstrapperInstaller *rtbootstrapper.Installer
 
-	if apiServerAclEnabled || runtimeBootstrapperEnabled {
+	runtimePredicate := func(configObject types.NamespacedName, runtime infrastructuremanagerv1.Runtime) bool {
+		if configObject.Name == config.ConverterConfig.Kubernetes.KubeApiServer.ACL.ConfigMapName {
+			return extensions.AclNeedsToBeEnabled(apiServerAclEnabled, runtime)
+		}
+		return true
+	}
+
+	var converterConfigLoader *configctrl.ConverterConfigLoader
+
+	if apiServerAclEnabled || runtimeBootstrapperEnabled || converterConfigReloadEnabled {
 		var secretPredicates []configctrl.ObjectUpdatedPredicate
 		var configMapPredicates []configctrl.ObjectUpdatedPredicate
 		var clusterTrustBundlePredicate *configctrl.ObjectUpdatedPredicate
@@ -321,14 +446,6 @@ func main() {
 		}
 
 		if runtimeBootstrapperEnabled {
-			if runtimeBootstrapperKCPClusterTrustBundle != "" {
-				// ClusterTrustBundle is a beta feature and needs to be explicitly enabled in the converter config
-				// When the feature is generally available, this code can be removed
-				// As of the time of writing (December 2025) there is no GA release date announced
-				// Details: https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/#cluster-trust-bundles
-				enableClusterTrustBundleFeatureForSKR(&config.ConverterConfig)
-			}
-
 			rtbConfig := rtbootstrapper.Config{
 				KCPConfig: rtbootstrapper.KCPConfig{
 					PullSecretName:         runtimeBootstrapperKCPPullSecretName,
@@ -385,24 +502,155 @@ func main() {
 			}})
 		}
 
+		if converterConfigReloadEnabled {
+			converterConfigLoader = &configctrl.ConverterConfigLoader{
+				ConfigMap: types.NamespacedName{
+					Name:      converterConfigMapName,
+					Namespace: defaultControlPlaneSystemNamespace,
+				},
+				Key:     filepath.Base(converterConfigFilepath),
+				Holder:  configHolder,
+				Metrics: metrics,
+				Staged:  configRolloutEnabled,
+			}
+		}
+
+		var rolloutTemplate *infrastructuremanagerv1.ConfigRolloutSpec
+		if configRolloutEnabled {
+			template, err := configrollout.NewTemplate(configRolloutCanarySelector, configRolloutWavePercentages, configRolloutHealthTimeout)
+			if err != nil {
+				setupLog.Error(err, "invalid config rollout configuration")
+				os.Exit(1)
+			}
+			rolloutTemplate = &template
+		}
+
 		if err = (&configctrl.ConfigReloadWatcher{
 			KcpClient:                   kcpClient,
 			Namespace:                   defaultControlPlaneSystemNamespace,
 			ConfigMapPredicates:         configMapPredicates,
 			SecretPredicates:            secretPredicates,
 			ClusterTrustBundlePredicate: clusterTrustBundlePredicate,
-			RuntimePredicate: func(configObject types.NamespacedName, runtime infrastructuremanagerv1.Runtime) bool {
-				if configObject.Name == config.ConverterConfig.Kubernetes.KubeApiServer.ACL.ConfigMapName {
-					return extensions.AclNeedsToBeEnabled(apiServerAclEnabled, runtime)
-				}
-				return true
-			},
+			RuntimePredicate:            runtimePredicate,
+			ConverterConfigLoader:       converterConfigLoader,
+			RolloutTemplate:             rolloutTemplate,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ConfigReloadWatcher")
 			os.Exit(1)
 		}
 	}
 
+	if configRolloutEnabled {
+		if err = (&configrollout.ConfigRolloutReconciler{
+			KcpClient:             mgr.GetClient(),
+			RuntimeReader:         mgr.GetAPIReader(),
+			Namespace:             defaultControlPlaneSystemNamespace,
+			RuntimePredicate:      runtimePredicate,
+			ConverterConfigLoader: converterConfigLoader,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ConfigRollout")
+			os.Exit(1)
+		}
+	}
+
+	var cloudProfileReader client.Reader
+	var cloudProfileValidator *cloudprofile.Validator
+	if cloudProfileValidationEnabled || !config.ConverterConfig.MachineImage.IsPinned() {
+		cloudProfileReader, err = initCloudProfileCache(gardenerKubeconfigPath, mgr)
+		if err != nil {
+			setupLog.Error(err, "unable to initialize CloudProfile cache")
+			os.Exit(1)
+		}
+
+		cloudProfileValidator = cloudprofile.NewValidator(cloudProfileReader)
+	}
+
+	gardenerNamespace := fmt.Sprintf("garden-%s", gardenerProjectName)
+	gardenerClient, dynamicKubeconfigClient, err := initGardenerClients(gardenerKubeconfigPath, runtimeCtrlGardenerRequestTimeout, runtimeCtrlGardenerRateLimiterQPS, runtimeCtrlGardenerRateLimiterBurst)
+
+	if err != nil {
+		setupLog.Error(err, "unable to initialize gardener clients", "controller", "GardenerCluster")
+		os.Exit(1)
+	}
+
+	defaultLandscape := landscape.Landscape{
+		Client: gardenerClient,
+		KubeconfigProvider: kubeconfig.NewKubeconfigProvider(
+			gardenerClient,
+			dynamicKubeconfigClient,
+			gardenerNamespace,
+			int64(expirationTime.Seconds())),
+		CloudProfileReader: cloudProfileReader,
+	}
+
+	if shootWatchEnabled {
+		var gardenerNamespaces []string
+		for _, gardenerProject := range config.ConverterConfig.Gardener.AllProjects() {
+			gardenerNamespaces = append(gardenerNamespaces, project.Namespace(gardenerProject))
+		}
+
+		defaultLandscape.ShootCache, err = initShootCache(gardenerKubeconfigPath, mgr, gardenerNamespaces)
+		if err != nil {
+			setupLog.Error(err, "unable to initialize Shoot cache")
+			os.Exit(1)
+		}
+	}
+
+	var additionalLandscapes []landscape.Landscape
+	if gardenerLandscapesConfigPath != "" {
+		additionalLandscapes, err = initAdditionalLandscapes(gardenerLandscapesConfigPath, mgr, cloudProfileReader != nil, shootWatchEnabled, landscapeClientDefaults{
+			timeout:             runtimeCtrlGardenerRequestTimeout,
+			qps:                 runtimeCtrlGardenerRateLimiterQPS,
+			burst:               runtimeCtrlGardenerRateLimiterBurst,
+			expirationInSeconds: int64(expirationTime.Seconds()),
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to initialize gardener landscapes")
+			os.Exit(1)
+		}
+	}
+
+	landscapes, err := landscape.NewRegistry(defaultLandscape, additionalLandscapes...)
+	if err != nil {
+		setupLog.Error(err, "invalid gardener landscape configuration")
+		os.Exit(1)
+	}
+	setupLog.Info("Gardener landscapes configured", "landscapes", landscapes.Names())
+
+	var shards *sharding.Coordinator
+	if runtimeShards > 0 {
+		shards, err = initShardCoordinator(mgr, runtimeShards, shardIdentity, shardLeaseDuration, logger)
+		if err != nil {
+			setupLog.Error(err, "unable to initialize shard coordination")
+			os.Exit(1)
+		}
+	}
+
+	rotationPeriod := time.Duration(minimalRotationTimeRatio*expirationTime.Minutes()) * time.Minute
+	gardenerClusterController := kubeconfigcontroller.NewGardenerClusterController(
+		mgr,
+		landscapes,
+		logger,
+		rotationPeriod,
+		minimalRotationTimeRatio,
+		gardenerCtrlReconciliationTimeout,
+		metrics,
+	)
+	gardenerClusterController.Shards = shards
+	if err = gardenerClusterController.SetupWithManager(mgr, gardenerClusterCtrlWorkersCnt); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GardenerCluster")
+		os.Exit(1)
+	}
+
+	errorCatalog := errorcatalog.Default()
+	if gardenerErrorCatalogPath != "" {
+		errorCatalog, err = errorcatalog.LoadFile(gardenerErrorCatalogPath)
+		if err != nil {
+			setupLog.Error(err, "unable to load the Gardener error catalog", "path", gardenerErrorCatalogPath)
+			os.Exit(1)
+		}
+	}
+
 	cfg := fsm.RCCfg{
 		GardenerRequeueDuration:              defaultGardenerRequeueDuration,
 		RequeueDurationShootCreate:           defaultShootCreateRequeueDuration,
@@ -410,8 +658,10 @@ func main() {
 		RequeueDurationShootReconcile:        defaultShootReconcileRequeueDuration,
 		ControlPlaneRequeueDuration:          defaultControlPlaneRequeueDuration,
 		StatusRequeueDelay:                   statusRequeueDelay,
+		DeletionGracePeriod:                  deletionGracePeriod,
+		ShootDriftDetectionInterval:          shootDriftDetectionInterval,
+		ShootWatchResyncPeriod:               shootWatchResyncPeriod,
 		Finalizer:                            infrastructuremanagerv1.Finalizer,
-		ShootNamesapace:                      gardenerNamespace,
 		Config:                               config,
 		AuditLogMandatory:                    auditLogMandatory,
 		DedicatedAuditLoggingEnabled:         dedicatedAuditLoggingEnabled,
@@ -422,17 +672,36 @@ func main() {
 		RegistryCacheConfigControllerEnabled: registryCacheConfigControllerEnabled,
 		RuntimeBootstrapperEnabled:           runtimeBootstrapperEnabled,
 		RuntimeBootstrapperInstaller:         runtimeBootstrapperInstaller,
+		CloudProfileValidationEnabled:        cloudProfileValidationEnabled,
+		CloudProfileValidator:                cloudProfileValidator,
+		CloudProfileReader:                   cloudProfileReader,
+		ConfigHolder:                         configHolder,
+		ErrorCatalog:                         errorCatalog,
+		FailureRecovery: fsm.FailureRecoveryConfig{
+			MaxAttempts:  failedRuntimeRetryAttempts,
+			InitialDelay: failedRuntimeRetryInitialDelay,
+			MaxDelay:     failedRuntimeRetryMaxDelay,
+		},
+	}
+
+	if notificationConfigPath != "" {
+		cfg.Notifier, err = initNotifications(notificationConfigPath, mgr, shards, logger)
+		if err != nil {
+			setupLog.Error(err, "unable to initialize notifications")
+			os.Exit(1)
+		}
 	}
 
 	runtimeReconciler := runtimecontroller.NewRuntimeReconciler(
 		mgr,
-		gardenerClient,
+		landscapes,
 		runtimeClientGetter,
 		runtimeBootstrapperInstaller,
 		logger,
 		cfg,
 	)
 
+	runtimeReconciler.Shards = shards
 	if err = runtimeReconciler.SetupWithManager(mgr, runtimeCtrlWorkersCnt); err != nil {
 		setupLog.Error(err, "unable to setup controller with Manager", "controller", "Runtime")
 		os.Exit(1)
@@ -468,8 +737,15 @@ func main() {
 
 	setupLog.Info("Starting Manager", "kubeconfigExpirationTime", expirationTime, "kubeconfigRotationPeriod", rotationPeriod)
 
-	if err := mgr.Start(ctx); err != nil {
-		setupLog.Error(err, "problem running manager")
+	mgrErr := mgr.Start(ctx)
+
+	// flush the spans of the last reconciliations
+	if err = shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+
+	if mgrErr != nil {
+		setupLog.Error(mgrErr, "problem running manager")
 		os.Exit(1)
 	}
 }
@@ -485,34 +761,195 @@ func CreateRuntimeScheme() *runtime.Scheme {
 	return prebuiltRuntimeScheme
 }
 
-func initGardenerClients(kubeconfigPath string, namespace string, timeout time.Duration, rlQPS, rlBurst int) (client.Client, gardenerapis.ShootInterface, client.SubResourceClient, error) {
+func initCloudProfileCache(kubeconfigPath string, mgr ctrl.Manager) (client.Reader, error) {
 	restConfig, err := gardener.NewRestConfigFromFile(kubeconfigPath)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
-	restConfig.Timeout = timeout
-	restConfig.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(rlQPS), rlBurst)
+	cloudProfileCache, err := cloudprofile.NewCache(context.Background(), restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mgr.Add(cloudProfileCache); err != nil {
+		return nil, err
+	}
+
+	return cloudProfileCache, nil
+}
+
+// initShootCache creates the cache of the Shoots in the namespaces which triggers the reconciliation of the runtimes
+func initShootCache(kubeconfigPath string, mgr ctrl.Manager, namespaces []string) (*shootcache.Cache, error) {
+	restConfig, err := gardener.NewRestConfigFromFile(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	shootCache, err := shootcache.NewCache(context.Background(), restConfig, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = mgr.Add(shootCache); err != nil {
+		return nil, err
+	}
+
+	return shootCache, nil
+}
+
+// initShardCoordinator creates the coordination of the runtime shards among the replicas
+func initShardCoordinator(mgr ctrl.Manager, shards int, identity string, leaseDuration time.Duration, logger logr.Logger) (*sharding.Coordinator, error) {
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to determine the shard identity")
+		}
+		identity = hostname
+	}
+
+	coordinator := sharding.NewCoordinator(sharding.Config{
+		Shards:        shards,
+		Namespace:     defaultControlPlaneSystemNamespace,
+		Identity:      identity,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: leaseDuration * 2 / 3,
+		RenewInterval: leaseDuration / 3,
+		Elected:       mgr.Elected(),
+	}, mgr.GetClient(), mgr.GetAPIReader(), logger.WithName("sharding"))
+
+	if err := mgr.Add(coordinator); err != nil {
+		return nil, errors.Wrap(err, "failed to add the shard coordinator to the manager")
+	}
+	setupLog.Info("Runtime sharding enabled", "shards", shards, "identity", identity)
+	return coordinator, nil
+}
+
+// initNotifications creates the outbox of the notifications and the controller delivering them to the sinks
+func initNotifications(configPath string, mgr ctrl.Manager, shards *sharding.Coordinator, logger logr.Logger) (*notification.Outbox, error) {
+	notificationConfig, err := notification.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	retryPolicy, err := notificationConfig.RetryPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	sinks := make(map[string]notificationcontroller.Deliverer, len(notificationConfig.Sinks))
+	for _, sinkConfig := range notificationConfig.Sinks {
+		sink, err := notification.NewSink(sinkConfig)
+		if err != nil {
+			return nil, err
+		}
+		sinks[sinkConfig.Name] = sink
+	}
+
+	if err = (&notificationcontroller.NotificationReconciler{
+		KcpClient:   mgr.GetClient(),
+		Sinks:       sinks,
+		RetryPolicy: retryPolicy,
+		Log:         logger.WithName("notification"),
+		Shards:      shards,
+	}).SetupWithManager(mgr); err != nil {
+		return nil, errors.Wrap(err, "failed to create the notification controller")
+	}
+
+	setupLog.Info("Runtime notifications enabled", "sinks", len(sinks))
+	return &notification.Outbox{
+		KcpClient: mgr.GetClient(),
+		Namespace: defaultControlPlaneSystemNamespace,
+		Sinks:     notificationConfig.Sinks,
+	}, nil
+}
+
+type landscapeClientDefaults struct {
+	timeout             time.Duration
+	qps                 int
+	burst               int
+	expirationInSeconds int64
+}
+
+// initAdditionalLandscapes creates the clients for the Gardener landscapes from the landscape configuration file
+func initAdditionalLandscapes(configPath string, mgr ctrl.Manager, cloudProfilesEnabled, shootWatchEnabled bool, defaults landscapeClientDefaults) ([]landscape.Landscape, error) {
+	configs, err := landscape.LoadConfigs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	landscapes := make([]landscape.Landscape, 0, len(configs))
+	for _, cfg := range configs {
+		timeout, err := cfg.Timeout(defaults.timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		qps, burst := cfg.ClientQPS, cfg.ClientBurst
+		if qps == 0 {
+			qps = defaults.qps
+		}
+		if burst == 0 {
+			burst = defaults.burst
+		}
+
+		gardenerClient, dynamicKubeconfigClient, err := initGardenerClients(cfg.KubeconfigPath, timeout, qps, burst)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create the client of landscape %s", cfg.Name)
+		}
+
+		gardenerLandscape := landscape.Landscape{
+			Name:        cfg.Name,
+			ProjectName: cfg.ProjectName,
+			Client:      gardenerClient,
+			KubeconfigProvider: kubeconfig.NewKubeconfigProvider(
+				gardenerClient,
+				dynamicKubeconfigClient,
+				fmt.Sprintf("garden-%s", cfg.ProjectName),
+				defaults.expirationInSeconds),
+		}
+
+		if cloudProfilesEnabled {
+			gardenerLandscape.CloudProfileReader, err = initCloudProfileCache(cfg.KubeconfigPath, mgr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create the CloudProfile cache of landscape %s", cfg.Name)
+			}
+		}
+
+		if shootWatchEnabled {
+			gardenerLandscape.ShootCache, err = initShootCache(cfg.KubeconfigPath, mgr, []string{project.Namespace(cfg.ProjectName)})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create the Shoot cache of landscape %s", cfg.Name)
+			}
+		}
 
-	gardenerClientSet, err := gardenerapis.NewForConfig(restConfig)
+		landscapes = append(landscapes, gardenerLandscape)
+	}
+	return landscapes, nil
+}
+
+func initGardenerClients(kubeconfigPath string, timeout time.Duration, rlQPS, rlBurst int) (client.Client, client.SubResourceClient, error) {
+	restConfig, err := gardener.NewRestConfigFromFile(kubeconfigPath)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
 
-	gardenerClient, err := client.New(restConfig, client.Options{})
+	restConfig.Timeout = timeout
+	restConfig.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(rlQPS), rlBurst)
+
+	gardenerClient, err := client.New(tracing.InstrumentRestConfig(restConfig), client.Options{})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
 
 	err = v1beta1.AddToScheme(gardenerClient.Scheme())
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, "failed to register Gardener schema")
+		return nil, nil, errors.Wrap(err, "failed to register Gardener schema")
 	}
 
-	shootClient := gardenerClientSet.Shoots(namespace)
 	dynamicKubeconfigAPI := gardenerClient.SubResource("adminkubeconfig")
 
-	return gardenerClient, shootClient, dynamicKubeconfigAPI, nil
+	return gardenerClient, dynamicKubeconfigAPI, nil
 }
 
 func refreshRuntimeMetrics(restConfig *rest.Config, logger logr.Logger, metrics metrics.Metrics) {
@@ -566,6 +1003,11 @@ func restrictWatchedNamespace(dedicatedAuditLoggingEnabled bool) cache.Options {
 					defaultControlPlaneSystemNamespace: {},
 				},
 			},
+			&infrastructuremanagerv1.ConfigRollout{}: {
+				Namespaces: map[string]cache.Config{
+					defaultControlPlaneSystemNamespace: {},
+				},
+			},
 		},
 	}
 
@@ -589,6 +1031,49 @@ func configureRuntimeBootstrapper(config rtbootstrapper.Config, runtimeClientGet
 	return rtbootstrapper.NewInstaller(config, kcpClient, runtimeClientGetter, fsm.NewRuntimeDynamicClientGetter(kcpClient)), nil
 }
 
+// validateConverterConfig is applied to the converter configuration on startup and on every reload.
+func validateConverterConfig(cfg config.Config, apiServerAclEnabled bool) error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	if err := validate.Struct(cfg); err != nil {
+		return errors.Wrap(err, "invalid converter configuration")
+	}
+
+	if apiServerAclEnabled && cfg.ConverterConfig.Kubernetes.KubeApiServer.ACL.ConfigMapName == "" {
+		return errors.New("acl configMap name need to be set when API server ACL is enabled")
+	}
+
+	if _, err := token.ValidateTokenExpirationTime(cfg.ConverterConfig.Kubernetes.KubeApiServer.MaxTokenExpiration); err != nil {
+		return errors.Wrap(err, "invalid token expiration format in converter configuration")
+	}
+
+	gardenerConfig := cfg.ConverterConfig.Gardener
+	for region, project := range gardenerConfig.Placement.Regions {
+		if !slices.Contains(gardenerConfig.AllProjects(), project) {
+			return fmt.Errorf("gardener project %s of region %s is not configured", project, region)
+		}
+	}
+
+	return nil
+}
+
+// validateConverterConfigReload rejects changes of the settings which are read only on startup.
+func validateConverterConfigReload(startupConfig, cfg config.Config, cloudProfileValidationEnabled bool) error {
+	if cfg.ConverterConfig.Kubernetes.KubeApiServer.ACL.ConfigMapName != startupConfig.ConverterConfig.Kubernetes.KubeApiServer.ACL.ConfigMapName {
+		return errors.New("changing the acl configMap name requires a restart")
+	}
+
+	if cfg.ConverterConfig.AuditLog.TenantConfigPath != startupConfig.ConverterConfig.AuditLog.TenantConfigPath {
+		return errors.New("changing the audit log tenant configuration path requires a restart")
+	}
+
+	cloudProfileCacheEnabled := cloudProfileValidationEnabled || !startupConfig.ConverterConfig.MachineImage.IsPinned()
+	if !cloudProfileCacheEnabled && !cfg.ConverterConfig.MachineImage.IsPinned() {
+		return errors.New("changing the machine image version strategy from pinned requires a restart")
+	}
+
+	return nil
+}
+
 func enableClusterTrustBundleFeatureForSKR(converterConfig *config.ConverterConfig) {
 	if converterConfig.Kubernetes.KubeApiServer.FeatureGates == nil {
 		converterConfig.Kubernetes.KubeApiServer.FeatureGates = make(map[string]bool)
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/internal/migration"
+	"github.com/kyma-project/infrastructure-manager/internal/migration/credentialsbinding"
+	"github.com/kyma-project/infrastructure-manager/internal/migration/shootcredentialsbinding"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// migrations contains the available migrations, created for the Gardener client and the Gardener project namespace.
+var migrations = map[string]func(c client.Client, namespace string) migration.Migration{
+	credentialsbinding.Name: func(c client.Client, namespace string) migration.Migration {
+		return credentialsbinding.Migration{Client: c, Namespace: namespace}
+	},
+	shootcredentialsbinding.Name: func(c client.Client, namespace string) migration.Migration {
+		return shootcredentialsbinding.Migration{Client: c, Namespace: namespace}
+	},
+}
+
+func main() {
+	ctx := context.Background()
+
+	var migrationName string
+	var gardenerKubeconfigPath string
+	var gardenerProjectName string
+	var checkpointNamespace string
+	var restart bool
+	var dryRun bool
+
+	flag.StringVar(&migrationName, "migration", "", fmt.Sprintf("Name of the migration to run, one of: %s", strings.Join(migrationNames(), ", ")))
+	//Gardener related parameters:
+	flag.StringVar(&gardenerKubeconfigPath, "gardener-kubeconfig-path", "/gardener/kubeconfig/kubeconfig", "Path to the kubeconfig file for accessing the Gardener cluster")
+	flag.StringVar(&gardenerProjectName, "gardener-project-name", "gardener-project", "Name of the Gardener project which is used for storing Shoot definitions")
+	flag.StringVar(&checkpointNamespace, "checkpoint-namespace", "", "Namespace of the checkpoint ConfigMap in the Gardener cluster. Defaults to the Gardener project namespace")
+	flag.BoolVar(&restart, "restart", false, "Removes the checkpoint of the migration and processes all items again")
+	flag.BoolVar(&dryRun, "dry-run", true, "Indicates whether to perform a dry run or actually make changes")
+	flag.Parse()
+
+	newMigration, found := migrations[migrationName]
+	if !found {
+		log.Fatalf("unknown migration %q, use one of: %s", migrationName, strings.Join(migrationNames(), ", "))
+	}
+
+	cfg, err := clientcmd.BuildConfigFromFlags("", gardenerKubeconfigPath)
+	if err != nil {
+		log.Fatalf("failed to build kubeconfig: %v", err)
+	}
+
+	scheme := k8sruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gardener.AddToScheme(scheme))
+	utilruntime.Must(gardenersecurity.AddToScheme(scheme))
+
+	gardenerClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		log.Fatalf("failed to create gardener client: %v", err)
+	}
+
+	projectNamespace := "garden-" + gardenerProjectName
+	if checkpointNamespace == "" {
+		checkpointNamespace = projectNamespace
+	}
+
+	runner := migration.Runner{
+		Checkpoint: migration.Checkpoint{Client: gardenerClient, Namespace: checkpointNamespace},
+		DryRun:     dryRun,
+		Restart:    restart,
+		Out:        os.Stdout,
+	}
+
+	report, err := runner.Run(ctx, newMigration(gardenerClient, projectNamespace))
+	if err != nil {
+		log.Fatalf("migration %s failed: %v", migrationName, err)
+	}
+	report.Print(os.Stdout, migrationName, dryRun)
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func migrationNames() []string {
+	names := make([]string, 0, len(migrations))
+	for name := range migrations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
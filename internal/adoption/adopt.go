@@ -0,0 +1,158 @@
+package adoption
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// AdoptedFromShootAnnotation marks Runtime CRs created for an existing Shoot
+const AdoptedFromShootAnnotation = "operator.kyma-project.io/adopted-from-shoot"
+
+const structuredAuthConfigKey = "config.yaml"
+
+type Options struct {
+	ShootName string
+	// GardenerNamespace is the namespace of the Gardener project
+	GardenerNamespace string
+	// Namespace is the KCP namespace of the Runtime CR
+	Namespace      string
+	RuntimeID      string
+	PlatformRegion string
+	// Labels are set on the Runtime CR, they must contain all labels required by KIM
+	Labels         map[string]string
+	Administrators []string
+	DryRun         bool
+}
+
+type Result struct {
+	Runtime  imv1.Runtime
+	Warnings []string
+}
+
+// Adopt creates a Runtime CR for a Shoot which was not created by KIM. The Runtime CR is created with the dry-run patch
+// annotation, so that KIM only reports the changes it would apply to the Shoot. The Shoot is patched
+// after the annotation is removed.
+func Adopt(ctx context.Context, gardenClient client.Reader, kcpClient client.Client, opts Options) (Result, error) {
+	var shoot gardener.Shoot
+	if err := gardenClient.Get(ctx, types.NamespacedName{Name: opts.ShootName, Namespace: opts.GardenerNamespace}, &shoot); err != nil {
+		return Result{}, errors.Wrap(err, "failed to get shoot")
+	}
+
+	if runtimeID, found := shoot.Annotations[extender.ShootRuntimeIDAnnotation]; found {
+		return Result{}, fmt.Errorf("shoot %s is already managed by KIM as Runtime %s", shoot.Name, runtimeID)
+	}
+
+	if err := checkNotAdopted(ctx, kcpClient, opts); err != nil {
+		return Result{}, err
+	}
+
+	authConfig, err := getAuthenticationConfiguration(ctx, gardenClient, shoot)
+	if err != nil {
+		return Result{}, err
+	}
+
+	runtimeShoot, warnings, err := ToRuntimeShoot(shoot, authConfig)
+	if err != nil {
+		return Result{}, err
+	}
+	runtimeShoot.PlatformRegion = opts.PlatformRegion
+
+	security, err := ToSecurity(shoot, opts.Administrators)
+	if err != nil {
+		return Result{}, err
+	}
+
+	labels := maps.Clone(opts.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[imv1.LabelKymaRuntimeID] = opts.RuntimeID
+	labels[imv1.LabelKymaShootName] = shoot.Name
+	if opts.PlatformRegion != "" {
+		labels[imv1.LabelKymaPlatformRegion] = opts.PlatformRegion
+	}
+
+	runtime := imv1.Runtime{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: imv1.GroupVersion.String(),
+			Kind:       "Runtime",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.RuntimeID,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				reconciler.DryRunPatchAnnotation: "true",
+				AdoptedFromShootAnnotation:       shoot.Namespace + "/" + shoot.Name,
+				imv1.AnnotationGardenerProject:   strings.TrimPrefix(shoot.Namespace, "garden-"),
+			},
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot:    runtimeShoot,
+			Security: security,
+		},
+	}
+
+	if err := runtime.ValidateRequiredLabels(); err != nil {
+		return Result{}, err
+	}
+
+	if !opts.DryRun {
+		if err := kcpClient.Create(ctx, &runtime); err != nil {
+			return Result{}, errors.Wrap(err, "failed to create Runtime")
+		}
+	}
+
+	return Result{Runtime: runtime, Warnings: warnings}, nil
+}
+
+// checkNotAdopted makes sure that no Runtime CR exists for the Shoot, otherwise two Runtime CRs would patch the same Shoot.
+func checkNotAdopted(ctx context.Context, kcpClient client.Reader, opts Options) error {
+	var runtimes imv1.RuntimeList
+	if err := kcpClient.List(ctx, &runtimes, client.InNamespace(opts.Namespace), client.MatchingLabels{imv1.LabelKymaShootName: opts.ShootName}); err != nil {
+		return errors.Wrap(err, "failed to list Runtimes")
+	}
+	if len(runtimes.Items) > 0 {
+		return fmt.Errorf("shoot %s is already adopted by Runtime %s", opts.ShootName, runtimes.Items[0].Name)
+	}
+
+	var existing imv1.Runtime
+	err := kcpClient.Get(ctx, types.NamespacedName{Name: opts.RuntimeID, Namespace: opts.Namespace}, &existing)
+	if err == nil {
+		return fmt.Errorf("runtime %s already exists", opts.RuntimeID)
+	}
+	return client.IgnoreNotFound(err)
+}
+
+func getAuthenticationConfiguration(ctx context.Context, gardenClient client.Reader, shoot gardener.Shoot) (*structuredauth.AuthenticationConfiguration, error) {
+	apiServer := shoot.Spec.Kubernetes.KubeAPIServer
+	if apiServer == nil || apiServer.StructuredAuthentication == nil || apiServer.StructuredAuthentication.ConfigMapName == "" {
+		return nil, nil
+	}
+
+	var configMap corev1.ConfigMap
+	key := types.NamespacedName{Name: apiServer.StructuredAuthentication.ConfigMapName, Namespace: shoot.Namespace}
+	if err := gardenClient.Get(ctx, key, &configMap); err != nil {
+		return nil, errors.Wrap(err, "failed to get structured authentication ConfigMap")
+	}
+
+	var authConfig structuredauth.AuthenticationConfiguration
+	if err := yaml.Unmarshal([]byte(configMap.Data[structuredAuthConfigKey]), &authConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to decode structured authentication configuration")
+	}
+	return &authConfig, nil
+}
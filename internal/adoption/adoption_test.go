@@ -0,0 +1,373 @@
+package adoption
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	gardenerNamespace = "garden-kyma"
+	kcpNamespace      = "kcp-system"
+)
+
+func TestToRuntimeShoot(t *testing.T) {
+	t.Run("Should convert the Shoot spec", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+
+		// when
+		runtimeShoot, warnings, err := ToRuntimeShoot(shoot, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.Equal(t, "shoot-a", runtimeShoot.Name)
+		assert.Equal(t, gardener.ShootPurposeProduction, runtimeShoot.Purpose)
+		assert.Equal(t, "eu-central-1", runtimeShoot.Region)
+		assert.Equal(t, "aws-binding", runtimeShoot.SecretBindingName)
+		assert.Equal(t, ptr.To("1.33.5"), runtimeShoot.Kubernetes.Version)
+		assert.Equal(t, "aws", runtimeShoot.Provider.Type)
+		assert.Equal(t, []gardener.Worker{shoot.Spec.Provider.Workers[0]}, runtimeShoot.Provider.Workers)
+		assert.Equal(t, &[]gardener.Worker{shoot.Spec.Provider.Workers[1]}, runtimeShoot.Provider.AdditionalWorkers)
+		assert.Equal(t, shoot.Spec.Provider.InfrastructureConfig, runtimeShoot.Provider.InfrastructureConfig)
+		assert.Equal(t, imv1.Networking{Type: ptr.To("calico"), Pods: "100.64.0.0/12", Nodes: "10.250.0.0/16", Services: "100.104.0.0/13", DualStack: ptr.To(true)}, runtimeShoot.Networking)
+		assert.Equal(t, ptr.To("partner"), runtimeShoot.LicenceType)
+		assert.Equal(t, ptr.To(true), runtimeShoot.EnforceSeedLocation)
+		assert.Equal(t, ptr.To("https://issuer.example.com"), runtimeShoot.Kubernetes.KubeAPIServer.OidcConfig.IssuerURL) //nolint:staticcheck
+		assert.Equal(t, &imv1.Hibernation{Schedules: []imv1.HibernationSchedule{{Start: ptr.To("00 20 * * 1,2,3,4,5")}}}, runtimeShoot.Hibernation)
+		assert.Equal(t, &imv1.Maintenance{
+			TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "030000+0000", End: "040000+0000"},
+			AutoUpdate: &imv1.MaintenanceAutoUpdate{KubernetesVersion: ptr.To(false), MachineImageVersion: ptr.To(true)},
+		}, runtimeShoot.Maintenance)
+	})
+
+	t.Run("Should read the OIDC configuration from the structured authentication configuration", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		shoot.Spec.Kubernetes.KubeAPIServer = &gardener.KubeAPIServerConfig{StructuredAuthentication: &gardener.StructuredAuthentication{ConfigMapName: "auth"}}
+		authConfig := &structuredauth.AuthenticationConfiguration{JWT: []structuredauth.JWTAuthenticator{
+			fixJWTAuthenticator("https://first.example.com"),
+			fixJWTAuthenticator("https://second.example.com"),
+		}}
+
+		// when
+		runtimeShoot, warnings, err := ToRuntimeShoot(shoot, authConfig)
+
+		// then
+		require.NoError(t, err)
+		oidcConfig := runtimeShoot.Kubernetes.KubeAPIServer.OidcConfig
+		assert.Equal(t, ptr.To("https://first.example.com"), oidcConfig.IssuerURL)
+		assert.Equal(t, ptr.To("client"), oidcConfig.ClientID)
+		assert.Equal(t, ptr.To("sub"), oidcConfig.UsernameClaim)
+		assert.Equal(t, ptr.To("-"), oidcConfig.UsernamePrefix)
+		assert.Equal(t, []string{"the OIDC issuer https://second.example.com is not kept, configure it as an additional OIDC configuration"}, warnings)
+	})
+
+	t.Run("Should convert the extensions and report the extensions not managed by KIM", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		shoot.Spec.Extensions = []gardener.Extension{
+			{Type: "acl", ProviderConfig: &k8sruntime.RawExtension{Raw: []byte(`{"rule":{"action":"ALLOW","cidrs":["1.1.1.1/32"],"type":"remote_ip"}}`)}},
+			{Type: "shoot-nvidia-openshell"},
+			{Type: "shoot-dns-service"},
+			{Type: "shoot-lakom-service"},
+		}
+
+		// when
+		runtimeShoot, warnings, err := ToRuntimeShoot(shoot, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, &imv1.ACL{AllowedCIDRs: []string{"1.1.1.1/32"}}, runtimeShoot.Kubernetes.KubeAPIServer.ACL)
+		assert.Equal(t, ptr.To(true), runtimeShoot.EnableNvidiaOpenshell)
+		assert.Len(t, warnings, 2)
+		assert.Contains(t, warnings, "the shoot-lakom-service extension is not managed by KIM")
+	})
+
+	t.Run("Should fail for a Shoot without workers", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		shoot.Spec.Provider.Workers = nil
+
+		// when
+		_, _, err := ToRuntimeShoot(shoot, nil)
+
+		// then
+		require.ErrorContains(t, err, "has no worker pools")
+	})
+}
+
+func TestToSecurity(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		filter   *gardener.Extension
+		expected imv1.Filter
+	}{
+		{
+			name: "Should disable the filter without the extension",
+		},
+		{
+			name:   "Should disable the filter for a disabled extension",
+			filter: &gardener.Extension{Type: "shoot-networking-filter", Disabled: ptr.To(true)},
+		},
+		{
+			name:     "Should enable the egress filter",
+			filter:   &gardener.Extension{Type: "shoot-networking-filter", Disabled: ptr.To(false)},
+			expected: imv1.Filter{Egress: imv1.Egress{Enabled: true}},
+		},
+		{
+			name: "Should enable the ingress filter for blackholing",
+			filter: &gardener.Extension{Type: "shoot-networking-filter",
+				ProviderConfig: &k8sruntime.RawExtension{Raw: []byte(`{"egressFilter":{"blackholingEnabled":true}}`)}},
+			expected: imv1.Filter{Egress: imv1.Egress{Enabled: true}, Ingress: &imv1.Ingress{Enabled: true}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			shoot := fixShoot()
+			if tc.filter != nil {
+				shoot.Spec.Extensions = []gardener.Extension{*tc.filter}
+			}
+
+			// when
+			security, err := ToSecurity(shoot, []string{"admin@example.com"})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []string{"admin@example.com"}, security.Administrators)
+			assert.Equal(t, tc.expected, security.Networking.Filter)
+		})
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	fixOptions := func(dryRun bool) Options {
+		return Options{
+			ShootName:         "shoot-a",
+			GardenerNamespace: gardenerNamespace,
+			Namespace:         kcpNamespace,
+			RuntimeID:         "runtime-a",
+			PlatformRegion:    "cf-eu10",
+			Labels: map[string]string{
+				imv1.LabelKymaInstanceID:      "instance",
+				imv1.LabelKymaRegion:          "eu-central-1",
+				imv1.LabelKymaName:            "kyma",
+				imv1.LabelKymaBrokerPlanID:    "plan-id",
+				imv1.LabelKymaBrokerPlanName:  "aws",
+				imv1.LabelKymaGlobalAccountID: "global-account",
+				imv1.LabelKymaSubaccountID:    "subaccount",
+			},
+			DryRun: dryRun,
+		}
+	}
+
+	t.Run("Should create the Runtime CR in dry-run patch mode", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		gardenClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(&shoot).Build()
+		kcpClient := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+		// when
+		result, err := Adopt(context.Background(), gardenClient, kcpClient, fixOptions(false))
+
+		// then
+		require.NoError(t, err)
+		var runtime imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "runtime-a", Namespace: kcpNamespace}, &runtime))
+		assert.Equal(t, "true", runtime.Annotations[reconciler.DryRunPatchAnnotation])
+		assert.Equal(t, "garden-kyma/shoot-a", runtime.Annotations[AdoptedFromShootAnnotation])
+		assert.Equal(t, "runtime-a", runtime.Labels[imv1.LabelKymaRuntimeID])
+		assert.Equal(t, "shoot-a", runtime.Labels[imv1.LabelKymaShootName])
+		assert.Equal(t, "kyma", runtime.Annotations[imv1.AnnotationGardenerProject])
+		assert.Equal(t, "cf-eu10", runtime.Spec.Shoot.PlatformRegion)
+		assert.Equal(t, result.Runtime.Spec, runtime.Spec)
+	})
+
+	t.Run("Should only return the Runtime CR in dry run", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		gardenClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(&shoot).Build()
+		kcpClient := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+		// when
+		result, err := Adopt(context.Background(), gardenClient, kcpClient, fixOptions(true))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "shoot-a", result.Runtime.Spec.Shoot.Name)
+		var runtimes imv1.RuntimeList
+		require.NoError(t, kcpClient.List(context.Background(), &runtimes))
+		assert.Empty(t, runtimes.Items)
+	})
+
+	t.Run("Should read the structured authentication ConfigMap", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		shoot.Spec.Kubernetes.KubeAPIServer = &gardener.KubeAPIServerConfig{StructuredAuthentication: &gardener.StructuredAuthentication{ConfigMapName: "auth"}}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: gardenerNamespace},
+			Data: map[string]string{"config.yaml": `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: AuthenticationConfiguration
+jwt:
+- issuer:
+    url: https://issuer.example.com
+    audiences: [client]
+  claimMappings:
+    username: {claim: sub, prefix: "-"}
+    groups: {claim: groups, prefix: ""}
+`},
+		}
+		gardenClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(&shoot, configMap).Build()
+		kcpClient := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+
+		// when
+		result, err := Adopt(context.Background(), gardenClient, kcpClient, fixOptions(true))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To("https://issuer.example.com"), result.Runtime.Spec.Shoot.Kubernetes.KubeAPIServer.OidcConfig.IssuerURL)
+	})
+
+	for _, tc := range []struct {
+		name          string
+		shoot         func(*gardener.Shoot)
+		kcpObjects    []client.Object
+		options       func(*Options)
+		expectedError string
+	}{
+		{
+			name:          "Should not adopt a Shoot managed by KIM",
+			shoot:         func(shoot *gardener.Shoot) { shoot.Annotations[extender.ShootRuntimeIDAnnotation] = "runtime-b" },
+			expectedError: "shoot shoot-a is already managed by KIM as Runtime runtime-b",
+		},
+		{
+			name:          "Should not adopt a Shoot twice",
+			kcpObjects:    []client.Object{fixRuntime("runtime-b", "shoot-a")},
+			expectedError: "shoot shoot-a is already adopted by Runtime runtime-b",
+		},
+		{
+			name:          "Should not overwrite an existing Runtime",
+			kcpObjects:    []client.Object{fixRuntime("runtime-a", "shoot-b")},
+			expectedError: "runtime runtime-a already exists",
+		},
+		{
+			name:          "Should require the labels of a Runtime",
+			options:       func(opts *Options) { delete(opts.Labels, imv1.LabelKymaSubaccountID) },
+			expectedError: imv1.LabelKymaSubaccountID,
+		},
+		{
+			name:          "Should fail for a missing Shoot",
+			options:       func(opts *Options) { opts.ShootName = "shoot-b" },
+			expectedError: "failed to get shoot",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			shoot := fixShoot()
+			if tc.shoot != nil {
+				tc.shoot(&shoot)
+			}
+			opts := fixOptions(false)
+			if tc.options != nil {
+				tc.options(&opts)
+			}
+			gardenClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(&shoot).Build()
+			kcpClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(tc.kcpObjects...).Build()
+
+			// when
+			_, err := Adopt(context.Background(), gardenClient, kcpClient, opts)
+
+			// then
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func newScheme(t *testing.T) *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gardener.AddToScheme(scheme))
+	require.NoError(t, imv1.AddToScheme(scheme))
+	return scheme
+}
+
+func fixShoot() gardener.Shoot {
+	return gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "shoot-a",
+			Namespace:   gardenerNamespace,
+			Annotations: map[string]string{extender.ShootLicenceTypeAnnotation: "partner"},
+		},
+		Spec: gardener.ShootSpec{
+			Purpose:                ptr.To(gardener.ShootPurposeProduction),
+			Region:                 "eu-central-1",
+			CredentialsBindingName: ptr.To("aws-binding"),
+			Kubernetes: gardener.Kubernetes{
+				Version: "1.33.5",
+				KubeAPIServer: &gardener.KubeAPIServerConfig{
+					OIDCConfig: &gardener.OIDCConfig{IssuerURL: ptr.To("https://issuer.example.com")}, //nolint:staticcheck
+				},
+			},
+			Provider: gardener.Provider{
+				Type: "aws",
+				Workers: []gardener.Worker{
+					{Name: "cpu-worker-0", Machine: gardener.Machine{Type: "m6i.large"}, Minimum: 3, Maximum: 20},
+					{Name: "gpu", Machine: gardener.Machine{Type: "g4dn.xlarge"}, Minimum: 0, Maximum: 2},
+				},
+				InfrastructureConfig: &k8sruntime.RawExtension{Raw: []byte(`{"kind":"InfrastructureConfig"}`)},
+			},
+			Networking: &gardener.Networking{
+				Type:       ptr.To("calico"),
+				Pods:       ptr.To("100.64.0.0/12"),
+				Nodes:      ptr.To("10.250.0.0/16"),
+				Services:   ptr.To("100.104.0.0/13"),
+				IPFamilies: []gardener.IPFamily{gardener.IPFamilyIPv4, gardener.IPFamilyIPv6},
+			},
+			SeedSelector: &gardener.SeedSelector{
+				LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"seed.gardener.cloud/region": "eu-central-1"}},
+			},
+			Hibernation: &gardener.Hibernation{
+				Schedules: []gardener.HibernationSchedule{{Start: ptr.To("00 20 * * 1,2,3,4,5")}},
+			},
+			Maintenance: &gardener.Maintenance{
+				TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "030000+0000", End: "040000+0000"},
+				AutoUpdate: &gardener.MaintenanceAutoUpdate{KubernetesVersion: false, MachineImageVersion: ptr.To(true)},
+			},
+		},
+	}
+}
+
+func fixJWTAuthenticator(issuer string) structuredauth.JWTAuthenticator {
+	return structuredauth.JWTAuthenticator{
+		Issuer: structuredauth.Issuer{URL: issuer, Audiences: []string{"client"}},
+		ClaimMappings: structuredauth.ClaimMappings{
+			Username: structuredauth.PrefixedClaim{Claim: "sub", Prefix: ptr.To("-")},
+			Groups:   structuredauth.PrefixedClaim{Claim: "groups", Prefix: ptr.To("")},
+		},
+	}
+}
+
+func fixRuntime(name, shootName string) *imv1.Runtime {
+	return &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: kcpNamespace,
+			Labels:    map[string]string{imv1.LabelKymaShootName: shootName},
+		},
+	}
+}
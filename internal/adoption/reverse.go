@@ -0,0 +1,227 @@
+package adoption
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/extensions"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/structuredauth"
+	"k8s.io/utils/ptr"
+)
+
+const seedRegionSelectorLabel = "seed.gardener.cloud/region"
+
+// extensions which are generated from the Runtime CR or from the KIM configuration
+var managedExtensions = []string{
+	extensions.ApiServerACLExtensionType,
+	extensions.AuditlogExtensionType,
+	extensions.CertExtensionType,
+	extensions.DNSExtensionType,
+	extensions.NetworkFilterType,
+	extensions.NvidiaOpenshellExtensionType,
+	extensions.OidcExtensionType,
+	extensions.RegistryCacheExtensionType,
+}
+
+// ToRuntimeShoot converts the spec of a Shoot created by other tooling into the spec of a Runtime CR.
+// The authentication configuration is read from the structured authentication ConfigMap of the Shoot, if there is one.
+// Warnings describe the parts of the Shoot which can't be represented in the Runtime CR.
+func ToRuntimeShoot(shoot gardener.Shoot, authConfig *structuredauth.AuthenticationConfiguration) (imv1.RuntimeShoot, []string, error) {
+	var warnings []string
+
+	if shoot.Spec.Networking == nil {
+		return imv1.RuntimeShoot{}, nil, fmt.Errorf("shoot %s has no networking configuration", shoot.Name)
+	}
+	if len(shoot.Spec.Provider.Workers) == 0 {
+		return imv1.RuntimeShoot{}, nil, fmt.Errorf("shoot %s has no worker pools", shoot.Name)
+	}
+
+	runtimeShoot := imv1.RuntimeShoot{
+		Name:              shoot.Name,
+		Purpose:           ptr.Deref(shoot.Spec.Purpose, ""),
+		Region:            shoot.Spec.Region,
+		SecretBindingName: ptr.Deref(shoot.Spec.CredentialsBindingName, ptr.Deref(shoot.Spec.SecretBindingName, "")), //nolint:staticcheck
+		Kubernetes: imv1.Kubernetes{
+			Version: ptr.To(shoot.Spec.Kubernetes.Version),
+		},
+		Provider: imv1.Provider{
+			Type:                 shoot.Spec.Provider.Type,
+			Workers:              []gardener.Worker{shoot.Spec.Provider.Workers[0]},
+			InfrastructureConfig: shoot.Spec.Provider.InfrastructureConfig,
+			ControlPlaneConfig:   shoot.Spec.Provider.ControlPlaneConfig,
+		},
+		Networking: imv1.Networking{
+			Type:     shoot.Spec.Networking.Type,
+			Pods:     ptr.Deref(shoot.Spec.Networking.Pods, ""),
+			Nodes:    ptr.Deref(shoot.Spec.Networking.Nodes, ""),
+			Services: ptr.Deref(shoot.Spec.Networking.Services, ""),
+		},
+		ControlPlane: shoot.Spec.ControlPlane,
+	}
+
+	if len(shoot.Spec.Provider.Workers) > 1 {
+		runtimeShoot.Provider.AdditionalWorkers = ptr.To(slices.Clone(shoot.Spec.Provider.Workers[1:]))
+	}
+
+	if slices.Contains(shoot.Spec.Networking.IPFamilies, gardener.IPFamilyIPv6) {
+		runtimeShoot.Networking.DualStack = ptr.To(true)
+	}
+
+	if licenceType := shoot.Annotations[extender.ShootLicenceTypeAnnotation]; licenceType != "" {
+		runtimeShoot.LicenceType = ptr.To(licenceType)
+	}
+
+	if shoot.Spec.SeedSelector != nil && shoot.Spec.SeedSelector.MatchLabels[seedRegionSelectorLabel] == shoot.Spec.Region {
+		runtimeShoot.EnforceSeedLocation = ptr.To(true)
+	}
+
+	oidcWarnings := setOIDCConfig(&runtimeShoot, shoot, authConfig)
+	warnings = append(warnings, oidcWarnings...)
+
+	extensionWarnings, err := setExtensions(&runtimeShoot, shoot)
+	if err != nil {
+		return imv1.RuntimeShoot{}, nil, err
+	}
+	warnings = append(warnings, extensionWarnings...)
+
+	runtimeShoot.Hibernation = toHibernation(shoot.Spec.Hibernation)
+	runtimeShoot.Maintenance = toMaintenance(shoot.Spec.Maintenance)
+
+	return runtimeShoot, warnings, nil
+}
+
+// ToSecurity converts the networking filter extension of the Shoot into the security settings of a Runtime CR.
+func ToSecurity(shoot gardener.Shoot, administrators []string) (imv1.Security, error) {
+	security := imv1.Security{Administrators: administrators}
+	if security.Administrators == nil {
+		security.Administrators = []string{}
+	}
+
+	filter := findExtension(shoot, extensions.NetworkFilterType)
+	if filter == nil || ptr.Deref(filter.Disabled, false) {
+		return security, nil
+	}
+	security.Networking.Filter.Egress.Enabled = true
+
+	if filter.ProviderConfig != nil && len(filter.ProviderConfig.Raw) > 0 {
+		var config extensions.Configuration
+		if err := json.Unmarshal(filter.ProviderConfig.Raw, &config); err != nil {
+			return imv1.Security{}, fmt.Errorf("failed to decode the %s extension: %w", extensions.NetworkFilterType, err)
+		}
+		if config.EgressFilter != nil && config.EgressFilter.BlackholingEnabled {
+			security.Networking.Filter.Ingress = &imv1.Ingress{Enabled: true}
+		}
+	}
+
+	return security, nil
+}
+
+func setOIDCConfig(runtimeShoot *imv1.RuntimeShoot, shoot gardener.Shoot, authConfig *structuredauth.AuthenticationConfiguration) []string {
+	apiServer := shoot.Spec.Kubernetes.KubeAPIServer
+	if apiServer == nil {
+		return nil
+	}
+
+	if apiServer.OIDCConfig != nil { //nolint:staticcheck
+		runtimeShoot.Kubernetes.KubeAPIServer.OidcConfig = *apiServer.OIDCConfig //nolint:staticcheck
+		return nil
+	}
+
+	if authConfig == nil || len(authConfig.JWT) == 0 {
+		return nil
+	}
+
+	authenticator := authConfig.JWT[0]
+	runtimeShoot.Kubernetes.KubeAPIServer.OidcConfig = gardener.OIDCConfig{ //nolint:staticcheck
+		IssuerURL:      ptr.To(authenticator.Issuer.URL),
+		UsernameClaim:  ptr.To(authenticator.ClaimMappings.Username.Claim),
+		UsernamePrefix: authenticator.ClaimMappings.Username.Prefix,
+		GroupsClaim:    ptr.To(authenticator.ClaimMappings.Groups.Claim),
+		GroupsPrefix:   authenticator.ClaimMappings.Groups.Prefix,
+	}
+	if len(authenticator.Issuer.Audiences) > 0 {
+		runtimeShoot.Kubernetes.KubeAPIServer.OidcConfig.ClientID = ptr.To(authenticator.Issuer.Audiences[0])
+	}
+
+	var warnings []string
+	if len(authenticator.Issuer.Audiences) > 1 {
+		warnings = append(warnings, fmt.Sprintf("only the first audience of the OIDC issuer %s is kept", authenticator.Issuer.URL))
+	}
+	for _, additional := range authConfig.JWT[1:] {
+		warnings = append(warnings, fmt.Sprintf("the OIDC issuer %s is not kept, configure it as an additional OIDC configuration", additional.Issuer.URL))
+	}
+	return warnings
+}
+
+func setExtensions(runtimeShoot *imv1.RuntimeShoot, shoot gardener.Shoot) ([]string, error) {
+	var warnings []string
+
+	if acl := findExtension(shoot, extensions.ApiServerACLExtensionType); acl != nil && !ptr.Deref(acl.Disabled, false) && acl.ProviderConfig != nil {
+		var config struct {
+			Rule struct {
+				Cidrs []string `json:"cidrs"`
+			} `json:"rule"`
+		}
+		if err := json.Unmarshal(acl.ProviderConfig.Raw, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode the %s extension: %w", extensions.ApiServerACLExtensionType, err)
+		}
+		runtimeShoot.Kubernetes.KubeAPIServer.ACL = &imv1.ACL{AllowedCIDRs: config.Rule.Cidrs}
+		warnings = append(warnings, "the API server ACL is kept with all its CIDRs, remove the operator and KCP CIDRs which KIM adds on its own")
+	}
+
+	if openshell := findExtension(shoot, extensions.NvidiaOpenshellExtensionType); openshell != nil && !ptr.Deref(openshell.Disabled, false) {
+		runtimeShoot.EnableNvidiaOpenshell = ptr.To(true)
+	}
+
+	for _, extension := range shoot.Spec.Extensions {
+		if !slices.Contains(managedExtensions, extension.Type) {
+			warnings = append(warnings, fmt.Sprintf("the %s extension is not managed by KIM", extension.Type))
+		}
+	}
+
+	return warnings, nil
+}
+
+func toHibernation(hibernation *gardener.Hibernation) *imv1.Hibernation {
+	if hibernation == nil || (hibernation.Enabled == nil && len(hibernation.Schedules) == 0) {
+		return nil
+	}
+
+	result := &imv1.Hibernation{Enabled: hibernation.Enabled}
+	for _, schedule := range hibernation.Schedules {
+		result.Schedules = append(result.Schedules, imv1.HibernationSchedule{
+			Start:    schedule.Start,
+			End:      schedule.End,
+			Location: schedule.Location,
+		})
+	}
+	return result
+}
+
+func toMaintenance(maintenance *gardener.Maintenance) *imv1.Maintenance {
+	if maintenance == nil || (maintenance.TimeWindow == nil && maintenance.AutoUpdate == nil) {
+		return nil
+	}
+
+	result := &imv1.Maintenance{TimeWindow: maintenance.TimeWindow}
+	if maintenance.AutoUpdate != nil {
+		result.AutoUpdate = &imv1.MaintenanceAutoUpdate{
+			KubernetesVersion:   ptr.To(maintenance.AutoUpdate.KubernetesVersion),
+			MachineImageVersion: maintenance.AutoUpdate.MachineImageVersion,
+		}
+	}
+	return result
+}
+
+func findExtension(shoot gardener.Shoot, extensionType string) *gardener.Extension {
+	for _, extension := range shoot.Spec.Extensions {
+		if extension.Type == extensionType {
+			return &extension
+		}
+	}
+	return nil
+}
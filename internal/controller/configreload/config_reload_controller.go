@@ -19,6 +19,7 @@ package configreload
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
@@ -42,6 +43,8 @@ type RuntimePredicate func(configObject types.NamespacedName, runtime imv1.Runti
 
 // ConfigReloadWatcher forces re-reconciliation of Runtime CRs when watched
 // ConfigMaps, Secrets, or ClusterTrustBundles are updated.
+// Updates of the converter configuration ConfigMap are loaded by the ConverterConfigLoader instead.
+// When the RolloutTemplate is set, the Runtime CRs are not annotated at once; a ConfigRollout is created instead.
 type ConfigReloadWatcher struct {
 	KcpClient                   client.Client
 	Namespace                   string
@@ -49,16 +52,33 @@ type ConfigReloadWatcher struct {
 	SecretPredicates            []ObjectUpdatedPredicate
 	ClusterTrustBundlePredicate *ObjectUpdatedPredicate
 	RuntimePredicate            RuntimePredicate
+	ConverterConfigLoader       *ConverterConfigLoader
+	RolloutTemplate             *imv1.ConfigRolloutSpec
 }
 
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=watch;list,namespace=kcp-system
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=watch;list,namespace=kcp-system
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;watch;list,namespace=kcp-system
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=clustertrustbundles,verbs=watch;list,namespace=kcp-system
 // +kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=runtimes,verbs=list;patch,namespace=kcp-system
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=clustertrustbundles,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get,namespace=kcp-system
 
 func (r *ConfigReloadWatcher) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
 
+	if r.ConverterConfigLoader != nil && req.NamespacedName == r.ConverterConfigLoader.ConfigMap {
+		return r.reloadConverterConfig(ctx)
+	}
+
+	if r.RolloutTemplate != nil {
+		source, revision, err := r.resolveSource(ctx, req.NamespacedName)
+		if err != nil {
+			logger.Error(err, "unable to resolve configuration resource", "name", req.Name, "namespace", req.Namespace)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.createRollout(ctx, source, revision)
+	}
+
 	var runtimes imv1.RuntimeList
 	err := r.KcpClient.List(ctx, &runtimes, &client.ListOptions{
 		Namespace: r.Namespace,
@@ -78,25 +98,10 @@ func (r *ConfigReloadWatcher) Reconcile(ctx context.Context, req ctrl.Request) (
 			continue
 		}
 
-		if item.Annotations != nil && item.Annotations[reconciler.ForceReconcileAnnotation] == "true" {
-			continue
-		}
-
-		newItem := item.DeepCopy()
-		if newItem.Annotations == nil {
-			newItem.Annotations = map[string]string{}
-		}
-		newItem.Annotations[reconciler.ForceReconcileAnnotation] = "true"
-		newItem.ManagedFields = nil
-
-		//nolint:staticcheck // SA1019: client.Apply is used with Patch, which is the correct API for this version
-		if err := r.KcpClient.Patch(ctx, newItem, client.Apply, &client.PatchOptions{
-			FieldManager: fieldManager,
-			Force:        ptr.To(true),
-		}); err != nil {
+		if err := ForceReconciliation(ctx, r.KcpClient, item); err != nil {
 			logger.Error(err, "unable to annotate runtime",
-				"namespace", newItem.Namespace,
-				"name", newItem.Name)
+				"namespace", item.Namespace,
+				"name", item.Name)
 
 			success = false
 		}
@@ -109,12 +114,92 @@ func (r *ConfigReloadWatcher) Reconcile(ctx context.Context, req ctrl.Request) (
 	return ctrl.Result{}, nil
 }
 
+// ForceReconciliation annotates the Runtime CR, so that the Runtime controller patches its Shoot.
+// Runtime CRs which already have the annotation are not patched.
+func ForceReconciliation(ctx context.Context, kcpClient client.Client, runtime imv1.Runtime) error {
+	return ForceReconciliationWithAnnotations(ctx, kcpClient, runtime, nil)
+}
+
+// ForceReconciliationWithAnnotations annotates the Runtime CR for forced reconciliation together with the given annotations.
+// Runtime CRs which already have all annotations are not patched.
+func ForceReconciliationWithAnnotations(ctx context.Context, kcpClient client.Client, runtime imv1.Runtime, annotations map[string]string) error {
+	annotations = maps.Clone(annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[reconciler.ForceReconcileAnnotation] = "true"
+
+	if hasAnnotations(runtime, annotations) {
+		return nil
+	}
+
+	newItem := runtime.DeepCopy()
+	if newItem.Annotations == nil {
+		newItem.Annotations = map[string]string{}
+	}
+	maps.Copy(newItem.Annotations, annotations)
+	newItem.ManagedFields = nil
+
+	//nolint:staticcheck // SA1019: client.Apply is used with Patch, which is the correct API for this version
+	return kcpClient.Patch(ctx, newItem, client.Apply, &client.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        ptr.To(true),
+	})
+}
+
+func hasAnnotations(runtime imv1.Runtime, annotations map[string]string) bool {
+	for key, value := range annotations {
+		if runtime.Annotations[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// reloadConverterConfig doesn't force re-reconciliation of the Runtime CRs, unless a rollout is configured. Like after a restart
+// of the manager, the new converter configuration is applied when a Runtime CR is reconciled next time.
+// With a rollout, the new configuration is staged and used only by the Runtime CRs of the waves which were rolled out.
+func (r *ConfigReloadWatcher) reloadConverterConfig(ctx context.Context) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	revision, changed, err := r.ConverterConfigLoader.Reload(ctx, r.KcpClient)
+	if err != nil {
+		// retrying doesn't help until the ConfigMap is updated again
+		logger.Error(err, "unable to reload converter configuration, keeping the current configuration",
+			"configMap", r.ConverterConfigLoader.ConfigMap.String(),
+			"hash", r.ConverterConfigLoader.Holder.Hash())
+		return ctrl.Result{}, nil
+	}
+
+	if !changed {
+		return ctrl.Result{}, nil
+	}
+
+	if r.RolloutTemplate == nil {
+		logger.Info("Converter configuration reloaded", "hash", revision)
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Converter configuration staged for rollout", "hash", revision, "currentHash", r.ConverterConfigLoader.Holder.Hash())
+	source := imv1.ConfigRolloutSource{
+		Kind:      configMapKind,
+		Name:      r.ConverterConfigLoader.ConfigMap.Name,
+		Namespace: r.ConverterConfigLoader.ConfigMap.Namespace,
+	}
+	return ctrl.Result{}, r.createRollout(ctx, source, revision)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ConfigReloadWatcher) SetupWithManager(mgr ctrl.Manager) error {
 	controller := ctrl.NewControllerManagedBy(mgr).
 		Named("config")
 
-	for _, p := range r.ConfigMapPredicates {
+	configMapPredicates := r.ConfigMapPredicates
+	if r.ConverterConfigLoader != nil {
+		configMapPredicates = append(configMapPredicates, r.ConverterConfigLoader.Predicate())
+	}
+
+	for _, p := range configMapPredicates {
 		controller = controller.Watches(&corev1.ConfigMap{},
 			&handler.EnqueueRequestForObject{},
 			builder.WithPredicates(p))
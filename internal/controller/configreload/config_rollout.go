@@ -0,0 +1,100 @@
+package configreload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	configMapKind          = "ConfigMap"
+	secretKind             = "Secret"
+	clusterTrustBundleKind = "ClusterTrustBundle"
+
+	maxRolloutNamePrefixLength = 52
+)
+
+// +kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=configrollouts,verbs=create,namespace=kcp-system
+
+// createRollout hands the configuration change over to the ConfigRollout controller, which reconciles the Runtimes in waves.
+// The rollout name is derived from the source and revision, so that a change is rolled out only once.
+func (r *ConfigReloadWatcher) createRollout(ctx context.Context, source imv1.ConfigRolloutSource, revision string) error {
+	logger := logf.FromContext(ctx)
+
+	rollout := imv1.ConfigRollout{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rolloutName(source, revision),
+			Namespace: r.Namespace,
+		},
+		Spec: *r.RolloutTemplate.DeepCopy(),
+	}
+	rollout.Spec.Source = source
+	rollout.Spec.Revision = revision
+
+	err := r.KcpClient.Create(ctx, &rollout)
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Config rollout created", "rollout", rollout.Name, "source", source.Name, "revision", revision)
+	return nil
+}
+
+// resolveSource finds the watched configuration resource which triggered the reconciliation and returns its revision.
+func (r *ConfigReloadWatcher) resolveSource(ctx context.Context, name types.NamespacedName) (imv1.ConfigRolloutSource, string, error) {
+	var object client.Object
+	var kind string
+
+	switch {
+	case matches(r.ConfigMapPredicates, name):
+		object, kind = &corev1.ConfigMap{}, configMapKind
+	case matches(r.SecretPredicates, name):
+		object, kind = &corev1.Secret{}, secretKind
+	case r.ClusterTrustBundlePredicate != nil && r.ClusterTrustBundlePredicate.NamespacedName == name:
+		object, kind = &certificatesv1beta1.ClusterTrustBundle{}, clusterTrustBundleKind
+	default:
+		return imv1.ConfigRolloutSource{}, "", fmt.Errorf("%s is not a watched configuration resource", name)
+	}
+
+	if err := r.KcpClient.Get(ctx, name, object); err != nil {
+		return imv1.ConfigRolloutSource{}, "", err
+	}
+
+	return imv1.ConfigRolloutSource{
+		Kind:      kind,
+		Name:      name.Name,
+		Namespace: name.Namespace,
+	}, object.GetResourceVersion(), nil
+}
+
+func matches(predicates []ObjectUpdatedPredicate, name types.NamespacedName) bool {
+	for _, p := range predicates {
+		if p.NamespacedName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func rolloutName(source imv1.ConfigRolloutSource, revision string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s/%s", source.Kind, source.Namespace, source.Name, revision)))
+
+	prefix := source.Name
+	if len(prefix) > maxRolloutNamePrefixLength {
+		prefix = prefix[:maxRolloutNamePrefixLength]
+	}
+	return fmt.Sprintf("%s-%s", prefix, hex.EncodeToString(sum[:])[:10])
+}
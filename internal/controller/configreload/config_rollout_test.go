@@ -0,0 +1,86 @@
+package configreload
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateRollout(t *testing.T) {
+	const namespace = "kcp-system"
+	configMapName := types.NamespacedName{Name: "acl-config", Namespace: namespace}
+
+	fixWatcher := func() *ConfigReloadWatcher {
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+		require.NoError(t, imv1.AddToScheme(scheme))
+
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace},
+		}).Build()
+
+		return &ConfigReloadWatcher{
+			KcpClient:           kcpClient,
+			Namespace:           namespace,
+			ConfigMapPredicates: []ObjectUpdatedPredicate{{NamespacedName: configMapName}},
+			RolloutTemplate: &imv1.ConfigRolloutSpec{
+				HealthTimeout: metav1.Duration{Duration: time.Hour},
+				Waves:         []imv1.ConfigRolloutWave{{Name: "canary"}},
+			},
+		}
+	}
+
+	t.Run("Should create a single rollout for a change of a watched resource", func(t *testing.T) {
+		// given
+		watcher := fixWatcher()
+		source, revision, err := watcher.resolveSource(context.Background(), configMapName)
+		require.NoError(t, err)
+
+		// when
+		require.NoError(t, watcher.createRollout(context.Background(), source, revision))
+		require.NoError(t, watcher.createRollout(context.Background(), source, revision))
+
+		// then
+		var rollouts imv1.ConfigRolloutList
+		require.NoError(t, watcher.KcpClient.List(context.Background(), &rollouts))
+		require.Len(t, rollouts.Items, 1)
+
+		rollout := rollouts.Items[0]
+		assert.Equal(t, namespace, rollout.Namespace)
+		assert.Equal(t, imv1.ConfigRolloutSource{Kind: "ConfigMap", Name: configMapName.Name, Namespace: namespace}, rollout.Spec.Source)
+		assert.Equal(t, revision, rollout.Spec.Revision)
+		assert.Equal(t, time.Hour, rollout.Spec.HealthTimeout.Duration)
+		assert.Equal(t, "canary", rollout.Spec.Waves[0].Name)
+	})
+
+	t.Run("Should reject a resource which is not watched", func(t *testing.T) {
+		// when
+		_, _, err := fixWatcher().resolveSource(context.Background(), types.NamespacedName{Name: "other", Namespace: namespace})
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("Should create different rollout names for different revisions", func(t *testing.T) {
+		// given
+		source := imv1.ConfigRolloutSource{Kind: "ConfigMap", Name: strings.Repeat("a", 253), Namespace: namespace}
+
+		// when
+		first := rolloutName(source, "1")
+		second := rolloutName(source, "2")
+
+		// then
+		assert.NotEqual(t, first, second)
+		assert.LessOrEqual(t, len(first), 63)
+	})
+}
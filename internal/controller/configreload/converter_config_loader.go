@@ -0,0 +1,92 @@
+package configreload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConverterConfigLoader reloads the converter configuration from its ConfigMap into the config holder
+// used by the Runtime controller and the Runtime webhooks.
+type ConverterConfigLoader struct {
+	ConfigMap types.NamespacedName
+	Key       string
+	Holder    *config.Holder
+	Metrics   metrics.Metrics
+	// Staged keeps a new configuration next to the current one until its ConfigRollout promotes it,
+	// so that only the Runtimes of the waves which were already rolled out use it
+	Staged bool
+}
+
+// Predicate returns the predicate matching updates of the converter configuration ConfigMap.
+func (l *ConverterConfigLoader) Predicate() ObjectUpdatedPredicate {
+	return ObjectUpdatedPredicate{NamespacedName: l.ConfigMap}
+}
+
+// Reload swaps in the configuration stored in the ConfigMap, or stages it when the loader is Staged. It returns the revision
+// of the loaded configuration, and false when it doesn't differ from the configuration in use.
+// An invalid configuration is rejected and the configuration which is currently in use is kept.
+// Every failed reload is counted, and the hash of the configuration in use is reported after every reload attempt.
+func (l *ConverterConfigLoader) Reload(ctx context.Context, c client.Reader) (string, bool, error) {
+	previousHash := l.Holder.Hash()
+
+	revision, err := l.load(ctx, c)
+	if err != nil {
+		l.Metrics.IncConverterConfigReloadErrorCounter()
+	}
+
+	l.Metrics.SetConverterConfigHash(l.Holder.Hash())
+	return revision, err == nil && revision != previousHash, err
+}
+
+// Stages returns true when the configuration rolled out from the source is staged by the loader
+func (l *ConverterConfigLoader) Stages(source imv1.ConfigRolloutSource) bool {
+	return l.Staged && source.Kind == configMapKind && source.Name == l.ConfigMap.Name && source.Namespace == l.ConfigMap.Namespace
+}
+
+// Promote makes the staged revision the configuration used by all Runtimes. It returns false when the revision is not staged.
+func (l *ConverterConfigLoader) Promote(revision string) bool {
+	promoted := l.Holder.Promote(revision)
+	l.Metrics.SetConverterConfigHash(l.Holder.Hash())
+	return promoted
+}
+
+// Discard drops the staged revision, the Runtimes which already use it go back to the current configuration
+func (l *ConverterConfigLoader) Discard(revision string) {
+	l.Holder.Discard(revision)
+}
+
+func (l *ConverterConfigLoader) load(ctx context.Context, c client.Reader) (string, error) {
+	var configMap corev1.ConfigMap
+	if err := c.Get(ctx, l.ConfigMap, &configMap); err != nil {
+		return "", fmt.Errorf("failed to get converter configuration ConfigMap: %w", err)
+	}
+
+	data, found := configMap.Data[l.Key]
+	if !found {
+		return "", fmt.Errorf("converter configuration ConfigMap doesn't contain the %s key", l.Key)
+	}
+
+	reader := func() (io.Reader, error) { return strings.NewReader(data), nil }
+
+	if l.Staged {
+		revision, err := l.Holder.Stage(reader)
+		if err != nil {
+			return "", fmt.Errorf("invalid converter configuration rejected: %w", err)
+		}
+		return revision, nil
+	}
+
+	if err := l.Holder.Load(reader); err != nil {
+		return "", fmt.Errorf("invalid converter configuration rejected: %w", err)
+	}
+	return l.Holder.Hash(), nil
+}
@@ -0,0 +1,172 @@
+package configreload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConverterConfigLoader(t *testing.T) {
+	const key = "converter_config.json"
+	configMapName := types.NamespacedName{Name: "converter-config", Namespace: "kcp-system"}
+
+	fixLoader := func(content string, holder *config.Holder, metrics *mocks.Metrics) (*ConverterConfigLoader, *fake.ClientBuilder) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+
+		builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configMapName.Name, Namespace: configMapName.Namespace},
+			Data:       map[string]string{key: content},
+		})
+
+		return &ConverterConfigLoader{
+			ConfigMap: configMapName,
+			Key:       key,
+			Holder:    holder,
+			Metrics:   metrics,
+		}, builder
+	}
+
+	requireVersion := func(cfg config.Config) error {
+		if cfg.ConverterConfig.Kubernetes.DefaultVersion == "" {
+			return errors.New("default version is required")
+		}
+		return nil
+	}
+
+	t.Run("Should swap in a valid configuration", func(t *testing.T) {
+		// given
+		holder := config.NewHolder(requireVersion)
+		metrics := mocks.NewMetrics(t)
+		metrics.On("SetConverterConfigHash", mock.AnythingOfType("string")).Once()
+		loader, builder := fixLoader(`{"converter":{"kubernetes":{"defaultVersion":"1.34"}}}`, holder, metrics)
+
+		// when
+		revision, changed, err := loader.Reload(context.Background(), builder.Build())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, holder.Hash(), revision)
+		assert.Equal(t, "1.34", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+	})
+
+	t.Run("Should stage a valid configuration until it is promoted", func(t *testing.T) {
+		// given
+		holder := config.NewHolder(requireVersion)
+		require.NoError(t, holder.Load(func() (io.Reader, error) {
+			return strings.NewReader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`), nil
+		}))
+		currentHash := holder.Hash()
+		metrics := mocks.NewMetrics(t)
+		metrics.On("SetConverterConfigHash", currentHash).Once()
+		loader, builder := fixLoader(`{"converter":{"kubernetes":{"defaultVersion":"1.34"}}}`, holder, metrics)
+		loader.Staged = true
+
+		// when
+		revision, changed, err := loader.Reload(context.Background(), builder.Build())
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.NotEqual(t, currentHash, revision)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+		assert.Equal(t, "1.34", holder.GetRevision(revision).ConverterConfig.Kubernetes.DefaultVersion)
+
+		// when
+		metrics.On("SetConverterConfigHash", revision).Once()
+		promoted := loader.Promote(revision)
+
+		// then
+		assert.True(t, promoted)
+		assert.Equal(t, "1.34", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+	})
+
+	t.Run("Should reject an invalid configuration", func(t *testing.T) {
+		// given
+		holder := config.NewHolder(requireVersion)
+		require.NoError(t, holder.Load(func() (io.Reader, error) {
+			return strings.NewReader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`), nil
+		}))
+		metrics := mocks.NewMetrics(t)
+		metrics.On("IncConverterConfigReloadErrorCounter").Once()
+		metrics.On("SetConverterConfigHash", holder.Hash()).Once()
+		loader, builder := fixLoader(`{"converter":{"kubernetes":{"defaultVersion":""}}}`, holder, metrics)
+
+		// when
+		_, changed, err := loader.Reload(context.Background(), builder.Build())
+
+		// then
+		require.Error(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+	})
+
+	t.Run("Should reject a ConfigMap without the configuration key", func(t *testing.T) {
+		// given
+		metrics := mocks.NewMetrics(t)
+		metrics.On("IncConverterConfigReloadErrorCounter").Once()
+		metrics.On("SetConverterConfigHash", "").Once()
+		loader, builder := fixLoader(`{}`, config.NewHolder(requireVersion), metrics)
+		loader.Key = "other.json"
+
+		// when
+		_, changed, err := loader.Reload(context.Background(), builder.Build())
+
+		// then
+		require.Error(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Should report an unchanged configuration", func(t *testing.T) {
+		// given
+		holder := config.NewHolder(requireVersion)
+		metrics := mocks.NewMetrics(t)
+		metrics.On("SetConverterConfigHash", mock.AnythingOfType("string")).Twice()
+		loader, builder := fixLoader(`{"converter":{"kubernetes":{"defaultVersion":"1.34"}}}`, holder, metrics)
+		kcpClient := builder.Build()
+		_, _, err := loader.Reload(context.Background(), kcpClient)
+		require.NoError(t, err)
+
+		// when
+		_, changed, err := loader.Reload(context.Background(), kcpClient)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Should count a failed read of the ConfigMap", func(t *testing.T) {
+		// given
+		holder := config.NewHolder(requireVersion)
+		require.NoError(t, holder.Load(func() (io.Reader, error) {
+			return strings.NewReader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`), nil
+		}))
+		metrics := mocks.NewMetrics(t)
+		metrics.On("IncConverterConfigReloadErrorCounter").Once()
+		metrics.On("SetConverterConfigHash", holder.Hash()).Once()
+		loader, builder := fixLoader(`{}`, holder, metrics)
+		loader.ConfigMap.Name = "missing"
+
+		// when
+		_, changed, err := loader.Reload(context.Background(), builder.Build())
+
+		// then
+		require.Error(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+	})
+}
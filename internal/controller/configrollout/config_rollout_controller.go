@@ -0,0 +1,408 @@
+package configrollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/configreload"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultHealthTimeout    = 30 * time.Minute
+	defaultRequeueInterval  = 30 * time.Second
+	maxFailedRuntimesListed = 10
+)
+
+// ConfigRolloutReconciler rolls out configuration changes to the Runtimes in waves.
+// The Runtimes of a wave are annotated for forced reconciliation; the next wave starts when all of them were reconciled and are Ready.
+// The rollout is paused when a Runtime fails or doesn't become Ready within the health timeout.
+// A staged converter configuration is used only by the Runtimes of the started waves, and it is promoted when the rollout completes.
+type ConfigRolloutReconciler struct {
+	KcpClient client.Client
+	// RuntimeReader reads the Runtimes bypassing the cache, so that the annotations set when the wave started are never missed
+	RuntimeReader         client.Reader
+	Namespace             string
+	RuntimePredicate      configreload.RuntimePredicate
+	RequeueInterval       time.Duration
+	ConverterConfigLoader *configreload.ConverterConfigLoader
+}
+
+// +kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=configrollouts,verbs=get;list;watch;create;update;patch,namespace=kcp-system
+// +kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=configrollouts/status,verbs=get;update;patch,namespace=kcp-system
+
+func (r *ConfigRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	var rollout imv1.ConfigRollout
+	if err := r.KcpClient.Get(ctx, req.NamespacedName, &rollout); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if rollout.Status.State == imv1.ConfigRolloutStateCompleted || rollout.Status.State == imv1.ConfigRolloutStateSuperseded {
+		return ctrl.Result{}, nil
+	}
+
+	superseded, err := r.isSuperseded(ctx, rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if superseded {
+		logger.Info("Config rollout superseded by a newer rollout", "rollout", rollout.Name)
+		if r.stages(rollout) {
+			r.ConverterConfigLoader.Discard(rollout.Spec.Revision)
+		}
+		return r.updateStatus(ctx, &rollout, imv1.ConfigRolloutStateSuperseded, "A newer rollout of the same source was started")
+	}
+
+	if rollout.Spec.Paused {
+		if rollout.Status.State == imv1.ConfigRolloutStatePaused {
+			return ctrl.Result{}, nil
+		}
+		return r.updateStatus(ctx, &rollout, imv1.ConfigRolloutStatePaused, "Rollout paused")
+	}
+
+	runtimes, _, err := r.listRuntimes(ctx, rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the current wave is rolled out again when the rollout is resumed
+	if rollout.Status.WaveStartTime == nil || rollout.Status.State == imv1.ConfigRolloutStatePaused {
+		return r.startWave(ctx, &rollout, runtimes)
+	}
+
+	return r.checkWave(ctx, &rollout, runtimes)
+}
+
+// startWave annotates the Runtimes of the current wave. The members of a wave are stored in the status when it starts for the first time,
+// and the same Runtimes are annotated again when the rollout is resumed.
+func (r *ConfigRolloutReconciler) startWave(ctx context.Context, rollout *imv1.ConfigRollout, runtimes []imv1.Runtime) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	if len(rollout.Status.Waves) == 0 {
+		rollout.Status.TotalRuntimes = int32(len(runtimes)) //nolint:gosec
+	}
+
+	index := int(rollout.Status.CurrentWave)
+	var current wave
+	if index < len(rollout.Status.Waves) {
+		current = waveMembers(*rollout, runtimes, index)
+	} else {
+		next, found, err := nextWave(*rollout, runtimes, index)
+		if err != nil {
+			logger.Error(err, "invalid config rollout, pausing", "rollout", rollout.Name)
+			return r.pause(ctx, rollout, err.Error())
+		}
+		if !found {
+			// the last started wave stays the current one
+			rollout.Status.CurrentWave = int32(max(index-1, 0)) //nolint:gosec
+			return r.complete(ctx, rollout)
+		}
+		current = next
+	}
+
+	logger.Info("Starting config rollout wave", "rollout", rollout.Name, "wave", current.name, "runtimes", len(current.runtimes))
+
+	var failed []string
+	for _, runtime := range current.runtimes {
+		if err := configreload.ForceReconciliationWithAnnotations(ctx, r.KcpClient, runtime, r.revisionAnnotations(*rollout)); err != nil {
+			logger.Error(err, "unable to annotate runtime", "namespace", runtime.Namespace, "name", runtime.Name)
+			failed = append(failed, runtime.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return ctrl.Result{}, fmt.Errorf("%w: %s", configreload.ErrRuntimeNotificationFailed, formatRuntimes(failed))
+	}
+
+	rollout.Status.WaveStartTime = ptr.To(metav1.Now())
+	setWaveStatus(rollout, index, imv1.ConfigRolloutWaveStatus{
+		Name:     current.name,
+		Runtimes: int32(len(current.runtimes)), //nolint:gosec
+		Members:  runtimeNames(current.runtimes),
+	})
+
+	result, err := r.updateStatus(ctx, rollout, imv1.ConfigRolloutStateProgressing,
+		fmt.Sprintf("Rolling out wave %s to %d runtimes", current.name, len(current.runtimes)))
+	if err != nil {
+		return result, err
+	}
+	return ctrl.Result{RequeueAfter: r.requeueInterval()}, nil
+}
+
+// checkWave waits until the members of the current wave are Ready, Runtimes deleted in the meantime are not awaited
+func (r *ConfigRolloutReconciler) checkWave(ctx context.Context, rollout *imv1.ConfigRollout, runtimes []imv1.Runtime) (ctrl.Result, error) {
+	index := int(rollout.Status.CurrentWave)
+	if index >= len(rollout.Status.Waves) {
+		return r.startWave(ctx, rollout, runtimes)
+	}
+	current := waveMembers(*rollout, runtimes, index)
+	waveStart := rollout.Status.WaveStartTime.Time
+	revisionAnnotations := r.revisionAnnotations(*rollout)
+
+	var ready int32
+	var failed, pending []string
+	for _, runtime := range current.runtimes {
+		switch runtimeHealth(runtime, revisionAnnotations) {
+		case healthReady:
+			ready++
+		case healthFailed:
+			failed = append(failed, runtime.Name)
+		default:
+			pending = append(pending, runtime.Name)
+		}
+	}
+
+	waveStatus := imv1.ConfigRolloutWaveStatus{
+		Name:          current.name,
+		Runtimes:      int32(len(current.runtimes)), //nolint:gosec
+		Members:       rollout.Status.Waves[index].Members,
+		ReadyRuntimes: ready,
+	}
+
+	timedOut := time.Since(waveStart) > healthTimeout(*rollout)
+	if len(failed) > 0 || (len(pending) > 0 && timedOut) {
+		waveStatus.FailedRuntimes = truncate(slices.Concat(failed, pending))
+		setWaveStatus(rollout, index, waveStatus)
+
+		message := fmt.Sprintf("Runtimes of wave %s failed: %s", current.name, formatRuntimes(failed))
+		if len(failed) == 0 {
+			message = fmt.Sprintf("Runtimes of wave %s didn't become Ready within %s: %s", current.name, healthTimeout(*rollout), formatRuntimes(pending))
+		}
+		return r.pause(ctx, rollout, message)
+	}
+
+	if len(pending) > 0 {
+		setWaveStatus(rollout, index, waveStatus)
+		result, err := r.updateStatus(ctx, rollout, imv1.ConfigRolloutStateProgressing,
+			fmt.Sprintf("Waiting for %d of %d runtimes of wave %s to become Ready", len(pending), len(current.runtimes), current.name))
+		if err != nil {
+			return result, err
+		}
+		return ctrl.Result{RequeueAfter: r.requeueInterval()}, nil
+	}
+
+	waveStatus.CompletionTime = ptr.To(metav1.Now())
+	setWaveStatus(rollout, index, waveStatus)
+
+	rollout.Status.CurrentWave++
+	return r.startWave(ctx, rollout, runtimes)
+}
+
+// complete promotes the staged converter configuration, so that it is used by all Runtimes. The Runtimes created after
+// the rollout started were provisioned with the previous configuration, so they are reconciled once more.
+func (r *ConfigRolloutReconciler) complete(ctx context.Context, rollout *imv1.ConfigRollout) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	if r.stages(*rollout) {
+		if !r.ConverterConfigLoader.Promote(rollout.Spec.Revision) {
+			// the staged configuration is lost after a restart, the configuration read on startup is already used by all Runtimes
+			logger.Info("Staged converter configuration not found, keeping the current configuration",
+				"rollout", rollout.Name, "revision", rollout.Spec.Revision, "hash", r.ConverterConfigLoader.Holder.Hash())
+		}
+
+		_, late, err := r.listRuntimes(ctx, *rollout)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		var failed []string
+		for _, runtime := range late {
+			if err := configreload.ForceReconciliation(ctx, r.KcpClient, runtime); err != nil {
+				logger.Error(err, "unable to annotate runtime", "namespace", runtime.Namespace, "name", runtime.Name)
+				failed = append(failed, runtime.Name)
+			}
+		}
+
+		if len(failed) > 0 {
+			return ctrl.Result{}, fmt.Errorf("%w: %s", configreload.ErrRuntimeNotificationFailed, formatRuntimes(failed))
+		}
+	}
+
+	return r.updateStatus(ctx, rollout, imv1.ConfigRolloutStateCompleted, "All waves completed")
+}
+
+// stages checks if the rollout promotes a staged converter configuration
+func (r *ConfigRolloutReconciler) stages(rollout imv1.ConfigRollout) bool {
+	return r.ConverterConfigLoader != nil && r.ConverterConfigLoader.Stages(rollout.Spec.Source)
+}
+
+// revisionAnnotations selects the staged converter configuration for the Runtimes of the started waves
+func (r *ConfigRolloutReconciler) revisionAnnotations(rollout imv1.ConfigRollout) map[string]string {
+	if !r.stages(rollout) {
+		return nil
+	}
+	return map[string]string{reconciler.ConverterConfigRevisionAnnotation: rollout.Spec.Revision}
+}
+
+// pause sets spec.paused, so that the rollout is resumed only after an operator unsets it.
+func (r *ConfigRolloutReconciler) pause(ctx context.Context, rollout *imv1.ConfigRollout, message string) (ctrl.Result, error) {
+	status := rollout.Status.DeepCopy()
+
+	rollout.Spec.Paused = true
+	if err := r.KcpClient.Update(ctx, rollout); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rollout.Status = *status
+	return r.updateStatus(ctx, rollout, imv1.ConfigRolloutStatePaused, message)
+}
+
+func (r *ConfigRolloutReconciler) updateStatus(ctx context.Context, rollout *imv1.ConfigRollout, state imv1.ConfigRolloutState, message string) (ctrl.Result, error) {
+	rollout.Status.State = state
+	rollout.Status.Message = message
+
+	if err := r.KcpClient.Status().Update(ctx, rollout); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// isSuperseded checks if a newer rollout of the same configuration resource exists.
+// Creation timestamps have a resolution of one second, rollouts created within the same second are ordered by name.
+func (r *ConfigRolloutReconciler) isSuperseded(ctx context.Context, rollout imv1.ConfigRollout) (bool, error) {
+	var rollouts imv1.ConfigRolloutList
+	if err := r.KcpClient.List(ctx, &rollouts, client.InNamespace(rollout.Namespace)); err != nil {
+		return false, err
+	}
+
+	for _, other := range rollouts.Items {
+		if other.Name == rollout.Name || other.Spec.Source != rollout.Spec.Source {
+			continue
+		}
+
+		if rollout.CreationTimestamp.Before(&other.CreationTimestamp) ||
+			(rollout.CreationTimestamp.Equal(&other.CreationTimestamp) && rollout.Name < other.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// listRuntimes returns the Runtimes affected by the rollout, and the Runtimes created after the rollout which are not rolled out in waves.
+func (r *ConfigRolloutReconciler) listRuntimes(ctx context.Context, rollout imv1.ConfigRollout) ([]imv1.Runtime, []imv1.Runtime, error) {
+	var runtimes imv1.RuntimeList
+	if err := r.runtimeReader().List(ctx, &runtimes, client.InNamespace(r.Namespace)); err != nil {
+		return nil, nil, err
+	}
+
+	source := types.NamespacedName{Name: rollout.Spec.Source.Name, Namespace: rollout.Spec.Source.Namespace}
+
+	var affected, late []imv1.Runtime
+	for _, runtime := range runtimes.Items {
+		if !runtime.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if r.RuntimePredicate != nil && !r.RuntimePredicate(source, runtime) {
+			continue
+		}
+
+		if rollout.CreationTimestamp.Before(&runtime.CreationTimestamp) {
+			late = append(late, runtime)
+			continue
+		}
+
+		affected = append(affected, runtime)
+	}
+	return affected, late, nil
+}
+
+func (r *ConfigRolloutReconciler) runtimeReader() client.Reader {
+	if r.RuntimeReader != nil {
+		return r.RuntimeReader
+	}
+	return r.KcpClient
+}
+
+func (r *ConfigRolloutReconciler) requeueInterval() time.Duration {
+	if r.RequeueInterval > 0 {
+		return r.RequeueInterval
+	}
+	return defaultRequeueInterval
+}
+
+type health int
+
+const (
+	healthPending health = iota
+	healthReady
+	healthFailed
+)
+
+// runtimeHealth checks if the Runtime was reconciled after the wave started and what its state is.
+// The Runtime controller removes the force reconciliation annotation once the Shoot was patched, even if the patch didn't change it.
+// Runtimes with suspended reconciliation are not awaited.
+func runtimeHealth(runtime imv1.Runtime, revisionAnnotations map[string]string) health {
+	if reconciler.ShouldSuspendReconciliation(runtime.Annotations) {
+		return healthReady
+	}
+
+	if reconciler.ShouldForceReconciliation(runtime.Annotations) {
+		return healthPending
+	}
+
+	// the Runtime doesn't use the staged converter configuration yet
+	for key, value := range revisionAnnotations {
+		if runtime.Annotations[key] != value {
+			return healthPending
+		}
+	}
+
+	switch runtime.Status.State {
+	case imv1.RuntimeStateReady:
+		return healthReady
+	case imv1.RuntimeStateFailed:
+		return healthFailed
+	default:
+		return healthPending
+	}
+}
+
+func healthTimeout(rollout imv1.ConfigRollout) time.Duration {
+	if rollout.Spec.HealthTimeout.Duration > 0 {
+		return rollout.Spec.HealthTimeout.Duration
+	}
+	return defaultHealthTimeout
+}
+
+func setWaveStatus(rollout *imv1.ConfigRollout, index int, waveStatus imv1.ConfigRolloutWaveStatus) {
+	for len(rollout.Status.Waves) <= index {
+		rollout.Status.Waves = append(rollout.Status.Waves, imv1.ConfigRolloutWaveStatus{})
+	}
+	rollout.Status.Waves[index] = waveStatus
+}
+
+func truncate(names []string) []string {
+	if len(names) > maxFailedRuntimesListed {
+		return names[:maxFailedRuntimesListed]
+	}
+	return names
+}
+
+func formatRuntimes(names []string) string {
+	if len(names) <= maxFailedRuntimesListed {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxFailedRuntimesListed], ", "), len(names)-maxFailedRuntimesListed)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ConfigRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&imv1.ConfigRollout{}).
+		Named("configrollout").
+		Complete(r)
+}
@@ -0,0 +1,442 @@
+package configrollout
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/configreload"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const namespace = "kcp-system"
+
+func TestConfigRolloutReconciler(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-3 * time.Hour).Truncate(time.Second))
+
+	fixRuntime := func(name string, state imv1.State, lastTransition time.Time, annotations map[string]string) *imv1.Runtime {
+		return &imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				Annotations:       annotations,
+				CreationTimestamp: metav1.NewTime(created.Add(-time.Hour)),
+			},
+			Status: imv1.RuntimeStatus{
+				State: state,
+				Conditions: []metav1.Condition{{
+					Type:               string(imv1.ConditionTypeRuntimeProvisioned),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(imv1.ConditionReasonConfigurationCompleted),
+					LastTransitionTime: metav1.NewTime(lastTransition),
+				}},
+			},
+		}
+	}
+
+	// fixRollout creates a rollout, the first wave with the members is started when the wave start is set
+	fixRollout := func(name string, waveStart *metav1.Time, members ...string) *imv1.ConfigRollout {
+		rollout := &imv1.ConfigRollout{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: created},
+			Spec: imv1.ConfigRolloutSpec{
+				Source:        imv1.ConfigRolloutSource{Kind: "ConfigMap", Name: "converter-config", Namespace: namespace},
+				Revision:      "1",
+				HealthTimeout: metav1.Duration{Duration: time.Hour},
+			},
+			Status: imv1.ConfigRolloutStatus{
+				State:         imv1.ConfigRolloutStateProgressing,
+				WaveStartTime: waveStart,
+			},
+		}
+
+		if waveStart != nil {
+			rollout.Status.TotalRuntimes = int32(len(members)) //nolint:gosec
+			rollout.Status.Waves = []imv1.ConfigRolloutWaveStatus{{
+				Name:     finalWaveName,
+				Runtimes: int32(len(members)), //nolint:gosec
+				Members:  members,
+			}}
+		}
+		return rollout
+	}
+
+	setup := func(objects ...client.Object) (*ConfigRolloutReconciler, client.Client) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, imv1.AddToScheme(scheme))
+
+		kcpClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(&imv1.ConfigRollout{}, &imv1.Runtime{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				// the fake client doesn't support apply patches of Runtimes, the annotation is stored with an update instead
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if patch.Type() != types.ApplyPatchType {
+						return c.Patch(ctx, obj, patch, opts...)
+					}
+					return c.Update(ctx, obj)
+				},
+			}).
+			Build()
+
+		return &ConfigRolloutReconciler{KcpClient: kcpClient, Namespace: namespace}, kcpClient
+	}
+
+	reconcile := func(t *testing.T, r *ConfigRolloutReconciler, name string) (ctrl.Result, imv1.ConfigRollout) {
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}})
+		require.NoError(t, err)
+
+		var rollout imv1.ConfigRollout
+		require.NoError(t, r.KcpClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &rollout))
+		return result, rollout
+	}
+
+	t.Run("Should annotate the runtimes of the first wave", func(t *testing.T) {
+		// given
+		r, kcpClient := setup(
+			fixRollout("rollout", nil),
+			fixRuntime("runtime", imv1.RuntimeStateReady, created.Add(-time.Hour), nil),
+		)
+
+		// when
+		result, rollout := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, defaultRequeueInterval, result.RequeueAfter)
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, rollout.Status.State)
+		assert.NotNil(t, rollout.Status.WaveStartTime)
+		require.Len(t, rollout.Status.Waves, 1)
+		assert.Equal(t, int32(1), rollout.Status.Waves[0].Runtimes)
+		assert.Equal(t, []string{"runtime"}, rollout.Status.Waves[0].Members)
+		assert.Equal(t, int32(1), rollout.Status.TotalRuntimes)
+
+		var rt imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "runtime", Namespace: namespace}, &rt))
+		assert.True(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+	})
+
+	t.Run("Should wait for runtimes which were not reconciled yet", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		r, _ := setup(
+			fixRollout("rollout", &waveStart, "runtime"),
+			fixRuntime("runtime", imv1.RuntimeStateReady, waveStart.Add(time.Second),
+				map[string]string{reconciler.ForceReconcileAnnotation: "true"}),
+		)
+
+		// when
+		result, rollout := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, defaultRequeueInterval, result.RequeueAfter)
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, rollout.Status.State)
+		assert.False(t, rollout.Spec.Paused)
+	})
+
+	t.Run("Should complete the rollout when the runtimes of the last wave are Ready", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		r, _ := setup(
+			fixRollout("rollout", &waveStart, "runtime"),
+			fixRuntime("runtime", imv1.RuntimeStateReady, waveStart.Add(time.Second), nil),
+		)
+
+		// when
+		_, rollout := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateCompleted, rollout.Status.State)
+		require.Len(t, rollout.Status.Waves, 1)
+		assert.Equal(t, int32(1), rollout.Status.Waves[0].ReadyRuntimes)
+		assert.NotNil(t, rollout.Status.Waves[0].CompletionTime)
+	})
+
+	t.Run("Should start the next wave when the runtimes of the current wave are Ready", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		rollout := fixRollout("rollout", &waveStart, "canary")
+		rollout.Spec.Waves = []imv1.ConfigRolloutWave{{
+			Name:     "canary",
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		}}
+		rollout.Status.TotalRuntimes = 2
+		rollout.Status.Waves[0].Name = "canary"
+		canaryRuntime := fixRuntime("canary", imv1.RuntimeStateReady, waveStart.Add(time.Second), nil)
+		canaryRuntime.Labels = map[string]string{"canary": "true"}
+		r, kcpClient := setup(rollout, canaryRuntime, fixRuntime("runtime", imv1.RuntimeStateReady, created.Add(-time.Hour), nil))
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, updated.Status.State)
+		assert.Equal(t, int32(1), updated.Status.CurrentWave)
+		require.Len(t, updated.Status.Waves, 2)
+		assert.NotNil(t, updated.Status.Waves[0].CompletionTime)
+		assert.Equal(t, finalWaveName, updated.Status.Waves[1].Name)
+
+		var rt imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "runtime", Namespace: namespace}, &rt))
+		assert.True(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+	})
+
+	t.Run("Should keep the members of the waves when runtimes are deleted during the rollout", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		rollout := fixRollout("rollout", &waveStart, "started-a", "started-b")
+		rollout.Spec.Waves = []imv1.ConfigRolloutWave{{Name: "half", Percentage: ptr.To(int32(50))}}
+		rollout.Status.TotalRuntimes = 4
+		rollout.Status.Waves[0].Name = "half"
+		r, kcpClient := setup(rollout,
+			fixRuntime("started-a", imv1.RuntimeStateReady, waveStart.Add(time.Second), nil),
+			fixRuntime("remaining-a", imv1.RuntimeStateReady, created.Add(-time.Hour), nil),
+			fixRuntime("remaining-b", imv1.RuntimeStateReady, created.Add(-time.Hour), nil),
+		)
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, updated.Status.State)
+		assert.Equal(t, int32(1), updated.Status.CurrentWave)
+		require.Len(t, updated.Status.Waves, 2)
+		assert.Equal(t, int32(1), updated.Status.Waves[0].Runtimes)
+		assert.NotNil(t, updated.Status.Waves[0].CompletionTime)
+		assert.Equal(t, finalWaveName, updated.Status.Waves[1].Name)
+		assert.ElementsMatch(t, []string{"remaining-a", "remaining-b"}, updated.Status.Waves[1].Members)
+
+		for _, name := range []string{"remaining-a", "remaining-b"} {
+			var rt imv1.Runtime
+			require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &rt))
+			assert.True(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+		}
+	})
+
+	t.Run("Should pause the rollout when a runtime failed", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		r, _ := setup(
+			fixRollout("rollout", &waveStart, "failed", "ready"),
+			fixRuntime("failed", imv1.RuntimeStateFailed, waveStart.Add(time.Second), nil),
+			fixRuntime("ready", imv1.RuntimeStateReady, waveStart.Add(time.Second), nil),
+		)
+
+		// when
+		_, rollout := reconcile(t, r, "rollout")
+
+		// then
+		assert.True(t, rollout.Spec.Paused)
+		assert.Equal(t, imv1.ConfigRolloutStatePaused, rollout.Status.State)
+		require.Len(t, rollout.Status.Waves, 1)
+		assert.Equal(t, []string{"failed"}, rollout.Status.Waves[0].FailedRuntimes)
+		assert.Equal(t, int32(1), rollout.Status.Waves[0].ReadyRuntimes)
+	})
+
+	t.Run("Should pause the rollout when the runtimes don't become Ready within the health timeout", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		r, _ := setup(
+			fixRollout("rollout", &waveStart, "pending"),
+			fixRuntime("pending", imv1.RuntimeStatePending, waveStart.Add(-time.Minute), nil),
+		)
+
+		// when
+		_, rollout := reconcile(t, r, "rollout")
+
+		// then
+		assert.True(t, rollout.Spec.Paused)
+		assert.Equal(t, imv1.ConfigRolloutStatePaused, rollout.Status.State)
+		assert.Equal(t, []string{"pending"}, rollout.Status.Waves[0].FailedRuntimes)
+	})
+
+	t.Run("Should supersede the rollout when a newer rollout of the same source exists", func(t *testing.T) {
+		// given
+		newer := fixRollout("newer", nil)
+		newer.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+		r, _ := setup(fixRollout("rollout", nil), newer)
+
+		// when
+		_, rollout := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateSuperseded, rollout.Status.State)
+	})
+
+	t.Run("Should supersede the rollout when a newer rollout was created within the same second", func(t *testing.T) {
+		// given
+		r, _ := setup(fixRollout("rollout-a", nil), fixRollout("rollout-b", nil))
+
+		// when
+		_, older := reconcile(t, r, "rollout-a")
+		_, newer := reconcile(t, r, "rollout-b")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateSuperseded, older.Status.State)
+		assert.NotEqual(t, imv1.ConfigRolloutStateSuperseded, newer.Status.State)
+	})
+
+	stage := func(t *testing.T, r *ConfigRolloutReconciler) (*config.Holder, string) {
+		holder := config.NewHolder(func(config.Config) error { return nil })
+		require.NoError(t, holder.Load(func() (io.Reader, error) {
+			return strings.NewReader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`), nil
+		}))
+		revision, err := holder.Stage(func() (io.Reader, error) {
+			return strings.NewReader(`{"converter":{"kubernetes":{"defaultVersion":"1.34"}}}`), nil
+		})
+		require.NoError(t, err)
+
+		metrics := mocks.NewMetrics(t)
+		metrics.On("SetConverterConfigHash", mock.AnythingOfType("string")).Maybe()
+
+		r.ConverterConfigLoader = &configreload.ConverterConfigLoader{
+			ConfigMap: types.NamespacedName{Name: "converter-config", Namespace: namespace},
+			Holder:    holder,
+			Metrics:   metrics,
+			Staged:    true,
+		}
+		return holder, revision
+	}
+
+	t.Run("Should select the staged configuration for the runtimes of the wave", func(t *testing.T) {
+		// given
+		r, kcpClient := setup(fixRuntime("runtime", imv1.RuntimeStateReady, created.Add(-time.Hour), nil))
+		holder, revision := stage(t, r)
+
+		rollout := fixRollout("rollout", nil)
+		rollout.Spec.Revision = revision
+		require.NoError(t, kcpClient.Create(context.Background(), rollout))
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, updated.Status.State)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+
+		var rt imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "runtime", Namespace: namespace}, &rt))
+		assert.True(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+		assert.Equal(t, revision, rt.Annotations[reconciler.ConverterConfigRevisionAnnotation])
+	})
+
+	t.Run("Should promote the staged configuration when the rollout completes", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		r, kcpClient := setup()
+		holder, revision := stage(t, r)
+
+		rollout := fixRollout("rollout", &waveStart, "runtime")
+		rollout.Spec.Revision = revision
+		late := fixRuntime("late", imv1.RuntimeStateReady, waveStart.Add(time.Second), nil)
+		late.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+		for _, object := range []client.Object{
+			rollout,
+			fixRuntime("runtime", imv1.RuntimeStateReady, waveStart.Add(time.Second),
+				map[string]string{reconciler.ConverterConfigRevisionAnnotation: revision}),
+			late,
+		} {
+			require.NoError(t, kcpClient.Create(context.Background(), object))
+		}
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateCompleted, updated.Status.State)
+		assert.Equal(t, "1.34", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+
+		var rt imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "late", Namespace: namespace}, &rt))
+		assert.True(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+	})
+
+	t.Run("Should wait for runtimes which don't use the staged configuration yet", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-time.Minute))
+		r, kcpClient := setup(fixRuntime("runtime", imv1.RuntimeStateReady, waveStart.Add(time.Second), nil))
+		holder, revision := stage(t, r)
+
+		rollout := fixRollout("rollout", &waveStart, "runtime")
+		rollout.Spec.Revision = revision
+		require.NoError(t, kcpClient.Create(context.Background(), rollout))
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, updated.Status.State)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+	})
+
+	t.Run("Should discard the staged configuration of a superseded rollout", func(t *testing.T) {
+		// given
+		newer := fixRollout("newer", nil)
+		newer.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+		r, kcpClient := setup(newer)
+		holder, revision := stage(t, r)
+
+		rollout := fixRollout("rollout", nil)
+		rollout.Spec.Revision = revision
+		require.NoError(t, kcpClient.Create(context.Background(), rollout))
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateSuperseded, updated.Status.State)
+		assert.False(t, holder.Promote(revision))
+	})
+
+	t.Run("Should not annotate runtimes of a paused rollout", func(t *testing.T) {
+		// given
+		rollout := fixRollout("rollout", nil)
+		rollout.Spec.Paused = true
+		r, kcpClient := setup(rollout, fixRuntime("runtime", imv1.RuntimeStateReady, created.Add(-time.Hour), nil))
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStatePaused, updated.Status.State)
+
+		var rt imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "runtime", Namespace: namespace}, &rt))
+		assert.False(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+	})
+
+	t.Run("Should roll out the current wave again when the rollout is resumed", func(t *testing.T) {
+		// given
+		waveStart := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		rollout := fixRollout("rollout", &waveStart, "runtime")
+		rollout.Status.State = imv1.ConfigRolloutStatePaused
+		r, kcpClient := setup(rollout, fixRuntime("runtime", imv1.RuntimeStateFailed, waveStart.Add(time.Second), nil))
+
+		// when
+		_, updated := reconcile(t, r, "rollout")
+
+		// then
+		assert.Equal(t, imv1.ConfigRolloutStateProgressing, updated.Status.State)
+		assert.True(t, updated.Status.WaveStartTime.After(waveStart.Time))
+
+		var rt imv1.Runtime
+		require.NoError(t, kcpClient.Get(context.Background(), types.NamespacedName{Name: "runtime", Namespace: namespace}, &rt))
+		assert.True(t, reconciler.ShouldForceReconciliation(rt.Annotations))
+	})
+
+}
@@ -0,0 +1,160 @@
+package configrollout
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+const finalWaveName = "remaining"
+
+type wave struct {
+	name     string
+	runtimes []imv1.Runtime
+}
+
+// nextWave selects the Runtimes of the wave at the index among the Runtimes which are not members of the started waves.
+// Every Runtime is assigned to the first wave selecting it. Runtimes which are not selected by any wave are assigned to a final wave.
+// The percentages are calculated from the number of Runtimes when the rollout started, so deleted Runtimes don't move Runtimes between the waves.
+// The order of the Runtimes is randomized per rollout, but stable between reconciliations.
+// It returns false when the rollout has no wave at the index.
+func nextWave(rollout imv1.ConfigRollout, runtimes []imv1.Runtime, index int) (wave, bool, error) {
+	started := map[string]struct{}{}
+	for _, waveStatus := range rollout.Status.Waves[:min(index, len(rollout.Status.Waves))] {
+		for _, member := range waveStatus.Members {
+			started[member] = struct{}{}
+		}
+	}
+
+	remaining := make([]imv1.Runtime, 0, len(runtimes))
+	for _, runtime := range runtimes {
+		if _, found := started[runtime.Name]; !found {
+			remaining = append(remaining, runtime)
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return rolloutOrder(rollout, remaining[i]) < rolloutOrder(rollout, remaining[j])
+	})
+
+	if index >= len(rollout.Spec.Waves) {
+		if index > len(rollout.Spec.Waves) || (len(remaining) == 0 && len(rollout.Spec.Waves) > 0) {
+			return wave{}, false, nil
+		}
+		return wave{name: finalWaveName, runtimes: remaining}, true, nil
+	}
+
+	waveSpec := rollout.Spec.Waves[index]
+	selector, err := waveSelector(waveSpec)
+	if err != nil {
+		return wave{}, false, err
+	}
+
+	limit := len(remaining)
+	if waveSpec.Percentage != nil {
+		limit = percentageOf(int(rollout.Status.TotalRuntimes), *waveSpec.Percentage) - len(started)
+	}
+
+	var selected []imv1.Runtime
+	for _, runtime := range remaining {
+		if len(selected) < limit && selector.Matches(labels.Set(runtime.Labels)) &&
+			(len(waveSpec.Regions) == 0 || slices.Contains(waveSpec.Regions, runtime.Spec.Shoot.Region)) {
+			selected = append(selected, runtime)
+		}
+	}
+
+	return wave{name: waveSpec.Name, runtimes: selected}, true, nil
+}
+
+// waveMembers returns the Runtimes of the started wave at the index, the Runtimes deleted in the meantime are skipped
+func waveMembers(rollout imv1.ConfigRollout, runtimes []imv1.Runtime, index int) wave {
+	waveStatus := rollout.Status.Waves[index]
+	names := map[string]struct{}{}
+	for _, member := range waveStatus.Members {
+		names[member] = struct{}{}
+	}
+
+	var members []imv1.Runtime
+	for _, runtime := range runtimes {
+		if _, found := names[runtime.Name]; found {
+			members = append(members, runtime)
+		}
+	}
+	return wave{name: waveStatus.Name, runtimes: members}
+}
+
+func runtimeNames(runtimes []imv1.Runtime) []string {
+	names := make([]string, 0, len(runtimes))
+	for _, runtime := range runtimes {
+		names = append(names, runtime.Name)
+	}
+	return names
+}
+
+func waveSelector(waveSpec imv1.ConfigRolloutWave) (labels.Selector, error) {
+	if waveSpec.Selector == nil {
+		return labels.Everything(), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(waveSpec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of wave %s: %w", waveSpec.Name, err)
+	}
+	return selector, nil
+}
+
+func percentageOf(total int, percentage int32) int {
+	return (total*int(percentage) + 99) / 100
+}
+
+func rolloutOrder(rollout imv1.ConfigRollout, runtime imv1.Runtime) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(rollout.Name + "/" + runtime.Name))
+	return h.Sum64()
+}
+
+// NewTemplate creates the rollout specification used for the configuration changes detected by the ConfigReloadWatcher.
+// The canary wave selects the Runtimes matching the label selector; each percentage adds a wave rolling out this share of all Runtimes.
+func NewTemplate(canarySelector, percentages string, healthTimeout time.Duration) (imv1.ConfigRolloutSpec, error) {
+	template := imv1.ConfigRolloutSpec{
+		HealthTimeout: metav1.Duration{Duration: healthTimeout},
+	}
+
+	if canarySelector != "" {
+		selector, err := metav1.ParseToLabelSelector(canarySelector)
+		if err != nil {
+			return imv1.ConfigRolloutSpec{}, fmt.Errorf("invalid canary selector: %w", err)
+		}
+
+		template.Waves = append(template.Waves, imv1.ConfigRolloutWave{
+			Name:     "canary",
+			Selector: selector,
+		})
+	}
+
+	if percentages == "" {
+		return template, nil
+	}
+
+	for _, value := range strings.Split(percentages, ",") {
+		percentage, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+		if err != nil || percentage < 1 || percentage > 100 {
+			return imv1.ConfigRolloutSpec{}, fmt.Errorf("invalid wave percentage %q, must be a number between 1 and 100", value)
+		}
+
+		template.Waves = append(template.Waves, imv1.ConfigRolloutWave{
+			Name:       fmt.Sprintf("%d-percent", percentage),
+			Percentage: ptr.To(int32(percentage)),
+		})
+	}
+
+	return template, nil
+}
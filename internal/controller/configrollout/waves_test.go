@@ -0,0 +1,232 @@
+package configrollout
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestNextWave(t *testing.T) {
+	fixRuntimes := func(count int, region string, labels map[string]string) []imv1.Runtime {
+		runtimes := make([]imv1.Runtime, 0, count)
+		for i := range count {
+			runtime := imv1.Runtime{ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("%s-runtime-%d", region, i),
+				Labels: labels,
+			}}
+			runtime.Spec.Shoot.Region = region
+			runtimes = append(runtimes, runtime)
+		}
+		return runtimes
+	}
+
+	fixRollout := func(waves ...imv1.ConfigRolloutWave) imv1.ConfigRollout {
+		return imv1.ConfigRollout{
+			ObjectMeta: metav1.ObjectMeta{Name: "rollout"},
+			Spec:       imv1.ConfigRolloutSpec{Waves: waves},
+		}
+	}
+
+	canary := map[string]string{"canary": "true"}
+
+	// startWaves starts the waves one after another, as the controller does when the Runtimes don't change during the rollout
+	startWaves := func(t *testing.T, rollout imv1.ConfigRollout, runtimes []imv1.Runtime) []wave {
+		rollout.Status.TotalRuntimes = int32(len(runtimes)) //nolint:gosec
+
+		var waves []wave
+		for index := 0; ; index++ {
+			next, found, err := nextWave(rollout, runtimes, index)
+			require.NoError(t, err)
+			if !found {
+				return waves
+			}
+			waves = append(waves, next)
+			rollout.Status.Waves = append(rollout.Status.Waves, imv1.ConfigRolloutWaveStatus{Name: next.name, Members: runtimeNames(next.runtimes)})
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		rollout       imv1.ConfigRollout
+		runtimes      []imv1.Runtime
+		expectedNames []string
+		expectedSizes []int
+	}{
+		{
+			name:          "Should roll out all runtimes in a single wave when no waves are defined",
+			rollout:       fixRollout(),
+			runtimes:      fixRuntimes(5, "eu", nil),
+			expectedNames: []string{finalWaveName},
+			expectedSizes: []int{5},
+		},
+		{
+			name: "Should roll out canary runtimes first",
+			rollout: fixRollout(imv1.ConfigRolloutWave{
+				Name:     "canary",
+				Selector: &metav1.LabelSelector{MatchLabels: canary},
+			}),
+			runtimes:      append(fixRuntimes(2, "us", canary), fixRuntimes(6, "eu", nil)...),
+			expectedNames: []string{"canary", finalWaveName},
+			expectedSizes: []int{2, 6},
+		},
+		{
+			name: "Should limit the waves by the cumulative percentage of all runtimes",
+			rollout: fixRollout(
+				imv1.ConfigRolloutWave{Name: "10-percent", Percentage: ptr.To(int32(10))},
+				imv1.ConfigRolloutWave{Name: "50-percent", Percentage: ptr.To(int32(50))},
+			),
+			runtimes:      fixRuntimes(20, "eu", nil),
+			expectedNames: []string{"10-percent", "50-percent", finalWaveName},
+			expectedSizes: []int{2, 8, 10},
+		},
+		{
+			name: "Should select runtimes by region",
+			rollout: fixRollout(imv1.ConfigRolloutWave{
+				Name:    "us",
+				Regions: []string{"us"},
+			}),
+			runtimes:      append(fixRuntimes(3, "us", nil), fixRuntimes(4, "eu", nil)...),
+			expectedNames: []string{"us", finalWaveName},
+			expectedSizes: []int{3, 4},
+		},
+		{
+			name: "Should not add a final wave when all runtimes are selected",
+			rollout: fixRollout(
+				imv1.ConfigRolloutWave{Name: "canary", Selector: &metav1.LabelSelector{MatchLabels: canary}},
+				imv1.ConfigRolloutWave{Name: "all"},
+			),
+			runtimes:      append(fixRuntimes(1, "us", canary), fixRuntimes(4, "eu", nil)...),
+			expectedNames: []string{"canary", "all"},
+			expectedSizes: []int{1, 4},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			waves := startWaves(t, tc.rollout, tc.runtimes)
+
+			// then
+			require.Len(t, waves, len(tc.expectedNames))
+			for i, w := range waves {
+				assert.Equal(t, tc.expectedNames[i], w.name)
+				assert.Len(t, w.runtimes, tc.expectedSizes[i])
+			}
+		})
+	}
+
+	t.Run("Should assign the runtimes to the same waves on every reconciliation", func(t *testing.T) {
+		// given
+		rollout := fixRollout(imv1.ConfigRolloutWave{Name: "half", Percentage: ptr.To(int32(50))})
+		runtimes := fixRuntimes(10, "eu", nil)
+		reversed := make([]imv1.Runtime, 0, len(runtimes))
+		for i := len(runtimes) - 1; i >= 0; i-- {
+			reversed = append(reversed, runtimes[i])
+		}
+
+		// when
+		first := startWaves(t, rollout, runtimes)
+		second := startWaves(t, rollout, reversed)
+
+		// then
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("Should reject an invalid selector", func(t *testing.T) {
+		// given
+		rollout := fixRollout(imv1.ConfigRolloutWave{
+			Name: "invalid",
+			Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "canary", Operator: "Unknown"},
+			}},
+		})
+
+		// when
+		_, _, err := nextWave(rollout, fixRuntimes(1, "eu", nil), 0)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("Should keep the runtimes in their waves when runtimes are deleted during the rollout", func(t *testing.T) {
+		// given
+		rollout := fixRollout(
+			imv1.ConfigRolloutWave{Name: "10-percent", Percentage: ptr.To(int32(10))},
+			imv1.ConfigRolloutWave{Name: "50-percent", Percentage: ptr.To(int32(50))},
+		)
+		runtimes := fixRuntimes(20, "eu", nil)
+		rollout.Status.TotalRuntimes = 20
+
+		first, found, err := nextWave(rollout, runtimes, 0)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Len(t, first.runtimes, 2)
+		rollout.Status.Waves = []imv1.ConfigRolloutWaveStatus{{Name: first.name, Members: runtimeNames(first.runtimes)}}
+
+		// a runtime of the started wave and five other runtimes are deleted
+		var remaining []imv1.Runtime
+		deleted := 0
+		for _, runtime := range runtimes {
+			if runtime.Name == first.runtimes[0].Name || (deleted < 5 && runtime.Name != first.runtimes[1].Name) {
+				if runtime.Name != first.runtimes[0].Name {
+					deleted++
+				}
+				continue
+			}
+			remaining = append(remaining, runtime)
+		}
+		require.Len(t, remaining, 14)
+
+		// when
+		members := waveMembers(rollout, remaining, 0)
+		second, found, err := nextWave(rollout, remaining, 1)
+
+		// then
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, []string{first.runtimes[1].Name}, runtimeNames(members.runtimes))
+		assert.Len(t, second.runtimes, 8)
+		assert.NotContains(t, runtimeNames(second.runtimes), first.runtimes[1].Name)
+	})
+}
+
+func TestNewTemplate(t *testing.T) {
+	t.Run("Should create the canary and percentage waves", func(t *testing.T) {
+		// when
+		template, err := NewTemplate("canary=true", "10, 50", time.Hour)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, template.HealthTimeout.Duration)
+		require.Len(t, template.Waves, 3)
+		assert.Equal(t, "canary", template.Waves[0].Name)
+		assert.Equal(t, map[string]string{"canary": "true"}, template.Waves[0].Selector.MatchLabels)
+		assert.Equal(t, "10-percent", template.Waves[1].Name)
+		assert.Equal(t, ptr.To(int32(10)), template.Waves[1].Percentage)
+		assert.Equal(t, "50-percent", template.Waves[2].Name)
+		assert.Equal(t, ptr.To(int32(50)), template.Waves[2].Percentage)
+	})
+
+	t.Run("Should create no waves by default", func(t *testing.T) {
+		// when
+		template, err := NewTemplate("", "", time.Hour)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, template.Waves)
+	})
+
+	for _, percentages := range []string{"0", "101", "ten"} {
+		t.Run("Should reject the wave percentage "+percentages, func(t *testing.T) {
+			// when
+			_, err := NewTemplate("", percentages, time.Hour)
+
+			// then
+			require.Error(t, err)
+		})
+	}
+}
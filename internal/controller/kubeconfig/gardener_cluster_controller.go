@@ -24,7 +24,9 @@ import (
 	"github.com/go-logr/logr"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/sharding"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/landscape"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -37,11 +39,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	pkgctrl "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
 	lastKubeconfigSyncAnnotation      = "operator.kyma-project.io/last-sync"
-	forceKubeconfigRotationAnnotation = "operator.kyma-project.io/force-kubeconfig-rotation"
+	ForceKubeconfigRotationAnnotation = "operator.kyma-project.io/force-kubeconfig-rotation"
 	clusterCRNameLabel                = "operator.kyma-project.io/cluster-name"
 
 	rotationPeriodRatio = 0.95
@@ -57,6 +60,8 @@ type GardenerClusterController struct {
 	minimalRotationTimeRatio float64
 	gardenerRequestTimeout   time.Duration
 	metrics                  metrics.Metrics
+	// Shards limits the reconciliation to the GardenerCluster CRs of the shards owned by the replica, all GardenerCluster CRs are reconciled when it is nil
+	Shards *sharding.Coordinator
 }
 
 func NewGardenerClusterController(mgr ctrl.Manager, kubeconfigProvider KubeconfigProvider, logger logr.Logger, rotationPeriod time.Duration, minimalRotationTimeRatio float64, gardenerRequestTimeout time.Duration, metrics metrics.Metrics) *GardenerClusterController {
@@ -76,7 +81,7 @@ func NewGardenerClusterController(mgr ctrl.Manager, kubeconfigProvider Kubeconfi
 //
 //go:generate mockery --name=KubeconfigProvider
 type KubeconfigProvider interface {
-	Fetch(ctx context.Context, shootName string) (string, error)
+	Fetch(ctx context.Context, landscape, gardenerProject, shootName string) (string, error)
 }
 
 //+kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=gardenerclusters,verbs=get;list;watch;create;update;patch;delete,namespace=kcp-system
@@ -114,6 +119,11 @@ func (controller *GardenerClusterController) Reconcile(ctx context.Context, req
 		return controller.resultWithoutRequeue(&cluster), err
 	}
 
+	if controller.Shards.Skips(&cluster) {
+		controller.log.Info("Skipping GardenerCluster of a shard owned by another replica", loggingContext(req)...)
+		return ctrl.Result{}, nil
+	}
+
 	secret, err := controller.getSecret(reconciliationContext, cluster.Spec.Shoot.Name)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		cluster.UpdateConditionForErrorState(imv1.ConditionTypeKubeconfigManagement, imv1.ConditionReasonFailedToGetSecret, err)
@@ -168,7 +178,7 @@ func (controller *GardenerClusterController) unsetMetrics(req ctrl.Request) {
 }
 
 func loggingContextFromCluster(cluster *imv1.GardenerCluster) []any {
-	return []any{"GardenerCluster", cluster.Name, "Namespace", cluster.Namespace}
+	return []any{"GardenerCluster", cluster.Name, "Namespace", cluster.Namespace, "landscape", landscape.NameFromLabels(cluster.Labels)}
 }
 
 func loggingContext(req ctrl.Request) []any {
@@ -256,7 +266,7 @@ const (
 )
 
 func (controller *GardenerClusterController) handleKubeconfig(ctx context.Context, secret *corev1.Secret, cluster *imv1.GardenerCluster, now time.Time) (kubeconfigStatus, error) {
-	kubeconfig, err := controller.KubeconfigProvider.Fetch(ctx, cluster.Spec.Shoot.Name)
+	kubeconfig, err := controller.KubeconfigProvider.Fetch(ctx, landscape.NameFromLabels(cluster.Labels), cluster.Labels[imv1.LabelKymaGardenerProject], cluster.Spec.Shoot.Name)
 	if err != nil {
 		cluster.UpdateConditionForErrorState(imv1.ConditionTypeKubeconfigManagement, imv1.ConditionReasonFailedToGetKubeconfig, err)
 		return ksZero, err
@@ -319,7 +329,7 @@ func secretRotationForced(cluster *imv1.GardenerCluster) bool {
 		return false
 	}
 
-	_, found := annotations[forceKubeconfigRotationAnnotation]
+	_, found := annotations[ForceKubeconfigRotationAnnotation]
 	return found
 }
 
@@ -398,7 +408,7 @@ func (controller *GardenerClusterController) removeForceRotationAnnotation(ctx c
 		}
 
 		annotations := clusterToUpdate.GetAnnotations()
-		delete(annotations, forceKubeconfigRotationAnnotation)
+		delete(annotations, ForceKubeconfigRotationAnnotation)
 		clusterToUpdate.SetAnnotations(annotations)
 
 		return controller.Update(ctx, &clusterToUpdate)
@@ -429,12 +439,25 @@ func (controller *GardenerClusterController) newSecret(cluster imv1.GardenerClus
 
 // SetupWithManager sets up the controller with the Manager.
 func (controller *GardenerClusterController) SetupWithManager(mgr ctrl.Manager, numberOfWorkers int) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&imv1.GardenerCluster{}, builder.WithPredicates(predicate.Or(
-			predicate.LabelChangedPredicate{},
-			predicate.AnnotationChangedPredicate{},
-			predicate.GenerationChangedPredicate{}),
-		)).
-		WithOptions(pkgctrl.Options{MaxConcurrentReconciles: numberOfWorkers}).
-		Complete(controller)
+	options := pkgctrl.Options{MaxConcurrentReconciles: numberOfWorkers}
+	predicates := []predicate.Predicate{predicate.Or(
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.GenerationChangedPredicate{}),
+	}
+	var shardSource source.Source
+	if controller.Shards != nil {
+		var shardPredicate predicate.Predicate
+		shardPredicate, shardSource = controller.Shards.Setup(&options, func() client.ObjectList { return &imv1.GardenerClusterList{} })
+		predicates = append(predicates, shardPredicate)
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&imv1.GardenerCluster{}, builder.WithPredicates(predicates...)).
+		WithOptions(options)
+
+	if shardSource != nil {
+		b = b.WatchesRawSource(shardSource)
+	}
+	return b.Complete(controller)
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	v1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/landscape"
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -14,6 +15,7 @@ const (
 	runtimeIDKeyName               = "runtimeId"
 	runtimeNameKeyName             = "runtimeName"
 	shootKeyName                   = "shoot"
+	landscapeKeyName               = "landscape"
 	rotationDuration               = "rotationDuration"
 	expirationDuration             = "expirationDuration"
 	componentName                  = "infrastructure_manager"
@@ -22,21 +24,53 @@ const (
 	GardenerClusterStateMetricName = "im_gardener_clusters_state"
 	RuntimeStateMetricName         = "im_runtime_state"
 	RuntimeFSMStopMetricName       = "unexpected_stops_total"
+	RuntimeShootDriftMetricName    = "im_runtime_shoot_drift"
+	ConverterConfigMetricName      = "im_converter_config_info"
+	ConverterConfigReloadErrorName = "im_converter_config_reload_errors_total"
+	RuntimeFSMStateDurationName    = "im_runtime_fsm_state_duration_seconds"
+	RuntimeFSMTransitionsName      = "im_runtime_fsm_transitions_total"
+	RuntimeOperationDurationName   = "im_runtime_operation_duration_seconds"
+	RuntimeFailuresName            = "im_runtime_failures_total"
 	provider                       = "provider"
 	state                          = "state"
 	reason                         = "reason"
 	message                        = "message"
+	path                           = "path"
+	stateFn                        = "stateFn"
+	from                           = "from"
+	to                             = "to"
+	operation                      = "operation"
+	region                         = "region"
+	purpose                        = "purpose"
+	hash                           = "hash"
 	KubeconfigExpirationMetricName = "im_kubeconfig_expiration"
 	expires                        = "expires"
 	lastSyncAnnotation             = "operator.kyma-project.io/last-sync"
 )
 
+// RuntimeOperation is the end-to-end operation of a Runtime measured with the im_runtime_operation_duration_seconds metric
+type RuntimeOperation string
+
+const (
+	OperationProvisioning   RuntimeOperation = "provisioning"
+	OperationUpdate         RuntimeOperation = "update"
+	OperationDeprovisioning RuntimeOperation = "deprovisioning"
+)
+
 //go:generate mockery --name=Metrics
 type Metrics interface {
 	SetRuntimeStates(runtime v1.Runtime)
 	CleanUpRuntimeGauge(runtimeID, runtimeName string)
 	ResetRuntimeMetrics()
 	IncRuntimeFSMStopCounter()
+	ObserveRuntimeFSMStateDuration(stateFnName string, duration time.Duration)
+	IncRuntimeFSMTransitionCounter(fromStateFn, toStateFn string)
+	ObserveRuntimeOperationDuration(runtime v1.Runtime, operation RuntimeOperation, duration time.Duration)
+	IncRuntimeFailureCounter(runtime v1.Runtime, reason v1.RuntimeConditionReason)
+	SetShootDrift(runtime v1.Runtime, driftedPaths []string)
+	CleanUpShootDrift(runtimeID, runtimeName string)
+	SetConverterConfigHash(hash string)
+	IncConverterConfigReloadErrorCounter()
 	SetGardenerClusterStates(cluster v1.GardenerCluster)
 	CleanUpGardenerClusterGauge(runtimeID string)
 	CleanUpKubeconfigExpiration(runtimeID string)
@@ -48,6 +82,13 @@ type metricsImpl struct {
 	kubeconfigExpirationGauge     *prometheus.GaugeVec
 	runtimeStateGauge             *prometheus.GaugeVec
 	runtimeFSMUnexpectedStopsCnt  prometheus.Counter
+	runtimeFSMStateDuration       *prometheus.HistogramVec
+	runtimeFSMTransitionsCnt      *prometheus.CounterVec
+	runtimeOperationDuration      *prometheus.HistogramVec
+	runtimeFailuresCnt            *prometheus.CounterVec
+	shootDriftGauge               *prometheus.GaugeVec
+	converterConfigGauge          *prometheus.GaugeVec
+	converterConfigReloadErrorCnt prometheus.Counter
 }
 
 func NewMetrics() Metrics {
@@ -57,7 +98,7 @@ func NewMetrics() Metrics {
 				Subsystem: componentName,
 				Name:      GardenerClusterStateMetricName,
 				Help:      "Indicates the Status.state for GardenerCluster CRs",
-			}, []string{runtimeIDKeyName, shootKeyName, state, reason}),
+			}, []string{runtimeIDKeyName, shootKeyName, landscapeKeyName, state, reason}),
 		kubeconfigExpirationGauge: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Subsystem: componentName,
@@ -69,14 +110,59 @@ func NewMetrics() Metrics {
 				Subsystem: componentName,
 				Name:      RuntimeStateMetricName,
 				Help:      "Exposes current Status.state for Runtime CRs",
-			}, []string{runtimeIDKeyName, runtimeNameKeyName, shootKeyName, provider, state, message}),
+			}, []string{runtimeIDKeyName, runtimeNameKeyName, shootKeyName, landscapeKeyName, provider, state, message}),
 		runtimeFSMUnexpectedStopsCnt: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Name: RuntimeFSMStopMetricName,
 				Help: "Exposes the number of unexpected state machine stop events",
 			}),
+		runtimeFSMStateDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem: componentName,
+				Name:      RuntimeFSMStateDurationName,
+				Help:      "Exposes the time spent in the state functions of the Runtime state machine",
+				Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+			}, []string{stateFn}),
+		runtimeFSMTransitionsCnt: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: componentName,
+				Name:      RuntimeFSMTransitionsName,
+				Help:      "Exposes the number of transitions between the state functions of the Runtime state machine",
+			}, []string{from, to}),
+		runtimeOperationDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Subsystem: componentName,
+				Name:      RuntimeOperationDurationName,
+				Help:      "Exposes the end-to-end duration of the provisioning, update, and deprovisioning of runtimes",
+				Buckets:   []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200, 10800},
+			}, []string{operation, provider, region, purpose}),
+		runtimeFailuresCnt: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Subsystem: componentName,
+				Name:      RuntimeFailuresName,
+				Help:      "Exposes the number of runtimes which entered the Failed state by condition reason",
+			}, []string{provider, reason}),
+		shootDriftGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: componentName,
+				Name:      RuntimeShootDriftMetricName,
+				Help:      "Exposes the Shoot fields which differ from the Shoot generated for the Runtime CR",
+			}, []string{runtimeIDKeyName, runtimeNameKeyName, shootKeyName, path}),
+		converterConfigGauge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Subsystem: componentName,
+				Name:      ConverterConfigMetricName,
+				Help:      "Exposes the SHA-256 hash of the converter configuration which is currently in use",
+			}, []string{hash}),
+		converterConfigReloadErrorCnt: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Subsystem: componentName,
+				Name:      ConverterConfigReloadErrorName,
+				Help:      "Exposes the number of rejected converter configuration updates",
+			}),
 	}
-	ctrlMetrics.Registry.MustRegister(m.gardenerClustersStateGaugeVec, m.kubeconfigExpirationGauge, m.runtimeStateGauge, m.runtimeFSMUnexpectedStopsCnt)
+	ctrlMetrics.Registry.MustRegister(m.gardenerClustersStateGaugeVec, m.kubeconfigExpirationGauge, m.runtimeStateGauge, m.runtimeFSMUnexpectedStopsCnt, m.shootDriftGauge,
+		m.converterConfigGauge, m.converterConfigReloadErrorCnt, m.runtimeFSMStateDuration, m.runtimeFSMTransitionsCnt, m.runtimeOperationDuration, m.runtimeFailuresCnt)
 	return m
 }
 
@@ -92,7 +178,7 @@ func (m metricsImpl) SetRuntimeStates(runtime v1.Runtime) {
 		}
 
 		m.CleanUpRuntimeGauge(runtimeID, runtime.Name)
-		m.runtimeStateGauge.WithLabelValues(runtimeID, runtime.Name, runtime.Spec.Shoot.Name, runtime.Spec.Shoot.Provider.Type, string(runtime.Status.State), reason).Set(1)
+		m.runtimeStateGauge.WithLabelValues(runtimeID, runtime.Name, runtime.Spec.Shoot.Name, landscape.NameFromLabels(runtime.Labels), runtime.Spec.Shoot.Provider.Type, string(runtime.Status.State), reason).Set(1)
 	}
 }
 
@@ -105,12 +191,61 @@ func (m metricsImpl) CleanUpRuntimeGauge(runtimeID, runtimeName string) {
 
 func (m metricsImpl) ResetRuntimeMetrics() {
 	m.runtimeStateGauge.Reset()
+	m.shootDriftGauge.Reset()
+}
+
+func (m metricsImpl) SetShootDrift(runtime v1.Runtime, driftedPaths []string) {
+	runtimeID := runtime.GetLabels()[RuntimeIDLabel]
+	if runtimeID == "" {
+		return
+	}
+
+	// first clean the old metric, no series is left when the Shoot doesn't drift
+	m.CleanUpShootDrift(runtimeID, runtime.Name)
+
+	for _, driftedPath := range driftedPaths {
+		m.shootDriftGauge.WithLabelValues(runtimeID, runtime.Name, runtime.Spec.Shoot.Name, driftedPath).Set(1)
+	}
+}
+
+// CleanUpShootDrift removes the drifted paths of a Runtime, the status updates of the Runtime keep them
+func (m metricsImpl) CleanUpShootDrift(runtimeID, runtimeName string) {
+	m.shootDriftGauge.DeletePartialMatch(prometheus.Labels{
+		runtimeIDKeyName:   runtimeID,
+		runtimeNameKeyName: runtimeName,
+	})
+}
+
+func (m metricsImpl) SetConverterConfigHash(configHash string) {
+	m.converterConfigGauge.Reset()
+	m.converterConfigGauge.WithLabelValues(configHash).Set(1)
+}
+
+func (m metricsImpl) IncConverterConfigReloadErrorCounter() {
+	m.converterConfigReloadErrorCnt.Inc()
 }
 
 func (m metricsImpl) IncRuntimeFSMStopCounter() {
 	m.runtimeFSMUnexpectedStopsCnt.Inc()
 }
 
+func (m metricsImpl) ObserveRuntimeFSMStateDuration(stateFnName string, duration time.Duration) {
+	m.runtimeFSMStateDuration.WithLabelValues(stateFnName).Observe(duration.Seconds())
+}
+
+func (m metricsImpl) IncRuntimeFSMTransitionCounter(fromStateFn, toStateFn string) {
+	m.runtimeFSMTransitionsCnt.WithLabelValues(fromStateFn, toStateFn).Inc()
+}
+
+func (m metricsImpl) ObserveRuntimeOperationDuration(runtime v1.Runtime, op RuntimeOperation, duration time.Duration) {
+	m.runtimeOperationDuration.WithLabelValues(string(op), runtime.Spec.Shoot.Provider.Type, runtime.Spec.Shoot.Region, string(runtime.Spec.Shoot.Purpose)).
+		Observe(duration.Seconds())
+}
+
+func (m metricsImpl) IncRuntimeFailureCounter(runtime v1.Runtime, failureReason v1.RuntimeConditionReason) {
+	m.runtimeFailuresCnt.WithLabelValues(runtime.Spec.Shoot.Provider.Type, string(failureReason)).Inc()
+}
+
 func (m metricsImpl) SetGardenerClusterStates(cluster v1.GardenerCluster) {
 	var runtimeID = cluster.GetLabels()[RuntimeIDLabel]
 	var shootName = cluster.GetLabels()[ShootNameLabel]
@@ -121,7 +256,7 @@ func (m metricsImpl) SetGardenerClusterStates(cluster v1.GardenerCluster) {
 
 			// first clean the old metric
 			m.CleanUpGardenerClusterGauge(runtimeID)
-			m.gardenerClustersStateGaugeVec.WithLabelValues(runtimeID, shootName, string(cluster.Status.State), reason).Set(1)
+			m.gardenerClustersStateGaugeVec.WithLabelValues(runtimeID, shootName, landscape.NameFromLabels(cluster.Labels), string(cluster.Status.State), reason).Set(1)
 		}
 	}
 }
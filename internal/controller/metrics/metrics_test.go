@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"testing"
+
+	v1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestShootDrift(t *testing.T) {
+	m := NewMetrics().(*metricsImpl)
+
+	runtime := v1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-runtime",
+			Labels: map[string]string{RuntimeIDLabel: "runtime-id"},
+		},
+		Spec: v1.RuntimeSpec{Shoot: v1.RuntimeShoot{Name: "test-shoot"}},
+	}
+
+	t.Run("Should keep the drifted paths when the status of the Runtime is written", func(t *testing.T) {
+		// when
+		m.SetShootDrift(runtime, []string{"spec.kubernetes.version", "spec.provider.workers"})
+		m.SetRuntimeStates(runtime)
+
+		// then
+		assert.Equal(t, 2, testutil.CollectAndCount(m.shootDriftGauge))
+		assert.Equal(t, float64(1), testutil.ToFloat64(m.shootDriftGauge.WithLabelValues("runtime-id", "test-runtime", "test-shoot", "spec.kubernetes.version")))
+	})
+
+	t.Run("Should remove the drifted paths when the Shoot doesn't drift anymore", func(t *testing.T) {
+		// given
+		m.SetShootDrift(runtime, []string{"spec.kubernetes.version"})
+
+		// when
+		m.SetShootDrift(runtime, nil)
+
+		// then
+		assert.Equal(t, 0, testutil.CollectAndCount(m.shootDriftGauge))
+	})
+
+	t.Run("Should remove the drifted paths of a deleted Runtime", func(t *testing.T) {
+		// given
+		m.SetShootDrift(runtime, []string{"spec.kubernetes.version"})
+
+		// when
+		m.CleanUpRuntimeGauge("runtime-id", "test-runtime")
+		m.CleanUpShootDrift("runtime-id", "test-runtime")
+
+		// then
+		assert.Equal(t, 0, testutil.CollectAndCount(m.shootDriftGauge))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	time "time"
 
 	v1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	metrics "github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
 	mock "github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -30,16 +31,51 @@ func (_m *Metrics) CleanUpRuntimeGauge(runtimeID string, runtimeName string) {
 	_m.Called(runtimeID, runtimeName)
 }
 
+// CleanUpShootDrift provides a mock function with given fields: runtimeID, runtimeName
+func (_m *Metrics) CleanUpShootDrift(runtimeID string, runtimeName string) {
+	_m.Called(runtimeID, runtimeName)
+}
+
+// IncConverterConfigReloadErrorCounter provides a mock function with given fields:
+func (_m *Metrics) IncConverterConfigReloadErrorCounter() {
+	_m.Called()
+}
+
+// IncRuntimeFSMTransitionCounter provides a mock function with given fields: fromStateFn, toStateFn
+func (_m *Metrics) IncRuntimeFSMTransitionCounter(fromStateFn string, toStateFn string) {
+	_m.Called(fromStateFn, toStateFn)
+}
+
 // IncRuntimeFSMStopCounter provides a mock function with given fields:
 func (_m *Metrics) IncRuntimeFSMStopCounter() {
 	_m.Called()
 }
 
+// IncRuntimeFailureCounter provides a mock function with given fields: runtime, reason
+func (_m *Metrics) IncRuntimeFailureCounter(runtime v1.Runtime, reason v1.RuntimeConditionReason) {
+	_m.Called(runtime, reason)
+}
+
+// ObserveRuntimeFSMStateDuration provides a mock function with given fields: stateFnName, duration
+func (_m *Metrics) ObserveRuntimeFSMStateDuration(stateFnName string, duration time.Duration) {
+	_m.Called(stateFnName, duration)
+}
+
+// ObserveRuntimeOperationDuration provides a mock function with given fields: runtime, operation, duration
+func (_m *Metrics) ObserveRuntimeOperationDuration(runtime v1.Runtime, operation metrics.RuntimeOperation, duration time.Duration) {
+	_m.Called(runtime, operation, duration)
+}
+
 // ResetRuntimeMetrics provides a mock function with given fields:
 func (_m *Metrics) ResetRuntimeMetrics() {
 	_m.Called()
 }
 
+// SetConverterConfigHash provides a mock function with given fields: hash
+func (_m *Metrics) SetConverterConfigHash(hash string) {
+	_m.Called(hash)
+}
+
 // SetGardenerClusterStates provides a mock function with given fields: cluster
 func (_m *Metrics) SetGardenerClusterStates(cluster v1.GardenerCluster) {
 	_m.Called(cluster)
@@ -55,6 +91,11 @@ func (_m *Metrics) SetRuntimeStates(runtime v1.Runtime) {
 	_m.Called(runtime)
 }
 
+// SetShootDrift provides a mock function with given fields: runtime, driftedPaths
+func (_m *Metrics) SetShootDrift(runtime v1.Runtime, driftedPaths []string) {
+	_m.Called(runtime, driftedPaths)
+}
+
 // NewMetrics creates a new instance of Metrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMetrics(t interface {
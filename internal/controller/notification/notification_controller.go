@@ -0,0 +1,208 @@
+package notification
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/sharding"
+	"github.com/kyma-project/infrastructure-manager/pkg/notification"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Deliverer sends an encoded notification to a sink
+type Deliverer interface {
+	Deliver(ctx context.Context, event []byte) error
+}
+
+// NotificationReconciler delivers the notifications stored in the outbox to the sinks.
+// Delivered notifications are removed from the outbox, failed deliveries are retried with an exponential backoff
+// until the maximal number of attempts is reached.
+// The notifications of a Runtime CR are delivered to every sink in the order of their sequence.
+type NotificationReconciler struct {
+	KcpClient   client.Client
+	Sinks       map[string]Deliverer
+	RetryPolicy notification.RetryPolicy
+	Log         logr.Logger
+	// Shards limits the delivery to the notifications of the Runtime CRs of the shards owned by the replica, all notifications are delivered when it is nil
+	Shards *sharding.Coordinator
+	// Now returns the current time, defaults to time.Now
+	Now func() time.Time
+}
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete,namespace=kcp-system
+
+func (r *NotificationReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	var entry corev1.ConfigMap
+	if err := r.KcpClient.Get(ctx, request.NamespacedName, &entry); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if entry.Labels[notification.OutboxLabel] != "true" {
+		return ctrl.Result{}, nil
+	}
+
+	if r.Shards.Skips(&entry) {
+		return ctrl.Result{}, nil
+	}
+
+	log := r.Log.WithValues("notification", entry.Name, "sink", notification.SinkName(&entry))
+
+	sink, found := r.Sinks[notification.SinkName(&entry)]
+	if !found {
+		log.Info("Dropping notification of a sink which is not configured anymore")
+		return ctrl.Result{}, r.remove(ctx, &entry)
+	}
+
+	queue, err := r.queue(ctx, &entry)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(queue) > 0 && queue[0].Name != entry.Name {
+		// the removal of the earlier notification enqueues this one again
+		log.V(1).Info("Waiting for the delivery of an earlier notification", "earlierNotification", queue[0].Name)
+		return ctrl.Result{}, nil
+	}
+
+	now := r.now()
+	if next := notification.NextAttempt(&entry); next.After(now) {
+		return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	deliveryErr := sink.Deliver(ctx, notification.Event(&entry))
+	if deliveryErr == nil {
+		log.V(1).Info("Notification delivered")
+		return ctrl.Result{}, r.remove(ctx, &entry)
+	}
+
+	attempts := notification.Attempts(&entry) + 1
+	if attempts >= r.RetryPolicy.MaxAttempts {
+		log.Error(deliveryErr, "Dropping notification after the maximal number of delivery attempts", "attempts", attempts)
+		return ctrl.Result{}, r.remove(ctx, &entry)
+	}
+
+	delay := r.RetryPolicy.Delay(attempts)
+	log.Info("Failed to deliver notification, retrying", "error", deliveryErr.Error(), "attempts", attempts, "retryAfter", delay)
+
+	// the update fails with a conflict when another replica took over the notification in the meantime
+	notification.SetFailedAttempt(&entry, attempts, now.Add(delay))
+	if err := r.KcpClient.Update(ctx, &entry); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	return ctrl.Result{RequeueAfter: delay}, nil
+}
+
+func (r *NotificationReconciler) remove(ctx context.Context, entry *corev1.ConfigMap) error {
+	err := r.KcpClient.Delete(ctx, entry, client.Preconditions{ResourceVersion: &entry.ResourceVersion})
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// queue returns the notifications of the Runtime CR of the entry for the same sink, ordered by their sequence
+func (r *NotificationReconciler) queue(ctx context.Context, entry *corev1.ConfigMap) ([]corev1.ConfigMap, error) {
+	runtimeID := notification.RuntimeID(entry)
+	if runtimeID == "" {
+		return nil, nil
+	}
+
+	var entries corev1.ConfigMapList
+	if err := r.KcpClient.List(ctx, &entries, client.InNamespace(entry.Namespace), client.MatchingLabels{
+		notification.OutboxLabel: "true",
+		imv1.LabelKymaRuntimeID:  runtimeID,
+	}); err != nil {
+		return nil, err
+	}
+
+	queue := make([]corev1.ConfigMap, 0, len(entries.Items))
+	for _, queued := range entries.Items {
+		if notification.SinkName(&queued) == notification.SinkName(entry) {
+			queue = append(queue, queued)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		if seqI, seqJ := notification.Sequence(&queue[i]), notification.Sequence(&queue[j]); seqI != seqJ {
+			return seqI < seqJ
+		}
+		return queue[i].Name < queue[j].Name
+	})
+	return queue, nil
+}
+
+// queuedEntries maps an outbox entry to itself and the other notifications of its Runtime CR for the same sink,
+// so the removal of a delivered notification triggers the delivery of the next one
+func (r *NotificationReconciler) queuedEntries(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(obj)}}
+
+	entry, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return requests
+	}
+
+	queue, err := r.queue(ctx, entry)
+	if err != nil {
+		r.Log.Error(err, "Failed to list the queued notifications", "notification", entry.Name)
+		return requests
+	}
+
+	for _, queued := range queue {
+		if queued.Name != entry.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&queued)})
+		}
+	}
+	return requests
+}
+
+func (r *NotificationReconciler) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NotificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	options := controller.Options{}
+	predicates := []predicate.Predicate{outboxPredicate()}
+	var shardSource source.Source
+	if r.Shards != nil {
+		var shardPredicate predicate.Predicate
+		shardPredicate, shardSource = r.Shards.Setup(&options, func() client.ObjectList { return &corev1.ConfigMapList{} })
+		predicates = append(predicates, shardPredicate)
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("notification").
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.queuedEntries), builder.WithPredicates(predicates...)).
+		WithOptions(options)
+
+	if shardSource != nil {
+		b = b.WatchesRawSource(shardSource)
+	}
+	return b.Complete(r)
+}
+
+// outboxPredicate passes the ConfigMaps of the outbox, updates are dropped as they are made by the controller itself
+func outboxPredicate() predicate.Predicate {
+	return predicate.And(
+		predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetLabels()[notification.OutboxLabel] == "true"
+		}),
+		predicate.Funcs{
+			UpdateFunc: func(_ event.UpdateEvent) bool { return false },
+		},
+	)
+}
@@ -0,0 +1,236 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const namespace = "kcp-system"
+
+type delivererFunc func(ctx context.Context, event []byte) error
+
+func (f delivererFunc) Deliver(ctx context.Context, event []byte) error {
+	return f(ctx, event)
+}
+
+func TestNotificationReconciler(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	retryPolicy := notification.RetryPolicy{MaxAttempts: 3, InitialDelay: 10 * time.Second, MaxDelay: time.Minute}
+
+	fixEntry := func(sink string, annotations map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "runtime-notification-abcde",
+				Namespace:   namespace,
+				Labels:      map[string]string{notification.OutboxLabel: "true"},
+				Annotations: annotations,
+			},
+			Data: map[string]string{"sink": sink, "event": `{"specversion":"1.0"}`},
+		}
+	}
+
+	setup := func(deliver delivererFunc, objects ...client.Object) (*NotificationReconciler, client.Client) {
+		scheme := runtime.NewScheme()
+		require.NoError(t, corev1.AddToScheme(scheme))
+
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		return &NotificationReconciler{
+			KcpClient:   kcpClient,
+			Sinks:       map[string]Deliverer{"broker": deliver},
+			RetryPolicy: retryPolicy,
+			Log:         logr.Discard(),
+			Now:         func() time.Time { return now },
+		}, kcpClient
+	}
+
+	fixQueuedEntry := func(name string, sequence int, sink string) *corev1.ConfigMap {
+		entry := fixEntry(sink, map[string]string{notification.SequenceAnnotation: strconv.Itoa(sequence)})
+		entry.Name = name
+		entry.Labels[imv1.LabelKymaRuntimeID] = "runtime-id"
+		return entry
+	}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "runtime-notification-abcde", Namespace: namespace}}
+
+	getEntry := func(t *testing.T, kcpClient client.Client) (*corev1.ConfigMap, error) {
+		var entry corev1.ConfigMap
+		err := kcpClient.Get(context.Background(), request.NamespacedName, &entry)
+		return &entry, err
+	}
+
+	t.Run("Should remove delivered notification from the outbox", func(t *testing.T) {
+		// given
+		var delivered []byte
+		reconciler, kcpClient := setup(func(_ context.Context, event []byte) error {
+			delivered = event
+			return nil
+		}, fixEntry("broker", nil))
+
+		// when
+		result, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+		assert.JSONEq(t, `{"specversion":"1.0"}`, string(delivered))
+
+		_, err = getEntry(t, kcpClient)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("Should record failed attempt and retry with backoff", func(t *testing.T) {
+		// given
+		reconciler, kcpClient := setup(func(context.Context, []byte) error {
+			return errors.New("connection refused")
+		}, fixEntry("broker", map[string]string{notification.AttemptsAnnotation: "1"}))
+
+		// when
+		result, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: 20 * time.Second}, result)
+
+		entry, err := getEntry(t, kcpClient)
+		require.NoError(t, err)
+		assert.Equal(t, 2, notification.Attempts(entry))
+		assert.Equal(t, now.Add(20*time.Second), notification.NextAttempt(entry))
+	})
+
+	t.Run("Should drop notification after the maximal number of attempts", func(t *testing.T) {
+		// given
+		reconciler, kcpClient := setup(func(context.Context, []byte) error {
+			return errors.New("connection refused")
+		}, fixEntry("broker", map[string]string{notification.AttemptsAnnotation: "2"}))
+
+		// when
+		result, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		_, err = getEntry(t, kcpClient)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("Should wait until the next attempt is due", func(t *testing.T) {
+		// given
+		reconciler, kcpClient := setup(func(context.Context, []byte) error {
+			t.Fatal("notification must not be delivered before the next attempt is due")
+			return nil
+		}, fixEntry("broker", map[string]string{
+			notification.AttemptsAnnotation:    "1",
+			notification.NextAttemptAnnotation: now.Add(time.Minute).Format(time.RFC3339),
+		}))
+
+		// when
+		result, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, result)
+
+		_, err = getEntry(t, kcpClient)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should drop notification of a sink which is not configured", func(t *testing.T) {
+		// given
+		reconciler, kcpClient := setup(func(context.Context, []byte) error {
+			t.Fatal("notification must not be delivered to another sink")
+			return nil
+		}, fixEntry("removed", nil))
+
+		// when
+		result, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		_, err = getEntry(t, kcpClient)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("Should wait for the delivery of an earlier notification of the runtime", func(t *testing.T) {
+		// given
+		reconciler, kcpClient := setup(func(context.Context, []byte) error {
+			t.Fatal("notification must not be delivered before the earlier one")
+			return nil
+		},
+			fixQueuedEntry("runtime-notification-abcde", 2, "broker"),
+			fixQueuedEntry("runtime-notification-earlier", 1, "broker"),
+		)
+
+		// when
+		result, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+
+		_, err = getEntry(t, kcpClient)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should deliver notification when the earlier ones are for another sink", func(t *testing.T) {
+		// given
+		delivered := false
+		reconciler, kcpClient := setup(func(context.Context, []byte) error {
+			delivered = true
+			return nil
+		},
+			fixQueuedEntry("runtime-notification-abcde", 2, "broker"),
+			fixQueuedEntry("runtime-notification-billing", 1, "billing"),
+		)
+
+		// when
+		_, err := reconciler.Reconcile(context.Background(), request)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, delivered)
+
+		_, err = getEntry(t, kcpClient)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("Should enqueue the notifications of the runtime for the same sink", func(t *testing.T) {
+		// given
+		first := fixQueuedEntry("runtime-notification-first", 1, "broker")
+		reconciler, _ := setup(nil,
+			fixQueuedEntry("runtime-notification-third", 3, "broker"),
+			fixQueuedEntry("runtime-notification-second", 2, "broker"),
+			fixQueuedEntry("runtime-notification-billing", 2, "billing"),
+		)
+
+		// when
+		requests := reconciler.queuedEntries(context.Background(), first)
+
+		// then
+		assert.Equal(t, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "runtime-notification-first", Namespace: namespace}},
+			{NamespacedName: types.NamespacedName{Name: "runtime-notification-second", Namespace: namespace}},
+			{NamespacedName: types.NamespacedName{Name: "runtime-notification-third", Namespace: namespace}},
+		}, requests)
+	})
+}
@@ -13,6 +13,12 @@ import (
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/errorcatalog"
+	"github.com/kyma-project/infrastructure-manager/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,14 +31,17 @@ type stateFn func(context.Context, *fsm, *systemState) (stateFn, *ctrl.Result, e
 
 // runtime reconciler specific configuration
 type RCCfg struct {
-	GardenerRequeueDuration              time.Duration
-	RequeueDurationShootCreate           time.Duration
-	RequeueDurationShootDelete           time.Duration
-	RequeueDurationShootReconcile        time.Duration
+	GardenerRequeueDuration       time.Duration
+	RequeueDurationShootCreate    time.Duration
+	RequeueDurationShootDelete    time.Duration
+	RequeueDurationShootReconcile time.Duration
+	// ShootWatchResyncPeriod replaces the requeue durations of the Shoot operations for Shoots watched by the ShootCache
+	ShootWatchResyncPeriod               time.Duration
 	ControlPlaneRequeueDuration          time.Duration
 	StatusRequeueDelay                   time.Duration
+	DeletionGracePeriod                  time.Duration
+	ShootDriftDetectionInterval          time.Duration
 	Finalizer                            string
-	ShootNamesapace                      string
 	AuditLogMandatory                    bool
 	ApiServerAclEnabled                  bool
 	NetworkRestrictionGlobalEnabled      bool
@@ -42,6 +51,21 @@ type RCCfg struct {
 	RegistryCacheConfigControllerEnabled bool
 	RuntimeBootstrapperEnabled           bool
 	RuntimeBootstrapperInstaller         RuntimeBootstrapperInstaller
+	CloudProfileValidationEnabled        bool
+	CloudProfileValidator                *cloudprofile.Validator
+	CloudProfileReader                   client.Reader
+	// ConfigHolder provides the reloadable configuration; when set, it replaces the embedded Config for each reconciliation
+	ConfigHolder *config.Holder
+	// ConfigRevision selects the staged revision of the reloadable configuration which is rolled out to the Runtime
+	ConfigRevision string
+	// LandscapeProjectName is the Gardener project of runtimes which don't belong to the default Gardener landscape
+	LandscapeProjectName string
+	// ErrorCatalog classifies the errors of failed Shoot operations, the default catalog is used when it is nil
+	ErrorCatalog *errorcatalog.Catalog
+	// FailureRecovery configures the automatic retries of Runtimes in the Failed state
+	FailureRecovery FailureRecoveryConfig
+	// Notifier publishes the lifecycle transitions of the Runtimes, the notifications are disabled when it is nil
+	Notifier Notifier
 	config.Config
 }
 
@@ -56,10 +80,18 @@ func (f stateFn) name() string {
 
 type Watch = func(src source.Source, eventhandler handler.EventHandler, predicates ...predicate.Predicate) error
 
+// ShootCache is the cache of the Shoots in the namespaces of the Gardener projects known at the start of KIM
+type ShootCache interface {
+	client.Reader
+	Watches(namespace string) bool
+}
+
 type K8s struct {
 	KcpClient client.Client
 	record.EventRecorder
-	GardenClient        client.Client
+	GardenClient client.Client
+	// ShootCache reads the Shoots from the cache of the Shoot watch, the GardenClient is used when it is nil
+	ShootCache          ShootCache
 	RuntimeClientGetter RuntimeClientGetter
 }
 
@@ -79,6 +111,16 @@ func (m *fsm) Run(ctx context.Context, v imv1.Runtime) (ctrl.Result, error) {
 	state := systemState{instance: v}
 	var err error
 	var result *ctrl.Result
+
+	ctx, span := tracing.Tracer().Start(ctx, "Runtime reconciliation", trace.WithAttributes(
+		attribute.String("runtime.id", v.Labels[imv1.LabelKymaRuntimeID]),
+		attribute.String("runtime.namespace", v.Namespace),
+		attribute.String("runtime.name", v.Name),
+		attribute.String("shoot.name", v.Spec.Shoot.Name),
+	))
+	defer func() {
+		endSpan(span, err)
+	}()
 loop:
 	for {
 		select {
@@ -87,7 +129,13 @@ loop:
 			break loop
 		default:
 			stateFnName := m.fn.name()
-			m.fn, result, err = m.fn(ctx, m, &state)
+			shortStateFnName := stateFnShortName(m.fn)
+			started := time.Now()
+			stateCtx, stateSpan := tracing.Tracer().Start(ctx, shortStateFnName)
+			m.fn, result, err = m.fn(stateCtx, m, &state)
+			endSpan(stateSpan, err)
+			m.Metrics.ObserveRuntimeFSMStateDuration(shortStateFnName, time.Since(started))
+			m.Metrics.IncRuntimeFSMTransitionCounter(shortStateFnName, stateFnShortName(m.fn))
 			newStateFnName := m.fn.name()
 			m.log.V(log_level.TRACE).WithValues("result", result, "err", err, "mFnIsNill", m.fn == nil).Info(fmt.Sprintf("switching state from %s to %s", stateFnName, newStateFnName))
 			if m.fn == nil || err != nil {
@@ -109,7 +157,27 @@ loop:
 	}, err
 }
 
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 func NewFsm(log logr.Logger, cfg RCCfg, k8s K8s) Fsm {
+	// a single reconciliation uses the same configuration even if it is reloaded in the meantime
+	if cfg.ConfigHolder != nil {
+		cfg.Config = cfg.ConfigHolder.GetRevision(cfg.ConfigRevision)
+	}
+
+	// runtimes of additional landscapes are always placed in the project of their landscape
+	if cfg.LandscapeProjectName != "" {
+		cfg.ConverterConfig.Gardener.ProjectName = cfg.LandscapeProjectName
+		cfg.ConverterConfig.Gardener.Projects = nil
+		cfg.ConverterConfig.Gardener.Placement = config.PlacementConfig{}
+	}
+
 	return &fsm{
 		fn:    sFnTakeSnapshot,
 		RCCfg: cfg,
@@ -24,7 +24,11 @@ var _ = Describe(`runtime_fsm_apply_crb`, Label("applyCRB"), func() {
 		m := &mocks.Metrics{}
 		m.On("SetRuntimeStates", mock.Anything).Return()
 		m.On("CleanUpRuntimeGauge", mock.Anything, mock.Anything).Return()
+		m.On("CleanUpShootDrift", mock.Anything, mock.Anything).Return()
 		m.On("IncRuntimeFSMStopCounter").Return()
+		m.On("ObserveRuntimeFSMStateDuration", mock.Anything, mock.Anything).Return()
+		m.On("IncRuntimeFSMTransitionCounter", mock.Anything, mock.Anything).Return()
+		m.On("ObserveRuntimeOperationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
 		return withMetrics(m)
 	}
 
@@ -2,7 +2,6 @@ package fsm
 
 import (
 	"context"
-	"fmt"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	"github.com/kyma-project/infrastructure-manager/internal/registrycache"
@@ -32,7 +31,7 @@ func sFnCleanupRegistryCacheGardenSecrets(ctx context.Context, m *fsm, s *system
 
 	secretManager := registrycache.NewGardenSecretManager(
 		m.GardenClient,
-		fmt.Sprintf("garden-%s", m.ConverterConfig.Gardener.ProjectName),
+		gardenerNamespace(m, s),
 		s.instance.Name)
 
 	m.log.V(log_level.DEBUG).Info("Registry cache secrets deletion", "instance", s.instance.Name)
@@ -125,7 +125,6 @@ func TestFnCleanupRegistryCacheGardenSecrets_RuntimeClientGetterFails(t *testing
 	testFSM := must(newFakeFSM,
 		withMockedMetrics(),
 		withTestFinalizer,
-		withShootNamespace("garden-"),
 		withFailedRuntimeK8sClient(getterErr, testScheme),
 		withFakeEventRecorder(1),
 		withDefaultReconcileDuration(),
@@ -7,6 +7,7 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,26 +31,26 @@ func sFnHandleKubeconfig(ctx context.Context, m *fsm, s *systemState) (stateFn,
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			m.log.Error(err, "GardenerCluster CR read error", "name", runtimeID)
-			s.instance.UpdateStateFailed(
+			m.Metrics.IncRuntimeFSMStopCounter()
+			return updateStateFailedWithErrorAndStop(
+				m, s,
 				imv1.ConditionTypeRuntimeKubeconfigReady,
 				imv1.ConditionReasonKubernetesAPIErr,
 				err.Error(),
 			)
-			m.Metrics.IncRuntimeFSMStopCounter()
-			return updateStatusAndStop()
 		}
 
 		m.log.V(log_level.DEBUG).Info("GardenerCluster CR not found, creating a new one", "name", runtimeID)
-		err = m.KcpClient.Create(ctx, makeGardenerClusterForRuntime(s.instance, s.shoot))
+		err = m.KcpClient.Create(ctx, makeGardenerClusterForRuntime(s.instance, s.shoot, project.FromRuntime(s.instance, m.ConverterConfig.Gardener.ProjectName)))
 		if err != nil {
 			m.log.Error(err, "GardenerCluster CR create error", "name", runtimeID)
-			s.instance.UpdateStateFailed(
+			m.Metrics.IncRuntimeFSMStopCounter()
+			return updateStateFailedWithErrorAndStop(
+				m, s,
 				imv1.ConditionTypeRuntimeKubeconfigReady,
 				imv1.ConditionReasonKubernetesAPIErr,
 				err.Error(),
 			)
-			m.Metrics.IncRuntimeFSMStopCounter()
-			return updateStatusAndStop()
 		}
 
 		s.instance.UpdateStatePending(imv1.ConditionTypeRuntimeKubeconfigReady, imv1.ConditionReasonGardenerCRCreated, metav1.ConditionUnknown, "Gardener Cluster CR created, waiting for readiness")
@@ -70,7 +71,7 @@ func sFnHandleKubeconfig(ctx context.Context, m *fsm, s *systemState) (stateFn,
 		sFnCreateKymaNamespace)
 }
 
-func makeGardenerClusterForRuntime(runtime imv1.Runtime, shoot *gardener.Shoot) *imv1.GardenerCluster {
+func makeGardenerClusterForRuntime(runtime imv1.Runtime, shoot *gardener.Shoot, gardenerProject string) *imv1.GardenerCluster {
 	gardenCluster := &imv1.GardenerCluster{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "GardenerCluster",
@@ -83,13 +84,15 @@ func makeGardenerClusterForRuntime(runtime imv1.Runtime, shoot *gardener.Shoot)
 				"skr-domain": *shoot.Spec.DNS.Domain,
 			},
 			Labels: map[string]string{
-				imv1.LabelKymaInstanceID:      runtime.Labels[imv1.LabelKymaInstanceID],
-				imv1.LabelKymaRuntimeID:       runtime.Labels[imv1.LabelKymaRuntimeID],
-				imv1.LabelKymaBrokerPlanID:    runtime.Labels[imv1.LabelKymaBrokerPlanID],
-				imv1.LabelKymaBrokerPlanName:  runtime.Labels[imv1.LabelKymaBrokerPlanName],
-				imv1.LabelKymaGlobalAccountID: runtime.Labels[imv1.LabelKymaGlobalAccountID],
-				imv1.LabelKymaSubaccountID:    runtime.Labels[imv1.LabelKymaSubaccountID], // BTW most likely this value will be missing
-				imv1.LabelKymaName:            runtime.Labels[imv1.LabelKymaName],
+				imv1.LabelKymaInstanceID:        runtime.Labels[imv1.LabelKymaInstanceID],
+				imv1.LabelKymaRuntimeID:         runtime.Labels[imv1.LabelKymaRuntimeID],
+				imv1.LabelKymaBrokerPlanID:      runtime.Labels[imv1.LabelKymaBrokerPlanID],
+				imv1.LabelKymaBrokerPlanName:    runtime.Labels[imv1.LabelKymaBrokerPlanName],
+				imv1.LabelKymaGlobalAccountID:   runtime.Labels[imv1.LabelKymaGlobalAccountID],
+				imv1.LabelKymaSubaccountID:      runtime.Labels[imv1.LabelKymaSubaccountID], // BTW most likely this value will be missing
+				imv1.LabelKymaName:              runtime.Labels[imv1.LabelKymaName],
+				imv1.LabelKymaGardenerProject:   gardenerProject,
+				imv1.LabelKymaGardenerLandscape: runtime.Labels[imv1.LabelKymaGardenerLandscape],
 
 				// values from Runtime CR fields
 				imv1.LabelKymaPlatformRegion: runtime.Spec.Shoot.PlatformRegion,
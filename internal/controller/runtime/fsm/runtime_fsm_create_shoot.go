@@ -44,7 +44,7 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 			m.log.Error(nil, msg)
 			m.Metrics.IncRuntimeFSMStopCounter()
 			return updateStateFailedWithErrorAndStop(
-				&s.instance,
+				m, s,
 				imv1.ConditionTypeRuntimeProvisioned,
 				imv1.ConditionReasonSeedNotFound,
 				msg)
@@ -73,7 +73,7 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 			m.log.Error(err, "Cannot provision runtime with dedicated audit logging")
 			m.Metrics.IncRuntimeFSMStopCounter()
 			return updateStateFailedWithErrorAndStop(
-				&s.instance,
+				m, s,
 				imv1.ConditionTypeRuntimeProvisioned,
 				imv1.ConditionReasonCustomAuditLogError,
 				msg)
@@ -86,13 +86,13 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 	cmName := fmt.Sprintf(extender.StructuredAuthConfigFmt, s.instance.Spec.Shoot.Name)
 	oidcConfig := structuredauth.GetOIDCConfigOrDefault(s.instance, m.ConverterConfig.Kubernetes.DefaultOperatorOidc.ToOIDCConfig())
 
-	err := structuredauth.CreateOrUpdateStructuredAuthConfigMap(ctx, m.GardenClient, types.NamespacedName{Name: cmName, Namespace: m.ShootNamesapace}, oidcConfig)
+	err := structuredauth.CreateOrUpdateStructuredAuthConfigMap(ctx, m.GardenClient, types.NamespacedName{Name: cmName, Namespace: gardenerNamespace(m, s)}, oidcConfig)
 	if err != nil {
 		m.log.Error(err, "Failed to create structured authentication config map")
 
 		m.Metrics.IncRuntimeFSMStopCounter()
 		return updateStateFailedWithErrorAndStop(
-			&s.instance,
+			m, s,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonOidcError,
 			msgFailedStructuredConfigMap)
@@ -111,7 +111,7 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 	if err != nil && m.AuditLogMandatory {
 		m.Metrics.IncRuntimeFSMStopCounter()
 		return updateStateFailedWithErrorAndStop(
-			&s.instance,
+			m, s,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonAuditLogError,
 			msgFailedToConfigureAuditlogs)
@@ -127,24 +127,58 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 	timeBoundaries, _ := token.ValidateTokenExpirationTime(m.ConverterConfig.Kubernetes.KubeApiServer.MaxTokenExpiration)
 	logTokenExpirationInfo(m.log, timeBoundaries)
 
-	shoot, err := convertCreate(ctx, &s.instance, gardener_shoot.CreateOpts{
-		KcpClient:                       m.KcpClient,
+	machineImages, err := getMachineImages(ctx, m, s.instance)
+	if err != nil {
+		m.log.Error(err, "Failed to get machine images from CloudProfile")
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonGardenerError,
+			metav1.ConditionFalse,
+			fmt.Sprintf("Failed to get machine images from CloudProfile: %v", err),
+		)
+		return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+	}
+
+	bindingName, err := resolveBindingName(ctx, m, s)
+	if err != nil {
+		m.log.Error(err, "Failed to resolve the binding of the Gardener project")
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonGardenerError,
+			metav1.ConditionFalse,
+			fmt.Sprintf("Failed to resolve the binding of the Gardener project: %v", err),
+		)
+		return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+	}
+
+	createOpts, err := gardener_shoot.NewCreateOpts(s.instance, gardener_shoot.OptsInputs{
 		ConverterConfig:                 m.ConverterConfig,
 		AuditLogData:                    auditLogConfig,
-		MaintenanceTimeWindow:           getMaintenanceTimeWindow(s, m),
+		KcpClient:                       m.KcpClient,
+		MachineImages:                   machineImages,
 		ApiServerAclEnabled:             m.ApiServerAclEnabled,
 		NetworkRestrictionGlobalEnabled: m.NetworkRestrictionGlobalEnabled,
+		BindingName:                     bindingName,
 	})
+	if err != nil {
+		m.log.Error(err, "Failed to get Maintenance Window data for region")
+	}
+
+	shoot, err := convertCreate(ctx, &s.instance, createOpts)
 	if err != nil {
 		m.log.Error(err, "Failed to convert Runtime instance to shoot object")
 		m.Metrics.IncRuntimeFSMStopCounter()
 		return updateStateFailedWithErrorAndStop(
-			&s.instance,
+			m, s,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonConversionError,
 			fmt.Sprintf("Runtime conversion error %v", err))
 	}
 
+	if nextState, res, err := validateWithCloudProfile(ctx, m, s, shoot, nil); nextState != nil {
+		return nextState, res, err
+	}
+
 	err = m.GardenClient.Create(ctx, &shoot)
 	if err != nil {
 		m.log.Error(err, "Failed to create new gardener Shoot")
@@ -157,6 +191,9 @@ func sFnCreateShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 		return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
 	}
 
+	setShootCreationTime(&s.instance, shoot)
+	setMachineImageStatus(&s.instance, shoot)
+
 	m.log.V(log_level.DEBUG).Info(
 		"Gardener shoot for runtime initialised successfully",
 		"name", shoot.Name,
@@ -4,15 +4,21 @@ import (
 	"context"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	fsm_testing "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/testing"
 	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
 	auditlogmocks "github.com/kyma-project/infrastructure-manager/pkg/auditlog/mocks"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
 	. "github.com/onsi/ginkgo/v2" //nolint:revive
 	. "github.com/onsi/gomega"    //nolint:revive
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -76,6 +82,128 @@ var _ = Describe("KIM sFnCreateShoot", func() {
 			// then
 			Expect(stateFn.name()).To(ContainSubstring("sFnUpdateStatus"))
 		})
+
+		It("Should fail the Runtime when the machine type is not offered by the CloudProfile", func() {
+			runtime := *inputRuntime.DeepCopy()
+
+			scheme, schemeErr := newCreateTestScheme()
+			Expect(schemeErr).To(BeNil(), "Failed to create test scheme")
+
+			cloudProfile := &gardener.CloudProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "gcp"},
+				Spec: gardener.CloudProfileSpec{
+					MachineTypes: []gardener.MachineType{{Name: "n2-standard-4"}},
+					Regions:      []gardener.Region{{Name: "region", Zones: []gardener.AvailabilityZone{{Name: "europe-west1-d"}}}},
+				},
+			}
+
+			var fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(cloudProfile).
+				Build()
+
+			mockProvider := &auditlogmocks.DataProvider{}
+			mockProvider.On("ReserveAuditLog", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockProvider.On("GetSharedAuditLogData", mock.Anything, mock.Anything, mock.Anything).Return(auditlog.AuditLogData{
+				TenantID:   "test-tenant",
+				ServiceURL: "http://test-service",
+				SecretName: "test-secret",
+			}, nil)
+
+			testFsm := must(newFakeFSM, withMockedMetrics())
+			testFsm.GardenClient = fakeClient
+			testFsm.KcpClient = fakeClient
+			testFsm.AuditLogDataProvider = mockProvider
+			testFsm.CloudProfileValidationEnabled = true
+			testFsm.CloudProfileValidator = cloudprofile.NewValidator(fakeClient)
+			testFsm.ConverterConfig.Provider.Worker = config.WorkerConfig{
+				DefaultMaxEvictRetries:     "2",
+				DefaultMachineDrainTimeout: "15m",
+			}
+
+			systemState := &systemState{
+				instance: runtime,
+			}
+
+			// when
+			stateFn, _, _ := sFnCreateShoot(ctx, testFsm, systemState)
+
+			// then
+			Expect(stateFn.name()).To(ContainSubstring("sFnUpdateStatus"))
+			Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateFailed)))
+
+			condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeProvisioned))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonMachineTypeUnavailable)))
+
+			var shoots gardener.ShootList
+			Expect(fakeClient.List(ctx, &shoots)).To(Succeed())
+			Expect(shoots.Items).To(BeEmpty())
+		})
+
+		It("Should create the shoot with the machine image version resolved from the CloudProfile", func() {
+			runtime := *inputRuntime.DeepCopy()
+			runtime.Spec.Shoot.Provider.Workers[0].Machine.Image = nil
+
+			scheme, schemeErr := newCreateTestScheme()
+			Expect(schemeErr).To(BeNil(), "Failed to create test scheme")
+
+			cloudProfile := &gardener.CloudProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "gcp"},
+				Spec: gardener.CloudProfileSpec{
+					MachineImages: []gardener.MachineImage{{
+						Name: "gardenlinux",
+						Versions: []gardener.MachineImageVersion{
+							{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0", Classification: ptr.To(gardener.ClassificationSupported)}},
+							{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.2.0", Classification: ptr.To(gardener.ClassificationSupported)}},
+							{ExpirableVersion: gardener.ExpirableVersion{Version: "1877.0.0", Classification: ptr.To(gardener.ClassificationPreview)}},
+						},
+					}},
+				},
+			}
+
+			var fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(cloudProfile).
+				Build()
+
+			mockProvider := &auditlogmocks.DataProvider{}
+			mockProvider.On("GetSharedAuditLogData", mock.Anything, mock.Anything, mock.Anything).Return(auditlog.AuditLogData{
+				TenantID:   "test-tenant",
+				ServiceURL: "http://test-service",
+				SecretName: "test-secret",
+			}, nil)
+
+			testFsm := must(newFakeFSM, withMockedMetrics())
+			testFsm.GardenClient = fakeClient
+			testFsm.KcpClient = fakeClient
+			testFsm.CloudProfileReader = fakeClient
+			testFsm.AuditLogDataProvider = mockProvider
+			testFsm.ConverterConfig.MachineImage = config.MachineImageConfig{
+				DefaultName:     "gardenlinux",
+				VersionStrategy: config.MachineImageVersionLatestSupported,
+			}
+			testFsm.ConverterConfig.Provider.Worker = config.WorkerConfig{
+				DefaultMaxEvictRetries:     "2",
+				DefaultMachineDrainTimeout: "15m",
+			}
+
+			systemState := &systemState{
+				instance: runtime,
+			}
+
+			// when
+			stateFn, _, _ := sFnCreateShoot(ctx, testFsm, systemState)
+
+			// then
+			Expect(stateFn.name()).To(ContainSubstring("sFnUpdateStatus"))
+			Expect(systemState.instance.Status.MachineImage).To(Equal(&imv1.RuntimeMachineImage{Name: "gardenlinux", Version: "1592.2.0"}))
+			Expect(systemState.instance.Status.ShootCreationTime).NotTo(BeNil())
+
+			var shoot gardener.Shoot
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: runtime.Spec.Shoot.Name, Namespace: "garden-"}, &shoot)).To(Succeed())
+			Expect(*shoot.Spec.Provider.Workers[0].Machine.Image.Version).To(Equal("1592.2.0"))
+		})
 	})
 })
 
@@ -27,7 +27,7 @@ func sFnDeleteKubeconfig(ctx context.Context, m *fsm, s *systemState) (stateFn,
 			m.Metrics.IncRuntimeFSMStopCounter()
 
 			return updateStateFailedWithErrorAndStop(
-				&s.instance,
+				m, s,
 				imv1.RuntimeStateTerminating,
 				imv1.ConditionReasonKubernetesAPIErr,
 				"Failed to get GardenerCluster CR")
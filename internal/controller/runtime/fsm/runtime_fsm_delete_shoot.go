@@ -2,6 +2,7 @@ package fsm
 
 import (
 	"context"
+	"time"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
@@ -30,7 +31,19 @@ func sFnDeleteShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 	if !s.shoot.GetDeletionTimestamp().IsZero() {
 		m.log.V(log_level.DEBUG).Info("Waiting for shoot to be deleted", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
 		LogLastErrors(s, m)
-		return updateStatusAndRequeueAfter(m.RequeueDurationShootDelete)
+
+		// Gardener retries the deletion, the errors are reported so that the user can remove what blocks it
+		if shootDeletionFailed(s.shoot) {
+			classification := classifyLastErrors(m, s.shoot.Status.LastErrors)
+			s.instance.UpdateStateDeletion(
+				imv1.ConditionTypeRuntimeDeprovisioned,
+				classification.Reason,
+				metav1.ConditionFalse,
+				classification.Message,
+			)
+			return updateStatusAndRequeueAfter(classification.RequeueAfter(time.Now(), shootRequeueDuration(m, s, m.RequeueDurationShootDelete)))
+		}
+		return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootDelete))
 	}
 
 	// action section
@@ -64,7 +77,7 @@ func sFnDeleteShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 			"Gardener API structured authentication configmap delete error",
 		)
 
-		return updateStatusAndRequeueAfter(m.RequeueDurationShootDelete)
+		return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootDelete))
 	}
 
 	m.log.Info("deleting shoot", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
@@ -88,7 +101,15 @@ func sFnDeleteShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl
 	}
 
 	// out section
-	return updateStatusAndRequeueAfter(m.RequeueDurationShootDelete)
+	return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootDelete))
+}
+
+func shootDeletionFailed(shoot *gardener.Shoot) bool {
+	lastOperation := shoot.Status.LastOperation
+	return lastOperation != nil &&
+		lastOperation.Type == gardener.LastOperationTypeDelete &&
+		(lastOperation.State == gardener.LastOperationStateFailed || lastOperation.State == gardener.LastOperationStateError) &&
+		len(shoot.Status.LastErrors) > 0
 }
 
 // workaround
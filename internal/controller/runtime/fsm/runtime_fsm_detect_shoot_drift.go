@@ -0,0 +1,91 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/diff"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const maxDriftedPathsInMessage = 10
+
+// sFnDetectShootDrift compares the Shoot generated for a Ready Runtime with the Shoot in the Gardener cluster.
+// Changes made by other field managers, for example manual edits in the Garden cluster, are reported with the ShootDrifted condition.
+// When the auto-correct policy is set on the Runtime CR, the Shoot is patched to revert the changes.
+func sFnDetectShootDrift(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("Checking Shoot for drift", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+
+	s.instance.Status.ShootDriftCheckTime = ptr.To(metav1.Now())
+
+	auditLogConfig, err := resolveAuditLogDataForDryRun(ctx, m, s)
+	if err != nil {
+		return updateDriftDetectionFailed(m, s, fmt.Sprintf("Failed to get audit log configuration: %v", err))
+	}
+
+	patchOptions, err := getPatchOptions(ctx, m, s, auditLogConfig)
+	if err != nil {
+		return updateDriftDetectionFailed(m, s, fmt.Sprintf("Failed to get patch options: %v", err))
+	}
+
+	desiredShoot, err := convertPatch(ctx, &s.instance, patchOptions)
+	if err != nil {
+		return updateDriftDetectionFailed(m, s, fmt.Sprintf("Runtime conversion error %v", err))
+	}
+
+	driftedPaths, err := diff.ComputeDrift(*s.shoot, desiredShoot)
+	if err != nil {
+		return updateDriftDetectionFailed(m, s, fmt.Sprintf("Failed to compute Shoot diff: %v", err))
+	}
+
+	m.Metrics.SetShootDrift(s.instance, driftedPaths)
+
+	if len(driftedPaths) == 0 {
+		s.instance.SetCondition(imv1.ConditionTypeRuntimeShootDrifted, imv1.ConditionReasonShootInSync, metav1.ConditionFalse, "Shoot matches the Runtime")
+		return updateStatusAndRequeueAfter(m.ShootDriftDetectionInterval)
+	}
+
+	m.log.Info("Shoot drift detected", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "driftedPaths", driftedPaths)
+
+	if reconciler.ShouldAutoCorrectShootDrift(s.instance.Annotations) {
+		s.instance.SetCondition(imv1.ConditionTypeRuntimeShootDrifted, imv1.ConditionReasonShootDriftCorrected, metav1.ConditionTrue,
+			fmt.Sprintf("Shoot patched to revert drifted fields: %s", formatDriftedPaths(driftedPaths)))
+		return switchState(sFnSyncRegistryCacheGardenSecrets)
+	}
+
+	s.instance.SetCondition(imv1.ConditionTypeRuntimeShootDrifted, imv1.ConditionReasonShootDriftDetected, metav1.ConditionTrue,
+		fmt.Sprintf("Shoot fields differ from the Runtime: %s", formatDriftedPaths(driftedPaths)))
+	return updateStatusAndRequeueAfter(m.ShootDriftDetectionInterval)
+}
+
+func updateDriftDetectionFailed(m *fsm, s *systemState, msg string) (stateFn, *ctrl.Result, error) {
+	m.log.Info("Shoot drift detection failed", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "reason", msg)
+	s.instance.SetCondition(imv1.ConditionTypeRuntimeShootDrifted, imv1.ConditionReasonShootDriftDetectionFailed, metav1.ConditionUnknown, msg)
+	return updateStatusAndRequeueAfter(m.ShootDriftDetectionInterval)
+}
+
+// nextShootDriftCheck returns the time left until the next drift detection is due.
+// Runtimes which were never checked get a random delay, so that the checks are spread over the interval after a restart.
+func nextShootDriftCheck(m *fsm, s *systemState) time.Duration {
+	lastCheck := s.instance.Status.ShootDriftCheckTime
+	if lastCheck == nil {
+		return rand.N(m.ShootDriftDetectionInterval)
+	}
+
+	return time.Until(lastCheck.Add(m.ShootDriftDetectionInterval))
+}
+
+func formatDriftedPaths(paths []string) string {
+	if len(paths) <= maxDriftedPathsInMessage {
+		return strings.Join(paths, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:maxDriftedPathsInMessage], ", "), len(paths)-maxDriftedPathsInMessage)
+}
@@ -0,0 +1,131 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	fsm_testing "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/testing"
+	. "github.com/onsi/gomega" //nolint:revive
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFSMDetectShootDrift(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	testScheme := api.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	util.Must(gardener.AddToScheme(testScheme))
+	util.Must(core_v1.AddToScheme(testScheme))
+
+	RegisterTestingT(t)
+
+	t.Run("should report drift without patching the shoot", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		inputRuntime.Status.State = imv1.RuntimeStateReady
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.ShootDriftDetectionInterval = time.Hour
+
+		shoot := fixShootGeneratedForRuntime(testCtx, testFsm, inputRuntime)
+		shoot.Spec.Provider.Workers[0].Machine.Type = "manually-changed"
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+		shootBefore := &gardener.Shoot{}
+		Expect(testFsm.GardenClient.Get(testCtx, client.ObjectKeyFromObject(shoot), shootBefore)).To(Succeed())
+
+		systemState := &systemState{instance: *inputRuntime, shoot: shootBefore.DeepCopy()}
+
+		// when
+		sFn, res, err := sFnDetectShootDrift(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(res).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateReady)))
+		Expect(systemState.instance.Status.ShootDriftCheckTime).ToNot(BeNil())
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeShootDrifted))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonShootDriftDetected)))
+		Expect(condition.Message).To(Equal("Shoot fields differ from the Runtime: spec.provider.workers[name=test-worker].machine.type"))
+
+		shootAfter := &gardener.Shoot{}
+		Expect(testFsm.GardenClient.Get(testCtx, client.ObjectKeyFromObject(shoot), shootAfter)).To(Succeed())
+		Expect(shootAfter.ResourceVersion).To(Equal(shootBefore.ResourceVersion))
+	})
+
+	t.Run("should switch to patch when drift auto-correction is enabled", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/shoot-drift-policy": "auto-correct"})
+		inputRuntime.Status.State = imv1.RuntimeStateReady
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.ShootDriftDetectionInterval = time.Hour
+
+		shoot := fsm_testing.TestShootForPatch()
+		shoot.Spec.Provider.Workers[0].Machine.Type = "manually-changed"
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+
+		systemState := &systemState{instance: *inputRuntime, shoot: shoot}
+
+		// when
+		sFn, _, err := sFnDetectShootDrift(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnSyncRegistryCacheGardenSecrets"))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeShootDrifted))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonShootDriftCorrected)))
+	})
+
+	t.Run("should set unknown drift condition when audit log configuration is missing", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		inputRuntime.Status.State = imv1.RuntimeStateReady
+		testFsm := setupFakeFSMForTestWithAuditLogMandatory(testScheme, inputRuntime)
+		testFsm.ShootDriftDetectionInterval = time.Hour
+
+		shoot := fsm_testing.TestShootForPatch()
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+
+		systemState := &systemState{instance: *inputRuntime, shoot: shoot}
+
+		// when
+		sFn, _, err := sFnDetectShootDrift(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeShootDrifted))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonShootDriftDetectionFailed)))
+	})
+}
+
+func fixShootGeneratedForRuntime(ctx context.Context, m *fsm, runtime *imv1.Runtime) *gardener.Shoot {
+	s := &systemState{instance: *runtime, shoot: fsm_testing.TestShootForPatch()}
+
+	auditLogConfig, err := resolveAuditLogDataForDryRun(ctx, m, s)
+	Expect(err).To(BeNil())
+
+	patchOptions, err := getPatchOptions(ctx, m, s, auditLogConfig)
+	Expect(err).To(BeNil())
+
+	shoot, err := convertPatch(ctx, runtime, patchOptions)
+	Expect(err).To(BeNil())
+
+	return &shoot
+}
@@ -0,0 +1,133 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/diff"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	dryRunConfigMapFmt = "%s-patch-dry-run"
+
+	dryRunKeyChangedPaths           = "changedPaths"
+	dryRunKeyRollingWorkerPools     = "rollingWorkerPools"
+	dryRunKeyShootGenerationChanged = "shootGenerationChanged"
+	dryRunKeyServerDryRun           = "serverDryRun"
+
+	dryRunServerSucceeded = "Succeeded"
+)
+
+// sFnDryRunPatchShoot computes the changes which sFnPatchExistingShoot would apply to the Shoot, without applying them.
+// The result is stored in a ConfigMap next to the Runtime CR, so that operators can review it before removing the annotation.
+// Nothing is written to the Gardener cluster, therefore the dedicated audit log is not claimed and the structured
+// authentication config is not updated.
+func sFnDryRunPatchShoot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	m.log.Info("Dry-run of Shoot patch requested", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+
+	auditLogConfig, err := resolveAuditLogDataForDryRun(ctx, m, s)
+	if err != nil {
+		return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunFailed, metav1.ConditionFalse, fmt.Sprintf("Failed to get audit log configuration: %v", err))
+	}
+
+	patchOptions, err := getPatchOptions(ctx, m, s, auditLogConfig)
+	if err != nil {
+		return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunFailed, metav1.ConditionFalse, fmt.Sprintf("Failed to get patch options: %v", err))
+	}
+
+	updatedShoot, err := convertPatch(ctx, &s.instance, patchOptions)
+	if err != nil {
+		return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunFailed, metav1.ConditionFalse, fmt.Sprintf("Runtime conversion error %v", err))
+	}
+
+	result, err := diff.Compute(*s.shoot, updatedShoot)
+	if err != nil {
+		return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunFailed, metav1.ConditionFalse, fmt.Sprintf("Failed to compute Shoot diff: %v", err))
+	}
+
+	serverDryRunResult := dryRunServerSucceeded
+	dryRunShoot := updatedShoot.DeepCopy()
+	//nolint:staticcheck // SA1019: client.Apply is used with Patch, which is the correct API for this version
+	err = m.GardenClient.Patch(ctx, dryRunShoot, client.Apply, &client.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: fieldManagerName,
+		Force:        ptr.To(true),
+	})
+	if err != nil {
+		m.log.Info("Server-side dry-run of Shoot patch failed", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "error", err.Error())
+		serverDryRunResult = err.Error()
+	}
+
+	cmName := fmt.Sprintf(dryRunConfigMapFmt, s.instance.Name)
+	if err := storeDryRunResult(ctx, m, s, cmName, map[string]string{
+		dryRunKeyChangedPaths:           strings.Join(result.ChangedPaths, "\n"),
+		dryRunKeyRollingWorkerPools:     strings.Join(result.RollingWorkerPools, "\n"),
+		dryRunKeyShootGenerationChanged: strconv.FormatBool(serverDryRunResult == dryRunServerSucceeded && dryRunShoot.Generation != s.shoot.Generation),
+		dryRunKeyServerDryRun:           serverDryRunResult,
+	}); err != nil {
+		m.log.Error(err, "Failed to store dry-run result", "RuntimeCR", s.instance.Name, "ConfigMap", cmName)
+		return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunFailed, metav1.ConditionFalse, fmt.Sprintf("Failed to store dry-run result: %v", err))
+	}
+
+	if serverDryRunResult != dryRunServerSucceeded {
+		return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunFailed, metav1.ConditionFalse,
+			fmt.Sprintf("Gardener rejected the Shoot patch, see ConfigMap %s", cmName))
+	}
+
+	return updateDryRunConditionAndStop(s, imv1.ConditionReasonDryRunCompleted, metav1.ConditionTrue,
+		fmt.Sprintf("%d Shoot fields would change and %d worker pools would roll, see ConfigMap %s. Remove the %s annotation to apply the changes",
+			len(result.ChangedPaths), len(result.RollingWorkerPools), cmName, reconciler.DryRunPatchAnnotation))
+}
+
+func updateDryRunConditionAndStop(s *systemState, reason imv1.RuntimeConditionReason, status metav1.ConditionStatus, msg string) (stateFn, *ctrl.Result, error) {
+	s.instance.SetCondition(imv1.ConditionTypeRuntimePatchDryRun, reason, status, msg)
+	return updateStatusAndStop()
+}
+
+// resolveAuditLogDataForDryRun returns the audit log configuration used by the patch, without claiming a dedicated audit log.
+func resolveAuditLogDataForDryRun(ctx context.Context, m *fsm, s *systemState) (auditlogs.AuditLogData, error) {
+	if m.DedicatedAuditLoggingEnabled {
+		data, err := m.AuditLogDataProvider.GetDedicatedAuditLogData(ctx, s.instance.Labels[imv1.LabelKymaRuntimeID], false)
+		if err == nil {
+			return toExtenderAuditLogData(data), nil
+		}
+	}
+
+	data, err := m.AuditLogDataProvider.GetSharedAuditLogData(ctx, s.instance.Spec.Shoot.Provider.Type, s.instance.Spec.Shoot.Region)
+	if err != nil && m.AuditLogMandatory {
+		return auditlogs.AuditLogData{}, err
+	}
+
+	return toExtenderAuditLogData(data), nil
+}
+
+func storeDryRunResult(ctx context.Context, m *fsm, s *systemState, name string, data map[string]string) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.instance.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, m.KcpClient, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[imv1.LabelKymaRuntimeID] = s.instance.Labels[imv1.LabelKymaRuntimeID]
+		cm.Data = data
+		return controllerutil.SetControllerReference(&s.instance, cm, m.KcpClient.Scheme())
+	})
+
+	return err
+}
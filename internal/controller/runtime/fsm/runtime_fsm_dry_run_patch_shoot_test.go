@@ -0,0 +1,99 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	fsm_testing "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/testing"
+	. "github.com/onsi/gomega" //nolint:revive
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFSMDryRunPatchShoot(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	testScheme := api.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	util.Must(gardener.AddToScheme(testScheme))
+	util.Must(core_v1.AddToScheme(testScheme))
+
+	RegisterTestingT(t)
+
+	t.Run("should store dry-run result without patching the shoot", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/dry-run-patch": "true"})
+		inputRuntime.Status.State = imv1.RuntimeStateReady
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+
+		shoot := fsm_testing.TestShootForPatch()
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+		shootBefore := &gardener.Shoot{}
+		Expect(testFsm.GardenClient.Get(testCtx, client.ObjectKeyFromObject(shoot), shootBefore)).To(Succeed())
+
+		systemState := &systemState{instance: *inputRuntime, shoot: shootBefore.DeepCopy()}
+
+		// when
+		sFn, res, err := sFnDryRunPatchShoot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(res).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateReady)))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimePatchDryRun))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonDryRunCompleted)))
+
+		var cm core_v1.ConfigMap
+		Expect(testFsm.KcpClient.Get(testCtx, client.ObjectKey{Name: "test-shoot-patch-dry-run", Namespace: inputRuntime.Namespace}, &cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue("serverDryRun", "Succeeded"))
+		Expect(cm.Data).To(HaveKey("changedPaths"))
+		Expect(cm.Data).To(HaveKey("rollingWorkerPools"))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+
+		shootAfter := &gardener.Shoot{}
+		Expect(testFsm.GardenClient.Get(testCtx, client.ObjectKeyFromObject(shoot), shootAfter)).To(Succeed())
+		Expect(shootAfter.Spec).To(Equal(shootBefore.Spec))
+		Expect(shootAfter.ResourceVersion).To(Equal(shootBefore.ResourceVersion))
+	})
+
+	t.Run("should set failed dry-run condition when audit log configuration is missing", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/dry-run-patch": "true"})
+		inputRuntime.Status.State = imv1.RuntimeStateReady
+		testFsm := setupFakeFSMForTestWithAuditLogMandatory(testScheme, inputRuntime)
+
+		shoot := fsm_testing.TestShootForPatch()
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+
+		systemState := &systemState{instance: *inputRuntime, shoot: shoot}
+
+		// when
+		sFn, _, err := sFnDryRunPatchShoot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateReady)))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimePatchDryRun))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonDryRunFailed)))
+
+		var cm core_v1.ConfigMap
+		err = testFsm.KcpClient.Get(testCtx, client.ObjectKey{Name: "test-shoot-patch-dry-run", Namespace: inputRuntime.Namespace}, &cm)
+		Expect(err).To(HaveOccurred())
+	})
+}
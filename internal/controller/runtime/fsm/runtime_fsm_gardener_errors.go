@@ -0,0 +1,15 @@
+package fsm
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/errorcatalog"
+)
+
+// classifyLastErrors classifies the errors of a failed Shoot operation with the Gardener error catalog
+func classifyLastErrors(m *fsm, lastErrors []gardener.LastError) errorcatalog.Classification {
+	catalog := m.ErrorCatalog
+	if catalog == nil {
+		catalog = errorcatalog.Default()
+	}
+	return catalog.Classify(lastErrors)
+}
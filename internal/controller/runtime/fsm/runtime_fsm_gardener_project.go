@@ -0,0 +1,44 @@
+package fsm
+
+import (
+	"context"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
+)
+
+// gardenerNamespace returns the namespace of the Gardener project the runtime is placed in
+func gardenerNamespace(m *fsm, s *systemState) string {
+	return project.NamespaceForRuntime(s.instance, m.ConverterConfig.Gardener.ProjectName)
+}
+
+// resolveBindingName returns the binding of the hyperscaler account in the Gardener project the runtime is placed in
+func resolveBindingName(ctx context.Context, m *fsm, s *systemState) (string, error) {
+	resolver := project.BindingResolver{Config: m.ConverterConfig.Gardener, GardenClient: m.GardenClient}
+	return resolver.Resolve(ctx, s.instance)
+}
+
+// setGardenerProject persists the Gardener project in the annotation of a new runtime. The project is chosen with the placement policy,
+// unless the Shoot already exists in the default project. The webhook rejects changes of the annotation once it is set.
+func setGardenerProject(ctx context.Context, m *fsm, s *systemState) error {
+	if s.instance.Annotations[imv1.AnnotationGardenerProject] != "" {
+		return nil
+	}
+
+	gardenerProject := m.ConverterConfig.Gardener.ProjectName
+	if s.shoot == nil {
+		placer := project.Placer{Config: m.ConverterConfig.Gardener, KcpClient: m.KcpClient}
+
+		var err error
+		gardenerProject, err = placer.Place(ctx, s.instance)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.instance.Annotations == nil {
+		s.instance.Annotations = map[string]string{}
+	}
+	s.instance.Annotations[imv1.AnnotationGardenerProject] = gardenerProject
+	return nil
+}
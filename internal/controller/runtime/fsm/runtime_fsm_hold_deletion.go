@@ -0,0 +1,52 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/log_level"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sFnHoldDeletion prevents the Shoot from being deleted while the Runtime is protected from deletion
+// or the deletion grace period has not expired yet. Once the deletion has started, it cannot be held anymore.
+func sFnHoldDeletion(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	if s.instance.Status.State == imv1.RuntimeStateTerminating {
+		return switchState(sFnDeleteKubeconfig)
+	}
+
+	if reconciler.IsDeletionProtected(s.instance.Annotations) {
+		if s.instance.IsStateWithConditionSet(imv1.RuntimeStateDeletionScheduled, imv1.ConditionTypeRuntimeDeprovisioned, imv1.ConditionReasonDeletionProtected) {
+			m.log.V(log_level.DEBUG).Info("Runtime is protected from deletion, stopping processing", "RuntimeCR", s.instance.Name)
+			return stop()
+		}
+
+		m.log.Info("Runtime is protected from deletion, Shoot will not be deleted", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+		s.instance.UpdateStateDeletionScheduled(
+			imv1.ConditionTypeRuntimeDeprovisioned,
+			imv1.ConditionReasonDeletionProtected,
+			metav1.ConditionFalse,
+			fmt.Sprintf("Runtime deletion blocked, remove the %s annotation to continue", reconciler.DeletionProtectionAnnotation),
+		)
+		return updateStatusAndStop()
+	}
+
+	deletionTime := s.instance.GetDeletionTimestamp().Add(m.DeletionGracePeriod)
+	remaining := time.Until(deletionTime)
+	if remaining <= 0 {
+		return switchState(sFnDeleteKubeconfig)
+	}
+
+	m.log.V(log_level.DEBUG).Info("Runtime deletion scheduled", "RuntimeCR", s.instance.Name, "deletionTime", deletionTime)
+	s.instance.UpdateStateDeletionScheduled(
+		imv1.ConditionTypeRuntimeDeprovisioned,
+		imv1.ConditionReasonDeletionScheduled,
+		metav1.ConditionUnknown,
+		fmt.Sprintf("Runtime deletion scheduled at %s", deletionTime.UTC().Format(time.RFC3339)),
+	)
+	return updateStatusAndRequeueAfter(remaining)
+}
@@ -0,0 +1,132 @@
+package fsm
+
+import (
+	"context"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("KIM sFnHoldDeletion", func() {
+	now := metav1.NewTime(time.Now())
+
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// GIVEN
+	testScheme := runtime.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	withTestSchemeAndObjects := func(objs ...client.Object) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			return withFakedK8sClient(testScheme, objs...)(fsm)
+		}
+	}
+
+	testShoot := gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-shoot",
+			Namespace: "garden-",
+		},
+	}
+
+	inputRtDeleted := makeInputRuntimeWithAnnotation(nil)
+	inputRtDeleted.DeletionTimestamp = &now
+	inputRtDeleted.Status.State = imv1.RuntimeStateReady
+
+	inputRtProtected := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/deletion-protection": "true"})
+	inputRtProtected.DeletionTimestamp = &now
+	inputRtProtected.Status.State = imv1.RuntimeStateReady
+
+	inputRtProtectedAndReported := inputRtProtected.DeepCopy()
+	inputRtProtectedAndReported.UpdateStateDeletionScheduled(
+		imv1.ConditionTypeRuntimeDeprovisioned,
+		imv1.ConditionReasonDeletionProtected,
+		metav1.ConditionFalse,
+		"Runtime deletion blocked")
+
+	inputRtProtectedAndTerminating := inputRtProtected.DeepCopy()
+	inputRtProtectedAndTerminating.Status.State = imv1.RuntimeStateTerminating
+
+	testFunction := buildTestFunction(sFnHoldDeletion)
+
+	DescribeTable(
+		"transition graph validation for sFnHoldDeletion",
+		testFunction,
+		Entry(
+			"should switch to sFnDeleteKubeconfig when grace period is not configured",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtDeleted, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnDeleteKubeconfig"),
+			},
+		),
+		Entry(
+			"should set DeletionScheduled state while grace period has not expired",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(), withDeletionGracePeriod(time.Hour)),
+			&systemState{instance: *inputRtDeleted, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStateDeletionScheduled),
+					haveCondition(imv1.ConditionTypeRuntimeDeprovisioned, imv1.ConditionReasonDeletionScheduled, metav1.ConditionUnknown),
+				},
+			},
+		),
+		Entry(
+			"should switch to sFnDeleteKubeconfig when grace period expired",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(), withDeletionGracePeriod(time.Nanosecond)),
+			&systemState{instance: *inputRtDeleted, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnDeleteKubeconfig"),
+			},
+		),
+		Entry(
+			"should block deletion of protected Runtime",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtProtected, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStateDeletionScheduled),
+					haveCondition(imv1.ConditionTypeRuntimeDeprovisioned, imv1.ConditionReasonDeletionProtected, metav1.ConditionFalse),
+				},
+			},
+		),
+		Entry(
+			"should stop when protected Runtime already reports blocked deletion",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtProtectedAndReported, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+			},
+		),
+		Entry(
+			"should continue deletion which has already started even if Runtime is protected",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtProtectedAndTerminating, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnDeleteKubeconfig"),
+			},
+		),
+	)
+})
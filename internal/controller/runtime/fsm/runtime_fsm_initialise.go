@@ -3,6 +3,7 @@ package fsm
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	"github.com/kyma-project/infrastructure-manager/internal/registrycache"
@@ -37,13 +38,13 @@ func sFnInitialize(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.
 	// instance is being deleted
 	if instanceIsBeingDeleted {
 		if s.shoot != nil {
-			return switchState(sFnDeleteKubeconfig)
+			return switchState(sFnHoldDeletion)
 		}
 
 		m.log.V(log_level.DEBUG).Info("Deleting registry cache secrets for a runtime", "instance", s.instance.Name)
 		secretManager := registrycache.NewGardenSecretManager(
 			m.GardenClient,
-			fmt.Sprintf("garden-%s", m.ConverterConfig.Gardener.ProjectName),
+			gardenerNamespace(m, s),
 			s.instance.Name)
 		err := secretManager.DeleteAll(ctx)
 		if err != nil {
@@ -68,6 +69,17 @@ func sFnInitialize(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.
 		return updateStatusAndRequeueAfter(m.StatusRequeueDelay)
 	}
 
+	if s.shoot == nil && shootCreatedBefore(&s.instance) {
+		msg := fmt.Sprintf("Gardener shoot %s doesn't exist in %s, it isn't created again because the runtime was already provisioned", s.instance.Spec.Shoot.Name, gardenerNamespace(m, s))
+		m.log.Error(nil, msg)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStateFailedWithErrorAndStop(
+			m, s,
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonShootNotFound,
+			msg)
+	}
+
 	if s.shoot == nil {
 		m.log.Info("Gardener shoot does not exist, creating new one")
 		return switchState(sFnCreateShoot)
@@ -76,7 +88,25 @@ func sFnInitialize(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.
 	return switchState(sFnSelectShootProcessing)
 }
 
+// shootCreatedBefore reports whether the Shoot of the runtime was created already. A missing Shoot of such a runtime
+// is not created again, for example in another Gardener project when the project of the runtime was lost.
+// The runtimes provisioned before the creation time was recorded are recognized by the completed provisioning.
+func shootCreatedBefore(runtime *imv1.Runtime) bool {
+	return runtime.Status.ShootCreationTime != nil || runtime.IsProvisioningCompletedStatusSet()
+}
+
 func addFinalizerAndRequeue(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	if err := setGardenerProject(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to place runtime in a Gardener project")
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeProvisioned,
+			imv1.ConditionReasonProjectPlacementError,
+			metav1.ConditionFalse,
+			fmt.Sprintf("Failed to place runtime in a Gardener project: %v", err),
+		)
+		return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+	}
+
 	controllerutil.AddFinalizer(&s.instance, m.Finalizer)
 
 	err := m.KcpClient.Update(ctx, &s.instance)
@@ -88,6 +118,11 @@ func addFinalizerAndRequeue(ctx context.Context, m *fsm, s *systemState) (stateF
 
 func removeFinalizerAndStop(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
 	runtimeID := s.instance.GetLabels()[metrics.RuntimeIDLabel]
+	if err := publishDeletedNotification(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to publish notification")
+		return updateStatusAndStopWithError(err)
+	}
+
 	controllerutil.RemoveFinalizer(&s.instance, m.Finalizer)
 	err := m.KcpClient.Update(ctx, &s.instance)
 	if err != nil {
@@ -96,7 +131,12 @@ func removeFinalizerAndStop(ctx context.Context, m *fsm, s *systemState) (stateF
 
 	m.log.Info("Shoot deleted")
 
+	if deletionTimestamp := s.instance.GetDeletionTimestamp(); deletionTimestamp != nil {
+		m.Metrics.ObserveRuntimeOperationDuration(s.instance, metrics.OperationDeprovisioning, time.Since(deletionTimestamp.Time))
+	}
+
 	// remove from metrics
 	m.Metrics.CleanUpRuntimeGauge(runtimeID, s.instance.Name)
+	m.Metrics.CleanUpShootDrift(runtimeID, s.instance.Name)
 	return stop()
 }
@@ -8,6 +8,7 @@ import (
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	. "github.com/onsi/ginkgo/v2" //nolint:revive
 	. "github.com/onsi/gomega"    //nolint:revive
 	"github.com/onsi/gomega/types"
@@ -47,6 +48,21 @@ var _ = Describe("KIM sFnInitialise", func() {
 		},
 	}
 
+	newTestRtInRegion := func() imv1.Runtime {
+		return imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance",
+				Namespace: "default",
+				Labels:    map[string]string{imv1.LabelKymaRuntimeID: "test-runtime-id"},
+			},
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{Region: "eu-west-1"},
+			},
+		}
+	}
+	testRtInRegion := newTestRtInRegion()
+	testRtInRegionWithShoot := newTestRtInRegion()
+
 	testRtWithFinalizerNoProvisioningCondition := imv1.Runtime{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "test-instance",
@@ -69,11 +85,33 @@ var _ = Describe("KIM sFnInitialise", func() {
 		Type:               string(imv1.ConditionTypeRuntimeProvisioned),
 		Status:             metav1.ConditionUnknown,
 		LastTransitionTime: now,
-		Reason:             "Test reason",
+		Reason:             string(imv1.ConditionReasonInitialized),
 		Message:            "Test message",
 	}
 	meta.SetStatusCondition(&testRtWithFinalizerAndProvisioningCondition.Status.Conditions, provisioningCondition)
 
+	testRtProvisioned := *testRtWithFinalizerAndProvisioningCondition.DeepCopy()
+	testRtProvisioned.Status.Conditions = nil
+	meta.SetStatusCondition(&testRtProvisioned.Status.Conditions, metav1.Condition{
+		Type:               string(imv1.ConditionTypeRuntimeProvisioned),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: now,
+		Reason:             string(imv1.ConditionReasonConfigurationCompleted),
+	})
+	testRtProvisioned.Status.ShootCreationTime = &now
+
+	testRtWithRetryableCreateFailure := *testRtWithFinalizerAndProvisioningCondition.DeepCopy()
+	testRtWithRetryableCreateFailure.Status.Conditions = nil
+	meta.SetStatusCondition(&testRtWithRetryableCreateFailure.Status.Conditions, metav1.Condition{
+		Type:               string(imv1.ConditionTypeRuntimeProvisioned),
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: now,
+		Reason:             "NewReasonOfFailureBeforeShootCreation",
+	})
+
+	testRtWithProvisioningCompleted := *testRtWithFinalizerAndProvisioningCondition.DeepCopy()
+	testRtWithProvisioningCompleted.Status.ProvisioningCompleted = true
+
 	testRtWithDeletionTimestamp := imv1.Runtime{
 		ObjectMeta: metav1.ObjectMeta{
 			DeletionTimestamp: &now,
@@ -124,13 +162,13 @@ var _ = Describe("KIM sFnInitialise", func() {
 			},
 		),
 		Entry(
-			"should return sFnDeleteShoot and no error when CR is being deleted with finalizer and shoot exists",
+			"should return sFnHoldDeletion and no error when CR is being deleted with finalizer and shoot exists",
 			testCtx,
 			must(newFakeFSM, withTestFinalizer, withMockedMetrics(), withDefaultReconcileDuration()),
 			&systemState{instance: testRtWithDeletionTimestampAndFinalizer, shoot: &testShoot},
 			testOpts{
 				MatchExpectedErr: BeNil(),
-				MatchNextFnState: haveName("sFnDeleteKubeconfig"),
+				MatchNextFnState: haveName("sFnHoldDeletion"),
 			},
 		),
 		Entry(
@@ -144,6 +182,43 @@ var _ = Describe("KIM sFnInitialise", func() {
 				StateMatch:       []types.GomegaMatcher{haveFinalizer("test-me-plz")},
 			},
 		),
+		Entry(
+			"should place the runtime in a Gardener project when CR has been created without finalizer",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(&testRtInRegion), withMockedMetrics(), withDefaultReconcileDuration(),
+				withGardenerConfig(config.GardenerConfig{
+					ProjectName: "kyma",
+					Projects:    []string{"kyma-2"},
+					Placement:   config.PlacementConfig{Policy: config.PlacementByRegion, Regions: map[string]string{"eu-west-1": "kyma-2"}},
+				})),
+			&systemState{instance: testRtInRegion},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+				StateMatch: []types.GomegaMatcher{
+					haveFinalizer("test-me-plz"),
+					HaveField("Annotations", HaveKeyWithValue(imv1.AnnotationGardenerProject, "kyma-2")),
+				},
+			},
+		),
+		Entry(
+			"should keep the default Gardener project when CR has been created without finalizer and shoot exists",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(&testRtInRegionWithShoot), withMockedMetrics(), withDefaultReconcileDuration(),
+				withGardenerConfig(config.GardenerConfig{
+					ProjectName: "kyma",
+					Projects:    []string{"kyma-2"},
+					Placement:   config.PlacementConfig{Policy: config.PlacementByRegion, Regions: map[string]string{"eu-west-1": "kyma-2"}},
+				})),
+			&systemState{instance: testRtInRegionWithShoot, shoot: &testShoot},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+				StateMatch: []types.GomegaMatcher{
+					HaveField("Annotations", HaveKeyWithValue(imv1.AnnotationGardenerProject, "kyma")),
+				},
+			},
+		),
 		Entry(
 			"should return sFnUpdateStatus and no error when there is no Provisioning Condition - Add condition",
 			testCtx,
@@ -164,6 +239,43 @@ var _ = Describe("KIM sFnInitialise", func() {
 				MatchNextFnState: haveName("sFnCreateShoot"),
 			},
 		),
+		Entry(
+			"should return sFnCreateShoot when the shoot creation failed with any reason and shoot is missing",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withMockedMetrics(), withDefaultReconcileDuration()),
+			&systemState{instance: testRtWithRetryableCreateFailure},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnCreateShoot"),
+			},
+		),
+		Entry(
+			"should stop with failure when the shoot was created and is missing",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withMockedMetrics(), withDefaultReconcileDuration()),
+			&systemState{instance: testRtProvisioned},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStateFailed),
+					haveCondition(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonShootNotFound, metav1.ConditionFalse),
+				},
+			},
+		),
+		Entry(
+			"should stop with failure when the provisioning was completed and shoot is missing",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withMockedMetrics(), withDefaultReconcileDuration()),
+			&systemState{instance: testRtWithProvisioningCompleted},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveCondition(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonShootNotFound, metav1.ConditionFalse),
+				},
+			},
+		),
 		Entry(
 			"should return sFnSelectShootProcessing and no error when exists Provisioning Condition and shoot exists",
 			testCtx,
@@ -172,6 +284,7 @@ var _ = Describe("KIM sFnInitialise", func() {
 			testOpts{
 				MatchExpectedErr: BeNil(),
 				MatchNextFnState: haveName("sFnSelectShootProcessing"),
+				StateMatch:       []types.GomegaMatcher{HaveField("Status.ShootCreationTime", Not(BeNil()))},
 			},
 		),
 	)
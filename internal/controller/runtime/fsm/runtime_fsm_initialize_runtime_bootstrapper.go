@@ -112,7 +112,7 @@ func sFnInitializeRuntimeBootstrapper(ctx context.Context, m *fsm, s *systemStat
 		{
 			m.log.Error(err, "Runtime bootstrapper installation failed")
 			return updateStateFailedWithErrorAndStop(
-				&s.instance,
+				m, s,
 				imv1.ConditionTypeRuntimeBootstrapperReady,
 				imv1.ConditionReasonRuntimeBootstrapperInstallationFailed,
 				msgInstallationFailed)
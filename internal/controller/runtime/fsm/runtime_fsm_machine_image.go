@@ -0,0 +1,51 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getMachineImages returns the machine images offered by the CloudProfile of the Runtime.
+// The CloudProfile is read only when the default machine image version is resolved from it; for the pinned strategy nil is returned.
+func getMachineImages(ctx context.Context, m *fsm, runtime imv1.Runtime) ([]gardener.MachineImage, error) {
+	if m.ConverterConfig.MachineImage.IsPinned() {
+		return nil, nil
+	}
+
+	cloudProfileName, err := extender.GetCloudProfileName(runtime, m.ConverterConfig.Provider.GDCH.CloudProfileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var cloudProfile gardener.CloudProfile
+	if err = m.CloudProfileReader.Get(ctx, client.ObjectKey{Name: cloudProfileName}, &cloudProfile); err != nil {
+		return nil, fmt.Errorf("failed to get CloudProfile %s: %w", cloudProfileName, err)
+	}
+
+	return cloudProfile.Spec.MachineImages, nil
+}
+
+// setMachineImageStatus records the machine image of the main worker pool which was sent to Gardener.
+func setMachineImageStatus(runtime *imv1.Runtime, shoot gardener.Shoot) {
+	if len(runtime.Spec.Shoot.Provider.Workers) == 0 {
+		return
+	}
+
+	mainWorkerName := runtime.Spec.Shoot.Provider.Workers[0].Name
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if worker.Name != mainWorkerName || worker.Machine.Image == nil || worker.Machine.Image.Version == nil {
+			continue
+		}
+
+		runtime.Status.MachineImage = &imv1.RuntimeMachineImage{
+			Name:    worker.Machine.Image.Name,
+			Version: *worker.Machine.Image.Version,
+		}
+		return
+	}
+}
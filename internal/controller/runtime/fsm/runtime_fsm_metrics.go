@@ -0,0 +1,57 @@
+package fsm
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const stoppedStateFnName = "stop"
+
+var closureSuffix = regexp.MustCompile(`\.func[0-9]*|\.glob|\.[0-9]+`)
+
+// stateFnShortName returns the name of the state function without the package path and closure suffixes,
+// e.g. sFnUpdateStatus instead of github.com/.../fsm.sFnUpdateStatus.func1, to keep the cardinality of the metric labels low
+func stateFnShortName(fn stateFn) string {
+	if fn == nil {
+		return stoppedStateFnName
+	}
+
+	name := closureSuffix.ReplaceAllString(fn.name(), "")
+	if i := strings.LastIndex(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// recordStatusMetrics records the end-to-end duration of the provisioning and update of the Runtime,
+// and the failure reason when the Runtime enters the Failed state. It must be called after the status was written.
+func recordStatusMetrics(m *fsm, s *systemState, now time.Time) {
+	previous := s.snapshot
+	current := s.instance.Status
+
+	if current.State == imv1.RuntimeStateFailed && previous.State != imv1.RuntimeStateFailed {
+		m.Metrics.IncRuntimeFailureCounter(s.instance, failureReason(&s.instance))
+	}
+
+	if current.ProvisioningCompleted && !previous.ProvisioningCompleted {
+		m.Metrics.ObserveRuntimeOperationDuration(s.instance, metrics.OperationProvisioning, now.Sub(s.instance.CreationTimestamp.Time))
+		return
+	}
+
+	if current.State != imv1.RuntimeStateReady || previous.State == imv1.RuntimeStateReady || !previous.ProvisioningCompleted {
+		return
+	}
+
+	// the update started when the Provisioned condition left the True status
+	provisioned := meta.FindStatusCondition(previous.Conditions, string(imv1.ConditionTypeRuntimeProvisioned))
+	if provisioned == nil || provisioned.Status == metav1.ConditionTrue {
+		return
+	}
+	m.Metrics.ObserveRuntimeOperationDuration(s.instance, metrics.OperationUpdate, now.Sub(provisioned.LastTransitionTime.Time))
+}
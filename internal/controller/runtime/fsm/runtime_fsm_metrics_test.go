@@ -0,0 +1,106 @@
+package fsm
+
+import (
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/metrics/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStateFnShortName(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		fn       stateFn
+		expected string
+	}{
+		{name: "Should return name of state function", fn: sFnTakeSnapshot, expected: "sFnTakeSnapshot"},
+		{name: "Should return name of state function closure", fn: sFnUpdateStatus(nil, nil), expected: "sFnUpdateStatus"},
+		{name: "Should return stop for stopped state machine", fn: nil, expected: "stop"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stateFnShortName(tc.fn))
+		})
+	}
+}
+
+func TestRecordStatusMetrics(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	setupMetrics := func() (*mocks.Metrics, *fsm) {
+		m := &mocks.Metrics{}
+		m.On("ObserveRuntimeOperationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
+		m.On("IncRuntimeFailureCounter", mock.Anything, mock.Anything).Return()
+		return m, &fsm{RCCfg: RCCfg{Metrics: m}}
+	}
+
+	t.Run("Should observe provisioning duration when provisioning completes", func(t *testing.T) {
+		// given
+		metricsMock, testFsm := setupMetrics()
+		runtime := makeInputRuntimeWithAnnotation(nil)
+		runtime.CreationTimestamp = metav1.NewTime(now.Add(-20 * time.Minute))
+		s := &systemState{instance: *runtime}
+		s.saveRuntimeStatus()
+		s.instance.UpdateStateReady(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConfigurationCompleted, "Runtime processing completed successfully")
+		s.instance.UpdateStateProvisioningCompleted()
+
+		// when
+		recordStatusMetrics(testFsm, s, now)
+
+		// then
+		metricsMock.AssertCalled(t, "ObserveRuntimeOperationDuration", s.instance, metrics.OperationProvisioning, 20*time.Minute)
+		metricsMock.AssertNotCalled(t, "IncRuntimeFailureCounter", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should observe update duration when runtime becomes ready again", func(t *testing.T) {
+		// given
+		metricsMock, testFsm := setupMetrics()
+		runtime := makeInputRuntimeWithAnnotation(nil)
+		runtime.UpdateStateProvisioningCompleted()
+		runtime.UpdateStatePending(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonProcessing, metav1.ConditionUnknown, "Shoot update is pending")
+		meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeRuntimeProvisioned)).LastTransitionTime = metav1.NewTime(now.Add(-5 * time.Minute))
+		s := &systemState{instance: *runtime}
+		s.saveRuntimeStatus()
+		s.instance.UpdateStateReady(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConfigurationCompleted, "Runtime processing completed successfully")
+
+		// when
+		recordStatusMetrics(testFsm, s, now)
+
+		// then
+		metricsMock.AssertCalled(t, "ObserveRuntimeOperationDuration", s.instance, metrics.OperationUpdate, 5*time.Minute)
+	})
+
+	t.Run("Should count failure reason when runtime enters failed state", func(t *testing.T) {
+		// given
+		metricsMock, testFsm := setupMetrics()
+		runtime := makeInputRuntimeWithAnnotation(nil)
+		s := &systemState{instance: *runtime}
+		s.saveRuntimeStatus()
+		s.instance.UpdateStateFailed(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonGardenerError, "Runtime failed")
+
+		// when
+		recordStatusMetrics(testFsm, s, now)
+
+		// then
+		metricsMock.AssertCalled(t, "IncRuntimeFailureCounter", s.instance, imv1.ConditionReasonGardenerError)
+		metricsMock.AssertNotCalled(t, "ObserveRuntimeOperationDuration", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not count failure of runtime which already failed", func(t *testing.T) {
+		// given
+		metricsMock, testFsm := setupMetrics()
+		s := &systemState{instance: *makeFailedRuntime(imv1.ConditionReasonGardenerError)}
+		s.saveRuntimeStatus()
+
+		// when
+		recordStatusMetrics(testFsm, s, now)
+
+		// then
+		metricsMock.AssertNotCalled(t, "IncRuntimeFailureCounter", mock.Anything, mock.Anything)
+	})
+}
@@ -53,14 +53,13 @@ func sFnMigrateToDedicatedAuditLog(ctx context.Context, m *fsm, s *systemState)
 		msg := fmt.Sprintf("Failed to get and claim dedicated audit log configuration: %v", err)
 		m.log.Error(err, "Cannot complete runtime provisioning - failed to claim reserved audit log")
 
-		s.instance.UpdateStateFailed(
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStateFailedWithErrorAndStop(
+			m, s,
 			imv1.ConditionTypeCustomAuditLogConfigured,
 			imv1.ConditionReasonCustomAuditLogError,
 			msg,
 		)
-
-		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatusAndStop()
 	}
 
 	m.log.Info("Successfully claimed dedicated audit log configuration",
@@ -0,0 +1,132 @@
+package fsm
+
+import (
+	"context"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/notification"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Notifier publishes the notifications of the lifecycle transitions of Runtimes
+type Notifier interface {
+	Publish(ctx context.Context, runtime imv1.Runtime, runtimeNotification imv1.RuntimeNotification) error
+}
+
+type lifecycleTransition struct {
+	eventType notification.EventType
+	reason    string
+}
+
+// lifecycleTransitions returns the transitions between the status at the beginning of the reconciliation and the written status
+func lifecycleTransitions(previous imv1.RuntimeStatus, runtime *imv1.Runtime) []lifecycleTransition {
+	current := runtime.Status
+	var transitions []lifecycleTransition
+
+	if previous.State == "" && current.State != "" {
+		transitions = append(transitions, lifecycleTransition{notification.EventTypeCreated, latestConditionReason(runtime)})
+	}
+
+	if conditionReasonChangedTo(previous, current, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonShootCreationCompleted) {
+		transitions = append(transitions, lifecycleTransition{notification.EventTypeShootCreated, string(imv1.ConditionReasonShootCreationCompleted)})
+	}
+
+	if kubeconfig := meta.FindStatusCondition(current.Conditions, string(imv1.ConditionTypeRuntimeKubeconfigReady)); kubeconfig != nil &&
+		kubeconfig.Status == metav1.ConditionTrue &&
+		!meta.IsStatusConditionTrue(previous.Conditions, string(imv1.ConditionTypeRuntimeKubeconfigReady)) {
+		transitions = append(transitions, lifecycleTransition{notification.EventTypeKubeconfigReady, kubeconfig.Reason})
+	}
+
+	switch {
+	case current.State == imv1.RuntimeStateReady && previous.State != imv1.RuntimeStateReady:
+		transitions = append(transitions, lifecycleTransition{notification.EventTypeReady, latestConditionReason(runtime)})
+	case current.State == imv1.RuntimeStateFailed && previous.State != imv1.RuntimeStateFailed:
+		transitions = append(transitions, lifecycleTransition{notification.EventTypeFailed, string(failureReason(runtime))})
+	case isDeletionState(current.State) && !isDeletionState(previous.State):
+		transitions = append(transitions, lifecycleTransition{notification.EventTypeDeleting, latestConditionReason(runtime)})
+	}
+	return transitions
+}
+
+// recordNotifications adds the notifications of the lifecycle transitions to the status before it is written,
+// so that a notification is persisted together with its transition and isn't lost when its publication fails
+func recordNotifications(m *fsm, s *systemState) {
+	if m.Notifier == nil {
+		return
+	}
+
+	for _, transition := range lifecycleTransitions(s.snapshot, &s.instance) {
+		s.instance.Status.PendingNotifications = append(s.instance.Status.PendingNotifications, nextNotification(s.instance, transition))
+		s.instance.Status.NotificationSequence++
+	}
+}
+
+// publishPendingNotifications stores the pending notifications of the Runtime in the outbox and removes them from its status.
+// The notifications are published again with the next reconciliation when it fails.
+func publishPendingNotifications(ctx context.Context, m *fsm, s *systemState) error {
+	if m.Notifier == nil || len(s.instance.Status.PendingNotifications) == 0 {
+		return nil
+	}
+
+	for _, pending := range s.instance.Status.PendingNotifications {
+		if err := m.Notifier.Publish(ctx, s.instance, pending); err != nil {
+			return errors.Wrapf(err, "failed to publish %s notification", pending.EventType)
+		}
+	}
+
+	s.instance.Status.PendingNotifications = nil
+	return errors.Wrap(m.KcpClient.Status().Update(ctx, &s.instance), "failed to remove published notifications from status")
+}
+
+// publishDeletedNotification publishes the notification of the deletion before the finalizer is removed,
+// as the status of the Runtime CR can't be written anymore afterwards
+func publishDeletedNotification(ctx context.Context, m *fsm, s *systemState) error {
+	if m.Notifier == nil {
+		return nil
+	}
+
+	deleted := nextNotification(s.instance, lifecycleTransition{notification.EventTypeDeleted, latestConditionReason(&s.instance)})
+	return errors.Wrap(m.Notifier.Publish(ctx, s.instance, deleted), "failed to publish deleted notification")
+}
+
+func nextNotification(runtime imv1.Runtime, transition lifecycleTransition) imv1.RuntimeNotification {
+	return imv1.RuntimeNotification{
+		Sequence:  runtime.Status.NotificationSequence + 1,
+		EventType: string(transition.eventType),
+		Reason:    transition.reason,
+	}
+}
+
+func conditionReasonChangedTo(previous, current imv1.RuntimeStatus, conditionType imv1.RuntimeConditionType, reason imv1.RuntimeConditionReason) bool {
+	condition := meta.FindStatusCondition(current.Conditions, string(conditionType))
+	if condition == nil || condition.Reason != string(reason) {
+		return false
+	}
+
+	previousCondition := meta.FindStatusCondition(previous.Conditions, string(conditionType))
+	return previousCondition == nil || previousCondition.Reason != string(reason)
+}
+
+func isDeletionState(state imv1.State) bool {
+	return state == imv1.RuntimeStateTerminating || state == imv1.RuntimeStateDeletionScheduled
+}
+
+// latestConditionReason returns the reason of the most recently changed lifecycle condition of the Runtime
+func latestConditionReason(runtime *imv1.Runtime) string {
+	var latest *metav1.Condition
+	for i, condition := range runtime.Status.Conditions {
+		if condition.Type == string(imv1.ConditionTypeRuntimeFailureRecovery) ||
+			condition.Type == string(imv1.ConditionTypeRuntimeShootDrifted) {
+			continue
+		}
+		if latest == nil || condition.LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = &runtime.Status.Conditions[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Reason
+}
@@ -0,0 +1,261 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/notification"
+	. "github.com/onsi/gomega" //nolint:revive
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type notifierFunc func(runtime imv1.Runtime, runtimeNotification imv1.RuntimeNotification) error
+
+func (f notifierFunc) Publish(_ context.Context, runtime imv1.Runtime, runtimeNotification imv1.RuntimeNotification) error {
+	return f(runtime, runtimeNotification)
+}
+
+func TestLifecycleTransitions(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	condition := func(conditionType imv1.RuntimeConditionType, status metav1.ConditionStatus, reason imv1.RuntimeConditionReason, age time.Duration) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(conditionType),
+			Status:             status,
+			Reason:             string(reason),
+			LastTransitionTime: metav1.NewTime(now.Add(-age)),
+		}
+	}
+
+	status := func(state imv1.State, conditions ...metav1.Condition) imv1.RuntimeStatus {
+		return imv1.RuntimeStatus{State: state, Conditions: conditions}
+	}
+
+	provisioningPending := condition(imv1.ConditionTypeRuntimeProvisioned, metav1.ConditionUnknown, imv1.ConditionReasonShootCreationPending, time.Minute)
+	shootCreated := condition(imv1.ConditionTypeRuntimeProvisioned, metav1.ConditionTrue, imv1.ConditionReasonShootCreationCompleted, 0)
+	kubeconfigReady := condition(imv1.ConditionTypeRuntimeKubeconfigReady, metav1.ConditionTrue, imv1.ConditionReasonGardenerCRReady, 2*time.Minute)
+	configured := condition(imv1.ConditionTypeRuntimeProvisioned, metav1.ConditionTrue, imv1.ConditionReasonConfigurationCompleted, time.Minute)
+	configurationFailed := condition(imv1.ConditionTypeRuntimeProvisioned, metav1.ConditionFalse, imv1.ConditionReasonConfigurationErr, 0)
+	deleting := condition(imv1.ConditionTypeRuntimeDeprovisioned, metav1.ConditionUnknown, imv1.ConditionReasonGardenerCRDeleted, 0)
+
+	for _, tc := range []struct {
+		name     string
+		previous imv1.RuntimeStatus
+		current  imv1.RuntimeStatus
+		expected []lifecycleTransition
+	}{
+		{
+			name:     "Should notify creation of the Runtime",
+			previous: status(""),
+			current:  status(imv1.RuntimeStatePending, provisioningPending),
+			expected: []lifecycleTransition{{notification.EventTypeCreated, string(imv1.ConditionReasonShootCreationPending)}},
+		},
+		{
+			name:     "Should notify creation of the shoot",
+			previous: status(imv1.RuntimeStatePending, provisioningPending),
+			current:  status(imv1.RuntimeStatePending, shootCreated),
+			expected: []lifecycleTransition{{notification.EventTypeShootCreated, string(imv1.ConditionReasonShootCreationCompleted)}},
+		},
+		{
+			name:     "Should notify kubeconfig and readiness of the Runtime",
+			previous: status(imv1.RuntimeStatePending, shootCreated),
+			current:  status(imv1.RuntimeStateReady, configured, kubeconfigReady),
+			expected: []lifecycleTransition{
+				{notification.EventTypeKubeconfigReady, string(imv1.ConditionReasonGardenerCRReady)},
+				{notification.EventTypeReady, string(imv1.ConditionReasonConfigurationCompleted)},
+			},
+		},
+		{
+			name:     "Should notify failure with its reason",
+			previous: status(imv1.RuntimeStatePending, shootCreated, kubeconfigReady),
+			current:  status(imv1.RuntimeStateFailed, configurationFailed, kubeconfigReady),
+			expected: []lifecycleTransition{{notification.EventTypeFailed, string(imv1.ConditionReasonConfigurationErr)}},
+		},
+		{
+			name:     "Should notify start of the deletion",
+			previous: status(imv1.RuntimeStateReady, configured, kubeconfigReady),
+			current:  status(imv1.RuntimeStateTerminating, configured, kubeconfigReady, deleting),
+			expected: []lifecycleTransition{{notification.EventTypeDeleting, string(imv1.ConditionReasonGardenerCRDeleted)}},
+		},
+		{
+			name:     "Should not notify when the Runtime stays in the same state",
+			previous: status(imv1.RuntimeStateReady, configured, kubeconfigReady),
+			current:  status(imv1.RuntimeStateReady, configured, kubeconfigReady),
+		},
+		{
+			name:     "Should not notify deletion again when the scheduled deletion starts",
+			previous: status(imv1.RuntimeStateDeletionScheduled, configured, kubeconfigReady),
+			current:  status(imv1.RuntimeStateTerminating, configured, kubeconfigReady, deleting),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			runtime := imv1.Runtime{Status: tc.current}
+
+			// when
+			transitions := lifecycleTransitions(tc.previous, &runtime)
+
+			// then
+			assert.Equal(t, tc.expected, transitions)
+		})
+	}
+}
+
+func TestNotificationPublishing(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	testScheme := api.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	util.Must(gardener.AddToScheme(testScheme))
+
+	RegisterTestingT(t)
+
+	var published []imv1.RuntimeNotification
+	publishing := notifierFunc(func(_ imv1.Runtime, runtimeNotification imv1.RuntimeNotification) error {
+		published = append(published, runtimeNotification)
+		return nil
+	})
+	failing := notifierFunc(func(imv1.Runtime, imv1.RuntimeNotification) error {
+		return errors.New("etcdserver: request timed out")
+	})
+
+	created := imv1.RuntimeNotification{Sequence: 1, EventType: string(notification.EventTypeCreated), Reason: string(imv1.ConditionReasonShootCreationPending)}
+
+	setup := func(notifier Notifier, runtime *imv1.Runtime) *fsm {
+		published = nil
+		testFsm := setupFakeFSMForTest(testScheme, runtime)
+		Expect(withFakeEventRecorder(5)(testFsm)).To(Succeed())
+		testFsm.Notifier = notifier
+		return testFsm
+	}
+
+	getRuntime := func(testFsm *fsm, runtime *imv1.Runtime) imv1.Runtime {
+		var runtimeAfter imv1.Runtime
+		Expect(testFsm.KcpClient.Get(testCtx, client.ObjectKeyFromObject(runtime), &runtimeAfter)).To(Succeed())
+		return runtimeAfter
+	}
+
+	t.Run("should publish the notification of a written transition", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setup(publishing, inputRuntime)
+		systemState := &systemState{instance: *inputRuntime}
+		systemState.instance.UpdateStatePending(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonShootCreationPending, metav1.ConditionUnknown, "Shoot is pending")
+
+		// when
+		sFn, _, err := sFnUpdateStatus(nil, nil)(testCtx, testFsm, systemState)
+		Expect(err).To(BeNil())
+		_, _, err = sFn(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(published).To(Equal([]imv1.RuntimeNotification{created}))
+
+		runtimeAfter := getRuntime(testFsm, inputRuntime)
+		Expect(runtimeAfter.Status.NotificationSequence).To(Equal(int64(1)))
+		Expect(runtimeAfter.Status.PendingNotifications).To(BeEmpty())
+	})
+
+	t.Run("should keep the notification pending in the status when its publication fails", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setup(failing, inputRuntime)
+		systemState := &systemState{instance: *inputRuntime}
+		systemState.instance.UpdateStatePending(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonShootCreationPending, metav1.ConditionUnknown, "Shoot is pending")
+
+		// when
+		sFn, _, err := sFnUpdateStatus(nil, nil)(testCtx, testFsm, systemState)
+		Expect(err).To(BeNil())
+		_, _, err = sFn(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(MatchError(ContainSubstring("failed to publish created notification")))
+
+		runtimeAfter := getRuntime(testFsm, inputRuntime)
+		Expect(runtimeAfter.Status.State).To(Equal(imv1.State(imv1.RuntimeStatePending)))
+		Expect(runtimeAfter.Status.NotificationSequence).To(Equal(int64(1)))
+		Expect(runtimeAfter.Status.PendingNotifications).To(Equal([]imv1.RuntimeNotification{created}))
+	})
+
+	t.Run("should publish the pending notifications before the runtime is processed", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		inputRuntime.Status.NotificationSequence = 1
+		inputRuntime.Status.PendingNotifications = []imv1.RuntimeNotification{created}
+		testFsm := setup(publishing, inputRuntime)
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := sFnTakeSnapshot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnInitialize"))
+		Expect(published).To(Equal([]imv1.RuntimeNotification{created}))
+		Expect(systemState.snapshot.PendingNotifications).To(BeEmpty())
+		Expect(getRuntime(testFsm, inputRuntime).Status.PendingNotifications).To(BeEmpty())
+	})
+
+	t.Run("should stop the reconciliation when the pending notifications can't be published", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		inputRuntime.Status.NotificationSequence = 1
+		inputRuntime.Status.PendingNotifications = []imv1.RuntimeNotification{created}
+		testFsm := setup(failing, inputRuntime)
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := sFnTakeSnapshot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(sFn).To(BeNil())
+		Expect(getRuntime(testFsm, inputRuntime).Status.PendingNotifications).To(HaveLen(1))
+	})
+
+	t.Run("should keep the finalizer when the deletion can't be notified", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		inputRuntime.Finalizers = []string{"test-me-plz"}
+		inputRuntime.Status.NotificationSequence = 4
+		testFsm := setup(failing, inputRuntime)
+		testFsm.Finalizer = "test-me-plz"
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := removeFinalizerAndStop(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+		Expect(getRuntime(testFsm, inputRuntime).Finalizers).To(ContainElement("test-me-plz"))
+	})
+
+	t.Run("should notify the deletion with the next sequence", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		inputRuntime.Finalizers = []string{"test-me-plz"}
+		inputRuntime.Status.NotificationSequence = 4
+		testFsm := setup(publishing, inputRuntime)
+		testFsm.Finalizer = "test-me-plz"
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		_, _, err := removeFinalizerAndStop(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(published).To(HaveLen(1))
+		Expect(published[0].Sequence).To(Equal(int64(5)))
+		Expect(published[0].EventType).To(Equal(string(notification.EventTypeDeleted)))
+	})
+}
@@ -41,14 +41,14 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 	err = structuredauth.CreateOrUpdateStructuredAuthConfigMap(
 		ctx,
 		m.GardenClient,
-		types.NamespacedName{Name: cmName, Namespace: m.ShootNamesapace},
+		types.NamespacedName{Name: cmName, Namespace: gardenerNamespace(m, s)},
 		oidcConfig,
 	)
 
 	if err != nil {
 		m.Metrics.IncRuntimeFSMStopCounter()
 		return updateStateFailedWithErrorAndStop(
-			&s.instance,
+			m, s,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonOidcError,
 			msgFailedStructuredConfigMap)
@@ -81,11 +81,15 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 			setRegistryCacheStatusFailed(ctx, m, s)
 		}
 
-		return updateStateFailedWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConversionError, fmt.Sprintf("Runtime conversion error %v", err))
+		return updateStateFailedWithErrorAndStop(m, s, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConversionError, fmt.Sprintf("Runtime conversion error %v", err))
 	}
 
 	m.log.V(log_level.DEBUG).Info("Shoot converted successfully", "Name", updatedShoot.Name, "Namespace", updatedShoot.Namespace)
 
+	if nextState, res, err := validateWithCloudProfile(ctx, m, s, updatedShoot, s.shoot); nextState != nil {
+		return nextState, res, err
+	}
+
 	hasRegistryCacheCountChanged, err := registrycache.HasRegistryCacheCountChanged(s.shoot.Spec.Extensions, s.instance.Spec.Caching)
 	if err != nil {
 		m.log.Error(err, "Failed to check if registry cache secret should be removed")
@@ -126,26 +130,6 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 		}
 	}
 
-	bindingShouldBePatched := m.ConverterConfig.Gardener.EnableCredentialBinding && s.shoot.Spec.SecretBindingName != nil && *s.shoot.Spec.SecretBindingName != "" //nolint:staticcheck
-	// Gardener is not handling properly the change from SecretBindingName to CredentialsBindingName with the Patch operation.
-	// Therefore, we need to do an additional Update operation to set the CredentialsBindingName and remove the SecretBindingName.
-	// This update can be removed after migration to CredentialsBinding is completed and all runtimes are using it.
-	if bindingShouldBePatched {
-		copyShoot := s.shoot.DeepCopy()
-		copyShoot.Spec.CredentialsBindingName = ptr.To(s.instance.Spec.Shoot.SecretBindingName)
-		copyShoot.Spec.SecretBindingName = nil //nolint:staticcheck
-
-		updateErr := m.GardenClient.Update(ctx, copyShoot,
-			&client.UpdateOptions{
-				FieldManager: fieldManagerName,
-			})
-
-		nextState, res, err := handleUpdateError(updateErr, m, s, "Failed to update shoot object with new CredentialsBinding, exiting with no retry", "Gardener API shoot update error")
-		if nextState != nil {
-			return nextState, res, err
-		}
-	}
-
 	//nolint:staticcheck // SA1019: client.Apply is used with Patch, which is the correct API for this version
 	patchErr := m.GardenClient.Patch(ctx, &updatedShoot, client.Apply, &client.PatchOptions{
 		FieldManager: fieldManagerName,
@@ -163,6 +147,8 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 		return requeue()
 	}
 
+	setMachineImageStatus(&s.instance, updatedShoot)
+
 	if updatedShoot.Generation == s.shoot.Generation {
 		m.log.V(log_level.DEBUG).Info("Gardener shoot for runtime did not change after patch, moving to processing", "Name", s.shoot.Name, "Namespace", s.shoot.Namespace)
 
@@ -173,6 +159,10 @@ func sFnPatchExistingShoot(ctx context.Context, m *fsm, s *systemState) (stateFn
 			"Shoot patched without changes",
 		)
 
+		if isShootHibernated(s.shoot) {
+			return switchState(sFnWaitForShootHibernation)
+		}
+
 		return switchState(sFnHandleKubeconfig)
 	}
 
@@ -219,7 +209,7 @@ func handleUpdateError(err error, m *fsm, s *systemState, errMsg, statusMsg stri
 
 		m.log.Error(err, errMsg)
 		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStateFailedWithErrorAndStop(&s.instance, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonProcessingErr, fmt.Sprintf("%s: %v", statusMsg, err))
+		return updateStateFailedWithErrorAndStop(m, s, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonProcessingErr, fmt.Sprintf("%s: %v", statusMsg, err))
 	}
 
 	return nil, nil, nil
@@ -268,39 +258,41 @@ func convertPatch(ctx context.Context, instance *imv1.Runtime, opts gardener_sho
 	return newShoot, nil
 }
 
-func updateStateFailedWithErrorAndStop(instance *imv1.Runtime,
-	//nolint:unparam
-	c imv1.RuntimeConditionType, r imv1.RuntimeConditionReason, msg string) (stateFn, *ctrl.Result, error) {
-	instance.UpdateStateFailed(c, r, msg)
-	return updateStatusAndStop()
-}
-
 func getPatchOptions(ctx context.Context, m *fsm, s *systemState, auditLogConfig auditlogs.AuditLogData) (gardener_shoot.PatchOpts, error) {
-	patchOptions := gardener_shoot.PatchOpts{
-		KcpClient:                       m.KcpClient,
+	inputs := gardener_shoot.OptsInputs{
 		ConverterConfig:                 m.ConverterConfig,
 		AuditLogData:                    auditLogConfig,
-		MaintenanceTimeWindow:           getMaintenanceTimeWindow(s, m),
-		Workers:                         s.shoot.Spec.Provider.Workers,
-		ShootK8SVersion:                 s.shoot.Spec.Kubernetes.Version,
-		Extensions:                      s.shoot.Spec.Extensions,
-		Resources:                       s.shoot.Spec.Resources,
-		InfrastructureConfig:            s.shoot.Spec.Provider.InfrastructureConfig,
-		ControlPlaneConfig:              s.shoot.Spec.Provider.ControlPlaneConfig,
+		KcpClient:                       m.KcpClient,
 		ApiServerAclEnabled:             m.ApiServerAclEnabled,
-		ExistingDNS:                     s.shoot.Spec.DNS,
 		NetworkRestrictionGlobalEnabled: m.NetworkRestrictionGlobalEnabled,
 	}
 
+	machineImages, err := getMachineImages(ctx, m, s.instance)
+	if err != nil {
+		return gardener_shoot.PatchOpts{}, err
+	}
+	inputs.MachineImages = machineImages
+
+	bindingName, err := resolveBindingName(ctx, m, s)
+	if err != nil {
+		return gardener_shoot.PatchOpts{}, err
+	}
+	inputs.BindingName = bindingName
+
 	if m.RegistryCacheConfigControllerEnabled {
-		secretManager := registrycache.NewGardenSecretManager(m.GardenClient, fmt.Sprintf("garden-%s", m.ConverterConfig.Gardener.ProjectName), s.instance.Labels[imv1.LabelKymaRuntimeID])
+		secretManager := registrycache.NewGardenSecretManager(m.GardenClient, gardenerNamespace(m, s), s.instance.Labels[imv1.LabelKymaRuntimeID])
 
 		registryCacheGardenSecretNames, err := secretManager.GetCacheUIDToSecretNameMap(ctx)
 		if err != nil {
-			return patchOptions, err
+			return gardener_shoot.PatchOpts{}, err
 		}
 
-		patchOptions.RegistryCacheGardenSecretNames = registryCacheGardenSecretNames
+		inputs.RegistryCacheGardenSecretNames = registryCacheGardenSecretNames
+	}
+
+	patchOptions, err := gardener_shoot.NewPatchOpts(s.instance, *s.shoot, inputs)
+	if err != nil {
+		m.log.Error(err, "Failed to get Maintenance Window data for region")
 	}
 
 	return patchOptions, nil
@@ -350,7 +342,7 @@ func getSharedAuditLogDataWithErrorHandling(ctx context.Context, m *fsm, s *syst
 		if m.AuditLogMandatory {
 			m.Metrics.IncRuntimeFSMStopCounter()
 			nextState, res, stateErr := updateStateFailedWithErrorAndStop(
-				&s.instance,
+				m, s,
 				imv1.ConditionTypeRuntimeProvisioned,
 				imv1.ConditionReasonAuditLogError,
 				msgFailedToConfigureAuditlogs)
@@ -378,7 +370,7 @@ func claimDedicatedAuditLog(ctx context.Context, m *fsm, s *systemState, runtime
 		m.log.Error(err, "Cannot upgrade runtime to dedicated audit logging")
 		m.Metrics.IncRuntimeFSMStopCounter()
 		nextState, res, stateErr := updateStateFailedWithErrorAndStop(
-			&s.instance,
+			m, s,
 			imv1.ConditionTypeRuntimeProvisioned,
 			imv1.ConditionReasonCustomAuditLogError,
 			msg)
@@ -225,7 +225,6 @@ func setupFakeFSMForTest(scheme *api.Scheme, objs ...client.Object) *fsm {
 	return must(newFakeFSM,
 		withMockedMetrics(),
 		withTestFinalizer,
-		withShootNamespace("garden-"),
 		withFakedK8sClient(scheme, objs...),
 		withFakeEventRecorder(1),
 		withDefaultReconcileDuration(),
@@ -242,7 +241,6 @@ func setupFakeFSMUpdatePatchForTest(scheme *api.Scheme, objs ...client.Object) *
 	return must(newFakeFSM,
 		withMockedMetrics(),
 		withTestFinalizer,
-		withShootNamespace("garden-"),
 		withFakedK8sClientWithFakeUpdateAndPatch(scheme, objs...),
 		withFakeEventRecorder(1),
 		withDefaultReconcileDuration(),
@@ -258,7 +256,6 @@ func setupFakeFSMForTestKeepGeneration(scheme *api.Scheme, runtime *imv1.Runtime
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientKeepGeneration(scheme, runtime),
 		withFakeEventRecorder(1),
@@ -278,7 +275,6 @@ func setupFakeFSMForTestWithFailingPatchWithInConflictError(scheme *api.Scheme,
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientFailPatchError(err, scheme, runtime),
 		withFakeEventRecorder(1),
@@ -298,7 +294,6 @@ func setupFakeFSMForTestWithFailingUpdateWithInConflictError(scheme *api.Scheme,
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientFailUpdateError(err, scheme, runtime),
 		withFakeEventRecorder(1),
@@ -318,7 +313,6 @@ func setupFakeFSMForTestWithFailingPatchWithForbiddenError(scheme *api.Scheme, r
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientFailPatchError(err, scheme, runtime),
 		withFakeEventRecorder(1),
@@ -338,7 +332,6 @@ func setupFakeFSMForTestWithFailingUpdateWithForbiddenError(scheme *api.Scheme,
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientFailUpdateError(err, scheme, runtime),
 		withFakeEventRecorder(1),
@@ -357,7 +350,6 @@ func setupFakeFSMForTestWithFailingPatchWithOtherError(scheme *api.Scheme, runti
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientFailPatchError(err, scheme, runtime),
 		withFakeEventRecorder(1),
@@ -376,7 +368,6 @@ func setupFakeFSMForTestWithFailingUpdateWithOtherError(scheme *api.Scheme, runt
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClientFailUpdateError(err, scheme, runtime),
 		withFakeEventRecorder(1),
@@ -389,7 +380,6 @@ func setupFakeFSMForTestWithAuditLogMandatory(scheme *api.Scheme, runtime *imv1.
 	mockProvider := newMockAuditLogDataProviderWithError()
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClient(scheme, runtime),
 		withFakeEventRecorder(1),
@@ -407,7 +397,6 @@ func setupFakeFSMForTestWithAuditLogMandatoryAndConfig(scheme *api.Scheme, runti
 	})
 	return must(newFakeFSM,
 		withMockedMetrics(),
-		withShootNamespace("garden-"),
 		withTestFinalizer,
 		withFakedK8sClient(scheme, runtime),
 		withFakeEventRecorder(1),
@@ -541,7 +530,7 @@ func TestWorkersAreEqual(t *testing.T) {
 	}
 }
 
-func Test_SFnPatchExistingShoot_CredentialsBindingPatched(t *testing.T) {
+func Test_SFnPatchExistingShoot_SecretBindingKeptUntilMigrated(t *testing.T) {
 	RegisterTestingT(t)
 	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -553,14 +542,13 @@ func Test_SFnPatchExistingShoot_CredentialsBindingPatched(t *testing.T) {
 
 	// Prepare runtime and shoot
 	inputRuntime := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/existing-annotation": "true"})
-	// Ensure runtime has the secret that should be propagated to CredentialsBindingName
 	inputRuntime.Spec.Shoot.SecretBindingName = "runtime-secret"
 
 	// Create FSM and enable credential binding in converter config
 	f := setupFakeFSMUpdatePatchForTest(testScheme, inputRuntime)
 	f.ConverterConfig.Gardener.EnableCredentialBinding = true
 
-	// Create a shoot that has SecretBindingName set (so bindingShouldBePatched will be true)
+	// Create a shoot that has SecretBindingName set, it is switched to a CredentialsBinding only by the shoot-credentials-binding migration
 	shoot := fsm_testing.TestShootForPatch()
 	shoot.Spec.SecretBindingName = ptr.To("existing-shoot-secret") //nolint:staticcheck
 
@@ -573,15 +561,13 @@ func Test_SFnPatchExistingShoot_CredentialsBindingPatched(t *testing.T) {
 	Expect(err).To(BeNil())
 	Expect(res).To(BeNil())
 
-	// Fetch the Shoot from the fake GardenClient and assert it was updated with CredentialsBindingName and SecretBindingName cleared
+	// Fetch the Shoot from the fake GardenClient and assert it still uses the SecretBinding
 	gotShoot := &gardener.Shoot{}
 	getErr := f.GardenClient.Get(testCtx, client.ObjectKey{Name: shoot.Name, Namespace: shoot.Namespace}, gotShoot)
 	Expect(getErr).To(BeNil())
 
-	// CredentialsBindingName should be set to runtime secret and SecretBindingName should be nil
-	Expect(gotShoot.Spec.CredentialsBindingName).To(Not(BeNil()))
-	Expect(*gotShoot.Spec.CredentialsBindingName).To(Equal(inputRuntime.Spec.Shoot.SecretBindingName))
-	Expect(gotShoot.Spec.SecretBindingName).To(BeNil()) //nolint:staticcheck
+	Expect(gotShoot.Spec.CredentialsBindingName).To(BeNil())
+	Expect(gotShoot.Spec.SecretBindingName).To(Not(BeNil())) //nolint:staticcheck
 
 	// Next state should be update status (or other valid step); ensure no panic and a state is returned
 	Expect(sFn).To(Not(BeNil()))
@@ -637,7 +623,6 @@ func TestSFnPatchExistingShoot_DedicatedAuditLogUpgrade(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -680,7 +665,6 @@ func TestSFnPatchExistingShoot_DedicatedAuditLogUpgrade(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -716,7 +700,6 @@ func TestSFnPatchExistingShoot_DedicatedAuditLogUpgrade(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -754,7 +737,6 @@ func TestSFnPatchExistingShoot_DedicatedAuditLogUpgrade(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -796,7 +778,6 @@ func TestSFnPatchExistingShoot_DedicatedAuditLogUpgrade(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -834,7 +815,6 @@ func TestSFnPatchExistingShoot_DedicatedAuditLogUpgrade(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -884,7 +864,6 @@ func TestSetRegistryCacheStatusFailed(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFailedRuntimeK8sClient(clientErr, testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -915,7 +894,6 @@ func TestSetRegistryCacheStatusFailed(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
@@ -947,7 +925,6 @@ func TestSetRegistryCacheStatusFailed(t *testing.T) {
 
 		f := must(newFakeFSM,
 			withMockedMetrics(),
-			withShootNamespace("garden-"),
 			withTestFinalizer,
 			withFakedK8sClient(testScheme, inputRuntime),
 			withFakeEventRecorder(1),
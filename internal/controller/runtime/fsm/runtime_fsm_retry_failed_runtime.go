@@ -0,0 +1,232 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/errorcatalog"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const failureRecoveryJitter = 0.2
+
+// FailureRecoveryConfig configures the automatic retries of Runtimes in the Failed state, the retries are disabled when MaxAttempts is 0
+type FailureRecoveryConfig struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+func (c FailureRecoveryConfig) Enabled() bool {
+	return c.MaxAttempts > 0
+}
+
+// Delay returns the delay before the attempt, it is doubled with every attempt and jittered before it is capped at MaxDelay
+func (c FailureRecoveryConfig) Delay(attempt int) time.Duration {
+	delay := c.InitialDelay
+	for i := 1; i < attempt && (c.MaxDelay <= 0 || delay < c.MaxDelay); i++ {
+		delay *= 2
+	}
+	delay = wait.Jitter(delay, failureRecoveryJitter)
+	if c.MaxDelay > 0 && delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	return delay
+}
+
+// retryableFailureReasons are the reasons of the failures not caused by Gardener errors which may disappear without a change of the Runtime CR.
+// Conversion errors and unavailable CloudProfile entries require a user action and are not retried. The failures caused by Gardener errors
+// are retried according to their classification by the Gardener error catalog.
+var retryableFailureReasons = []imv1.RuntimeConditionReason{
+	imv1.ConditionReasonProcessingErr,
+	imv1.ConditionReasonCreationError,
+	imv1.ConditionReasonKubernetesAPIErr,
+	imv1.ConditionReasonAuditLogError,
+	imv1.ConditionReasonCustomAuditLogError,
+	imv1.ConditionReasonOidcError,
+	imv1.ConditionReasonSeedNotFound,
+	imv1.ConditionReasonRuntimeBootstrapperInstallationFailed,
+}
+
+// failureReason returns the reason of the most recent failed condition of the Runtime
+func failureReason(runtime *imv1.Runtime) imv1.RuntimeConditionReason {
+	var latest *metav1.Condition
+	for i, condition := range runtime.Status.Conditions {
+		if condition.Status != metav1.ConditionFalse ||
+			condition.Type == string(imv1.ConditionTypeRuntimeFailureRecovery) ||
+			condition.Type == string(imv1.ConditionTypeRuntimeShootDrifted) {
+			continue
+		}
+		if latest == nil || condition.LastTransitionTime.After(latest.LastTransitionTime.Time) {
+			latest = &runtime.Status.Conditions[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return imv1.RuntimeConditionReason(latest.Reason)
+}
+
+// updateStateFailedWithErrorAndStop sets the Runtime to the Failed state and schedules its next retry when the failure reason is retryable.
+// The processing stops after the status is written, the Runtime is requeued only when a retry is scheduled.
+func updateStateFailedWithErrorAndStop(m *fsm, s *systemState,
+	//nolint:unparam
+	c imv1.RuntimeConditionType, r imv1.RuntimeConditionReason, msg string) (stateFn, *ctrl.Result, error) {
+	s.instance.UpdateStateFailed(c, r, msg)
+	s.instance.Status.FailureClassification = nil
+
+	if delay := scheduleFailureRecovery(m, s, time.Now()); delay > 0 {
+		return updateStatusAndRequeueAfter(delay)
+	}
+	return updateStatusAndStop()
+}
+
+// updateStateFailedWithClassificationAndStop sets the Runtime to the Failed state because of Gardener errors and records their classification,
+// the next retry is scheduled when the classification is retryable
+func updateStateFailedWithClassificationAndStop(m *fsm, s *systemState, c imv1.RuntimeConditionType, classification errorcatalog.Classification) (stateFn, *ctrl.Result, error) {
+	s.instance.UpdateStateFailed(c, classification.Reason, classification.Message)
+	s.instance.Status.FailureClassification = failureClassification(classification)
+
+	if delay := scheduleFailureRecovery(m, s, time.Now()); delay > 0 {
+		return updateStatusAndRequeueAfter(delay)
+	}
+	return updateStatusAndStop()
+}
+
+// scheduleFailureRecovery schedules the next retry of a Runtime which has just failed.
+// It returns the delay until the retry is due, or 0 when no retry is scheduled.
+func scheduleFailureRecovery(m *fsm, s *systemState, now time.Time) time.Duration {
+	if !m.FailureRecovery.Enabled() || !s.instance.GetDeletionTimestamp().IsZero() || s.instance.Status.State != imv1.RuntimeStateFailed {
+		return 0
+	}
+
+	recovery := s.instance.Status.FailureRecovery
+	if recovery != nil && recovery.Generation != s.instance.Generation {
+		// a changed Runtime CR gets the full number of retries
+		recovery = nil
+		s.instance.Status.FailureRecovery = nil
+		meta.RemoveStatusCondition(&s.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))
+	}
+
+	if recovery != nil && recovery.NextRetryTime != nil {
+		if remaining := recovery.NextRetryTime.Sub(now); remaining > 0 {
+			return remaining
+		}
+	}
+
+	reason := failureReason(&s.instance)
+	classification := s.instance.Status.FailureClassification
+	if !isRetryableFailure(classification, reason) {
+		return 0
+	}
+
+	if recovery == nil {
+		recovery = &imv1.RuntimeFailureRecovery{Generation: s.instance.Generation}
+		s.instance.Status.FailureRecovery = recovery
+	}
+	recovery.Reason = string(reason)
+
+	if recovery.Attempts >= m.FailureRecovery.MaxAttempts {
+		recovery.NextRetryTime = nil
+		s.instance.SetCondition(imv1.ConditionTypeRuntimeFailureRecovery, imv1.ConditionReasonRetriesExhausted, metav1.ConditionFalse,
+			fmt.Sprintf("Runtime failed with reason %s after %d retries, no more retries are scheduled", reason, recovery.Attempts))
+		return 0
+	}
+
+	recovery.Attempts++
+	delay := failureRecoveryDelays(m.FailureRecovery, classification).Delay(recovery.Attempts)
+	recovery.NextRetryTime = &metav1.Time{Time: now.Add(delay)}
+	s.instance.SetCondition(imv1.ConditionTypeRuntimeFailureRecovery, imv1.ConditionReasonRetryScheduled, metav1.ConditionUnknown,
+		fmt.Sprintf("Runtime failed with reason %s, retry %d of %d is scheduled at %s",
+			reason, recovery.Attempts, m.FailureRecovery.MaxAttempts, recovery.NextRetryTime.UTC().Format(time.RFC3339)))
+
+	m.log.Info("Scheduled retry of failed runtime", "RuntimeCR", s.instance.Name, "reason", reason, "attempt", recovery.Attempts, "delay", delay)
+	return delay
+}
+
+func failureClassification(classification errorcatalog.Classification) *imv1.RuntimeFailureClassification {
+	recorded := &imv1.RuntimeFailureClassification{
+		Reason:    string(classification.Reason),
+		Retryable: classification.Retryable,
+	}
+	if backoff := classification.Backoff(); backoff.Initial > 0 {
+		recorded.InitialBackoff = &metav1.Duration{Duration: backoff.Initial}
+		if backoff.Max > 0 {
+			recorded.MaxBackoff = &metav1.Duration{Duration: backoff.Max}
+		}
+	}
+	return recorded
+}
+
+// isRetryableFailure returns true when the failure is retried, the failures caused by Gardener errors are retried according to their classification
+func isRetryableFailure(classification *imv1.RuntimeFailureClassification, reason imv1.RuntimeConditionReason) bool {
+	if classification != nil {
+		return classification.Retryable
+	}
+	return slices.Contains(retryableFailureReasons, reason)
+}
+
+// failureRecoveryDelays returns the delays of the retries, the backoff of the Gardener error catalog entry replaces the configured delays
+func failureRecoveryDelays(cfg FailureRecoveryConfig, classification *imv1.RuntimeFailureClassification) FailureRecoveryConfig {
+	if classification == nil || classification.InitialBackoff == nil {
+		return cfg
+	}
+
+	cfg.InitialDelay = classification.InitialBackoff.Duration
+	cfg.MaxDelay = 0
+	if classification.MaxBackoff != nil {
+		cfg.MaxDelay = classification.MaxBackoff.Duration
+	}
+	return cfg
+}
+
+// shouldRetryFailedRuntime checks if a retry is scheduled for a failed Runtime
+func shouldRetryFailedRuntime(m *fsm, s *systemState) bool {
+	return m.FailureRecovery.Enabled() &&
+		s.instance.Status.State == imv1.RuntimeStateFailed &&
+		s.instance.Status.FailureRecovery != nil &&
+		s.instance.Status.FailureRecovery.NextRetryTime != nil &&
+		!reconciler.ShouldSuspendReconciliation(s.instance.Annotations)
+}
+
+// sFnRetryFailedRuntime processes a failed Runtime again when its retry is due.
+// A failed Shoot operation is retried by Gardener, then the Runtime is handled like a pending one.
+func sFnRetryFailedRuntime(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	recovery := s.instance.Status.FailureRecovery
+	if remaining := time.Until(recovery.NextRetryTime.Time); remaining > 0 {
+		return requeueAfter(remaining)
+	}
+
+	m.log.Info("Retrying failed runtime", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "attempt", recovery.Attempts, "reason", recovery.Reason)
+
+	if s.shoot.Status.LastOperation.State == gardener.LastOperationStateFailed {
+		patchedShoot := s.shoot.DeepCopy()
+		metav1.SetMetaDataAnnotation(&patchedShoot.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.ShootOperationRetry)
+
+		if err := m.GardenClient.Patch(ctx, patchedShoot, client.MergeFrom(s.shoot), &client.PatchOptions{
+			FieldManager: fieldManagerName,
+		}); err != nil {
+			m.log.Error(err, "Failed to request retry of the Shoot operation", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+			return requeueAfter(m.GardenerRequeueDuration)
+		}
+	}
+
+	recovery.NextRetryTime = nil
+	s.instance.UpdateStatePending(
+		imv1.ConditionTypeRuntimeFailureRecovery,
+		imv1.ConditionReasonRetryInProgress,
+		metav1.ConditionUnknown,
+		fmt.Sprintf("Retrying failed runtime, attempt %d of %d", recovery.Attempts, m.FailureRecovery.MaxAttempts),
+	)
+	return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+}
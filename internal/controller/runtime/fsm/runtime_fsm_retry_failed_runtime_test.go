@@ -0,0 +1,397 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	fsm_testing "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/testing"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/errorcatalog"
+	. "github.com/onsi/gomega" //nolint:revive
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestFSMRetryFailedRuntime(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	testScheme := api.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	util.Must(gardener.AddToScheme(testScheme))
+
+	RegisterTestingT(t)
+
+	failureRecovery := FailureRecoveryConfig{MaxAttempts: 3, InitialDelay: 5 * time.Minute, MaxDelay: time.Hour}
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should schedule retry of runtime failed with retryable reason", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonKubernetesAPIErr)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(BeNumerically(">=", 5*time.Minute))
+		Expect(delay).To(BeNumerically("<=", 6*time.Minute))
+
+		recovery := systemState.instance.Status.FailureRecovery
+		Expect(recovery).ToNot(BeNil())
+		Expect(recovery.Attempts).To(Equal(1))
+		Expect(recovery.Reason).To(Equal(string(imv1.ConditionReasonKubernetesAPIErr)))
+		Expect(recovery.NextRetryTime.Time).To(Equal(now.Add(delay)))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonRetryScheduled)))
+	})
+
+	t.Run("should not schedule retry of runtime failed with non-retryable reason", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonConversionError)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(BeZero())
+		Expect(systemState.instance.Status.FailureRecovery).To(BeNil())
+		Expect(meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))).To(BeNil())
+	})
+
+	t.Run("should not schedule retry of runtime failed with gardener errors classified as non-retryable", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonInfrastructureQuotaExceeded)
+		inputRuntime.Status.FailureClassification = &imv1.RuntimeFailureClassification{
+			Reason:         string(imv1.ConditionReasonInfrastructureQuotaExceeded),
+			Retryable:      false,
+			InitialBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+		}
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(BeZero())
+		Expect(systemState.instance.Status.FailureRecovery).To(BeNil())
+	})
+
+	t.Run("should schedule retry of runtime failed with retryable gardener errors with the backoff of the classification", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonInfrastructureResourcesDepleted)
+		inputRuntime.Status.FailureClassification = &imv1.RuntimeFailureClassification{
+			Reason:         string(imv1.ConditionReasonInfrastructureResourcesDepleted),
+			Retryable:      true,
+			InitialBackoff: &metav1.Duration{Duration: 20 * time.Minute},
+			MaxBackoff:     &metav1.Duration{Duration: 30 * time.Minute},
+		}
+		inputRuntime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{Attempts: 1}
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(Equal(30 * time.Minute))
+		Expect(systemState.instance.Status.FailureRecovery.Attempts).To(Equal(2))
+	})
+
+	t.Run("should record the classification of the gardener errors when runtime fails", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+		catalog := &errorcatalog.Catalog{Version: errorcatalog.Version, Entries: []errorcatalog.Entry{{
+			Reason:    imv1.ConditionReasonInfrastructureQuotaExceeded,
+			Codes:     []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded},
+			Retryable: false,
+			Backoff:   errorcatalog.Backoff{Initial: 5 * time.Minute, Max: time.Hour},
+		}}}
+		classification := catalog.Classify([]gardener.LastError{{
+			Description: "quota exceeded",
+			Codes:       []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded},
+		}})
+
+		// when
+		_, _, err := updateStateFailedWithClassificationAndStop(testFsm, systemState, imv1.ConditionTypeRuntimeProvisioned, classification)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateFailed)))
+		Expect(systemState.instance.Status.FailureClassification).To(Equal(&imv1.RuntimeFailureClassification{
+			Reason:         string(imv1.ConditionReasonInfrastructureQuotaExceeded),
+			Retryable:      false,
+			InitialBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+			MaxBackoff:     &metav1.Duration{Duration: time.Hour},
+		}))
+		Expect(systemState.instance.Status.FailureRecovery).To(BeNil())
+
+		// when
+		_, _, err = updateStateFailedWithErrorAndStop(testFsm, systemState, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonKubernetesAPIErr, "Runtime failed")
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(systemState.instance.Status.FailureClassification).To(BeNil())
+		Expect(systemState.instance.Status.FailureRecovery.Attempts).To(Equal(1))
+	})
+
+	t.Run("should not schedule retry when failure recovery is disabled", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonGardenerError)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(BeZero())
+		Expect(systemState.instance.Status.FailureRecovery).To(BeNil())
+	})
+
+	t.Run("should keep pending retry", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonGardenerError)
+		inputRuntime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{
+			Attempts:      2,
+			Reason:        string(imv1.ConditionReasonGardenerError),
+			NextRetryTime: &metav1.Time{Time: now.Add(7 * time.Minute)},
+		}
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(Equal(7 * time.Minute))
+		Expect(systemState.instance.Status.FailureRecovery.Attempts).To(Equal(2))
+	})
+
+	t.Run("should set terminal condition when retries are exhausted", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonInfrastructureQuotaExceeded)
+		inputRuntime.Status.FailureClassification = &imv1.RuntimeFailureClassification{
+			Reason:    string(imv1.ConditionReasonInfrastructureQuotaExceeded),
+			Retryable: true,
+		}
+		inputRuntime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{Attempts: 3}
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(BeZero())
+		Expect(systemState.instance.Status.FailureRecovery.Attempts).To(Equal(3))
+		Expect(systemState.instance.Status.FailureRecovery.NextRetryTime).To(BeNil())
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonRetriesExhausted)))
+	})
+
+	t.Run("should reset retries when runtime is changed", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonKubernetesAPIErr)
+		inputRuntime.Generation = 2
+		inputRuntime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{
+			Attempts:      3,
+			Generation:    1,
+			NextRetryTime: &metav1.Time{Time: now.Add(30 * time.Minute)},
+		}
+		inputRuntime.SetCondition(imv1.ConditionTypeRuntimeFailureRecovery, imv1.ConditionReasonRetriesExhausted, metav1.ConditionFalse, "exhausted")
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		delay := scheduleFailureRecovery(testFsm, systemState, now)
+
+		// then
+		Expect(delay).To(BeNumerically(">=", 5*time.Minute))
+		Expect(delay).To(BeNumerically("<=", 6*time.Minute))
+
+		recovery := systemState.instance.Status.FailureRecovery
+		Expect(recovery.Attempts).To(Equal(1))
+		Expect(recovery.Generation).To(Equal(int64(2)))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonRetryScheduled)))
+	})
+
+	t.Run("should reset failure recovery when runtime is ready", func(t *testing.T) {
+		// given
+		runtime := makeFailedRuntime(imv1.ConditionReasonGardenerError)
+		runtime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{Attempts: 2}
+		runtime.Status.FailureClassification = &imv1.RuntimeFailureClassification{Reason: string(imv1.ConditionReasonGardenerError), Retryable: true}
+		runtime.SetCondition(imv1.ConditionTypeRuntimeFailureRecovery, imv1.ConditionReasonRetryInProgress, metav1.ConditionUnknown, "retrying")
+
+		// when
+		runtime.UpdateStateReady(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonConfigurationCompleted, "Runtime processing completed successfully")
+
+		// then
+		Expect(runtime.Status.FailureRecovery).To(BeNil())
+		Expect(runtime.Status.FailureClassification).To(BeNil())
+		Expect(meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))).To(BeNil())
+	})
+
+	t.Run("should schedule retry in the failing state and requeue until the retry is due", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		Expect(withFakeEventRecorder(2)(testFsm)).To(Succeed())
+		systemState := &systemState{instance: *inputRuntime, snapshot: *inputRuntime.Status.DeepCopy()}
+
+		// when
+		sFn, res, err := updateStateFailedWithErrorAndStop(testFsm, systemState, imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonKubernetesAPIErr, "Runtime failed")
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(res).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateFailed)))
+		Expect(systemState.instance.Status.FailureRecovery.Attempts).To(Equal(1))
+
+		// when
+		sFn, _, err = sFn(testCtx, testFsm, systemState)
+		Expect(err).To(BeNil())
+		_, res, err = sFn(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(res).ToNot(BeNil())
+		Expect(res.RequeueAfter).To(BeNumerically(">=", 5*time.Minute))
+
+		runtimeAfter := &imv1.Runtime{}
+		Expect(testFsm.KcpClient.Get(testCtx, client.ObjectKeyFromObject(inputRuntime), runtimeAfter)).To(Succeed())
+		Expect(runtimeAfter.Status.FailureRecovery).ToNot(BeNil())
+		Expect(runtimeAfter.Status.FailureRecovery.NextRetryTime).ToNot(BeNil())
+	})
+
+	t.Run("should not modify the status of a failed runtime when it is written", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonGardenerError)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime, snapshot: *inputRuntime.Status.DeepCopy()}
+
+		// when
+		_, res, err := sFnUpdateStatus(nil, nil)(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(res).To(BeNil())
+		Expect(systemState.instance.Status.FailureRecovery).To(BeNil())
+	})
+
+	t.Run("should request retry of the failed shoot operation", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonGardenerError)
+		inputRuntime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{
+			Attempts:      1,
+			Reason:        string(imv1.ConditionReasonGardenerError),
+			NextRetryTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		}
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+
+		shoot := fsm_testing.TestShootForPatch()
+		shoot.Status.LastOperation.State = gardener.LastOperationStateFailed
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+
+		systemState := &systemState{instance: *inputRuntime, shoot: shoot}
+
+		// when
+		sFn, res, err := sFnRetryFailedRuntime(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(res).To(BeNil())
+		Expect(sFn).To(haveName("sFnUpdateStatus"))
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStatePending)))
+		Expect(systemState.instance.Status.FailureRecovery.NextRetryTime).To(BeNil())
+		Expect(systemState.instance.Status.FailureRecovery.Attempts).To(Equal(1))
+
+		condition := meta.FindStatusCondition(systemState.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeFailureRecovery))
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal(string(imv1.ConditionReasonRetryInProgress)))
+
+		shootAfter := &gardener.Shoot{}
+		Expect(testFsm.GardenClient.Get(testCtx, client.ObjectKeyFromObject(shoot), shootAfter)).To(Succeed())
+		Expect(shootAfter.Annotations).To(HaveKeyWithValue(v1beta1constants.GardenerOperation, v1beta1constants.ShootOperationRetry))
+	})
+
+	t.Run("should wait until the retry is due", func(t *testing.T) {
+		// given
+		inputRuntime := makeFailedRuntime(imv1.ConditionReasonGardenerError)
+		inputRuntime.Status.FailureRecovery = &imv1.RuntimeFailureRecovery{
+			Attempts:      1,
+			NextRetryTime: &metav1.Time{Time: time.Now().Add(time.Hour)},
+		}
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.FailureRecovery = failureRecovery
+		systemState := &systemState{instance: *inputRuntime, shoot: fsm_testing.TestShootForPatch()}
+
+		// when
+		sFn, res, err := sFnRetryFailedRuntime(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(BeNil())
+		Expect(res.RequeueAfter).To(BeNumerically(">", 59*time.Minute))
+		Expect(systemState.instance.Status.State).To(Equal(imv1.State(imv1.RuntimeStateFailed)))
+	})
+}
+
+func TestFailureRecoveryDelay(t *testing.T) {
+	cfg := FailureRecoveryConfig{MaxAttempts: 10, InitialDelay: 5 * time.Minute, MaxDelay: time.Hour}
+
+	for _, tc := range []struct {
+		attempt  int
+		minDelay time.Duration
+		maxDelay time.Duration
+	}{
+		{attempt: 1, minDelay: 5 * time.Minute, maxDelay: 6 * time.Minute},
+		{attempt: 2, minDelay: 10 * time.Minute, maxDelay: 12 * time.Minute},
+		{attempt: 4, minDelay: 40 * time.Minute, maxDelay: 48 * time.Minute},
+		{attempt: 5, minDelay: time.Hour, maxDelay: time.Hour},
+		{attempt: 10, minDelay: time.Hour, maxDelay: time.Hour},
+	} {
+		// the jitter is added before the delay is capped, so the delay never exceeds MaxDelay
+		if got := cfg.Delay(tc.attempt); got < tc.minDelay || got > tc.maxDelay {
+			t.Errorf("attempt %d: expected delay between %s and %s, got %s", tc.attempt, tc.minDelay, tc.maxDelay, got)
+		}
+	}
+}
+
+func makeFailedRuntime(reason imv1.RuntimeConditionReason) *imv1.Runtime {
+	runtime := makeInputRuntimeWithAnnotation(nil)
+	runtime.UpdateStateFailed(imv1.ConditionTypeRuntimeProvisioned, reason, "Runtime failed")
+	return runtime
+}
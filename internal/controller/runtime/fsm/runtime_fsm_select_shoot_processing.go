@@ -30,6 +30,10 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 
 	LogLastErrors(s, m)
 
+	if reconciler.ShouldDryRunPatch(s.instance.Annotations) {
+		return switchState(sFnDryRunPatchShoot)
+	}
+
 	patchShoot, err := shouldPatchShoot(&s.instance, s.shoot, &m.log)
 	if err != nil {
 		m.log.Error(err, "Failed to get applied generation for shoot", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
@@ -41,14 +45,20 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 		return switchState(sFnSyncRegistryCacheGardenSecrets)
 	}
 
-	if s.instance.Status.State == imv1.RuntimeStatePending || s.instance.Status.State == "" {
-		if lastOperation.Type == gardener.LastOperationTypeCreate {
-			return switchState(sFnWaitForShootCreation)
-		}
+	runtimePending := s.instance.Status.State == imv1.RuntimeStatePending || s.instance.Status.State == ""
 
-		if lastOperation.Type == gardener.LastOperationTypeReconcile {
-			return switchState(sFnWaitForShootReconcile)
-		}
+	if runtimePending && lastOperation.Type == gardener.LastOperationTypeCreate {
+		return switchState(sFnWaitForShootCreation)
+	}
+
+	// Hibernated shoots and shoots being hibernated or woken up must not be handled as regular reconciliations,
+	// otherwise the FSM would try to configure an unreachable cluster and set the Runtime to Failed
+	if shouldProcessHibernation(&s.instance, s.shoot) {
+		return switchState(sFnWaitForShootHibernation)
+	}
+
+	if runtimePending && lastOperation.Type == gardener.LastOperationTypeReconcile {
+		return switchState(sFnWaitForShootReconcile)
 	}
 
 	shootStatus := s.shoot.Status
@@ -80,11 +90,30 @@ func sFnSelectShootProcessing(_ context.Context, m *fsm, s *systemState) (stateF
 		}
 	}
 
+	if shouldRetryFailedRuntime(m, s) {
+		return switchState(sFnRetryFailedRuntime)
+	}
+
+	if shouldDetectShootDrift(m, s) {
+		nextCheck := nextShootDriftCheck(m, s)
+		if nextCheck <= 0 {
+			return switchState(sFnDetectShootDrift)
+		}
+		return requeueAfter(nextCheck)
+	}
+
 	// All other runtimes in Ready and Failed state will be not processed to mitigate massive reconciliation during restart
 	m.log.Info("Stopping processing reconcile, exiting with no retry", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name, "function", "sFnSelectShootProcessing")
 	return stop()
 }
 
+// shouldDetectShootDrift checks if the Shoot of a Ready Runtime should be periodically compared with the Runtime.
+func shouldDetectShootDrift(m *fsm, s *systemState) bool {
+	return m.ShootDriftDetectionInterval > 0 &&
+		s.instance.Status.State == imv1.RuntimeStateReady &&
+		!reconciler.ShouldSuspendReconciliation(s.instance.Annotations)
+}
+
 func LogLastErrors(s *systemState, m *fsm) {
 	if s.shoot == nil {
 		return
@@ -32,10 +32,18 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 
 	inputRtWithForceAnnotation := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/force-patch-reconciliation": "true"})
 	inputRtWithSuspendAnnotation := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/suspend-patch-reconciliation": "true"})
+	inputRtWithDryRunAnnotation := makeInputRuntimeWithAnnotation(map[string]string{"operator.kyma-project.io/dry-run-patch": "true"})
+	inputRtWithDryRunAnnotation.Status.State = imv1.RuntimeStateReady
 	inputRtReady := makeInputRuntimeWithAnnotation(nil)
 	inputRtReady.Status.State = imv1.RuntimeStateReady
 	inputRtFailed := makeInputRuntimeWithAnnotation(nil)
 	inputRtFailed.Status.State = imv1.RuntimeStateFailed
+	inputRtReadyDriftCheckDue := makeInputRuntimeWithAnnotation(nil)
+	inputRtReadyDriftCheckDue.Status.State = imv1.RuntimeStateReady
+	inputRtReadyDriftCheckDue.Status.ShootDriftCheckTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	inputRtReadyDriftChecked := makeInputRuntimeWithAnnotation(nil)
+	inputRtReadyDriftChecked.Status.State = imv1.RuntimeStateReady
+	inputRtReadyDriftChecked.Status.ShootDriftCheckTime = &metav1.Time{Time: time.Now()}
 
 	shootRuntimeGenerationAnnotation := map[string]string{
 		"infrastructuremanager.kyma-project.io/runtime-generation": "0",
@@ -98,6 +106,10 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 		},
 	}
 
+	testShootHibernated := testShootQuiet.DeepCopy()
+	testShootHibernated.Spec.Hibernation = &gardener.Hibernation{Enabled: ptr.To(true)}
+	testShootHibernated.Status.IsHibernated = true
+
 	testShoot := gardener.Shoot{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test-shoot",
@@ -130,6 +142,16 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 				MatchNextFnState: haveName("sFnSyncRegistryCacheGardenSecrets"),
 			},
 		),
+		Entry(
+			"should switch to sFnDryRunPatchShoot due to dry-run annotation",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtWithDryRunAnnotation, shoot: &testShootQuiet},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnDryRunPatchShoot"),
+			},
+		),
 		Entry(
 			"should stop due to suspend annotation",
 			testCtx,
@@ -170,6 +192,36 @@ var _ = Describe("KIM sFnSelectShootProcessing", func() {
 				MatchNextFnState: BeNil(),
 			},
 		),
+		Entry(
+			"RuntimeCR Ready + Shoot quiet + drift check due, route to sFnDetectShootDrift",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(), withShootDriftDetectionInterval(time.Hour)),
+			&systemState{instance: *inputRtReadyDriftCheckDue, shoot: &testShootQuiet},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnDetectShootDrift"),
+			},
+		),
+		Entry(
+			"RuntimeCR Ready + Shoot quiet + drift check not due, stop() with requeue",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects(), withShootDriftDetectionInterval(time.Hour)),
+			&systemState{instance: *inputRtReadyDriftChecked, shoot: &testShootQuiet},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+			},
+		),
+		Entry(
+			"RuntimeCR Ready + Shoot hibernated, route to sFnWaitForShootHibernation",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtReady, shoot: testShootHibernated},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnWaitForShootHibernation"),
+			},
+		),
 		Entry(
 			"RuntimeCR Failed + Shoot quiet -> stop() (no-storm guard preserved)",
 			testCtx,
@@ -2,7 +2,6 @@ package fsm
 
 import (
 	"context"
-	"fmt"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	"github.com/kyma-project/infrastructure-manager/internal/registrycache"
@@ -44,7 +43,7 @@ func sFnSyncRegistryCacheGardenSecrets(ctx context.Context, m *fsm, s *systemSta
 		secretSyncer := registrycache.NewGardenSecretSyncer(
 			m.GardenClient,
 			runtimeClient, registrycache.DefaultGardenSecretNameGenerator,
-			fmt.Sprintf("garden-%s", m.ConverterConfig.Gardener.ProjectName),
+			gardenerNamespace(m, s),
 			s.instance.Name)
 
 		if registrycache.HasCachesWithSecrets(s.instance.Spec.Caching) {
@@ -141,7 +141,6 @@ func TestFnSyncRegistryCacheGardenSecrets_RuntimeClientGetterFails(t *testing.T)
 	testFSM := must(newFakeFSM,
 		withMockedMetrics(),
 		withTestFinalizer,
-		withShootNamespace("garden-"),
 		withFailedRuntimeK8sClient(getterErr, testScheme),
 		withFakeEventRecorder(1),
 		withDefaultReconcileDuration(),
@@ -2,8 +2,10 @@ package fsm
 
 import (
 	"context"
+	"time"
 
 	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/internal/log_level"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -11,12 +13,18 @@ import (
 
 // to save the runtime status at the begining of the reconciliation
 func sFnTakeSnapshot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	// the notifications which failed to be published in an earlier reconciliation are published first to keep their order
+	if err := publishPendingNotifications(ctx, m, s); err != nil {
+		m.log.Error(err, "Failed to publish pending notifications")
+		return nil, nil, err
+	}
+
 	s.saveRuntimeStatus()
 
 	var shoot gardener_api.Shoot
-	err := m.GardenClient.Get(ctx, types.NamespacedName{
+	err := getShoot(ctx, m, types.NamespacedName{
 		Name:      s.instance.Spec.Shoot.Name,
-		Namespace: m.ShootNamesapace,
+		Namespace: gardenerNamespace(m, s),
 	}, &shoot)
 
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -30,3 +38,35 @@ func sFnTakeSnapshot(ctx context.Context, m *fsm, s *systemState) (stateFn, *ctr
 
 	return switchState(sFnInitialize)
 }
+
+// getShoot reads the Shoot from the cache of the Shoot watch when the cache serves its namespace. The Shoot is read
+// from the Gardener cluster when the cache doesn't know it, so that a Shoot which was just created isn't created again,
+// and when the namespace isn't watched, for example, of a Gardener project added after the start.
+func getShoot(ctx context.Context, m *fsm, key types.NamespacedName, shoot *gardener_api.Shoot) error {
+	if !watchesShoots(m, key.Namespace) {
+		return m.GardenClient.Get(ctx, key, shoot)
+	}
+
+	err := m.ShootCache.Get(ctx, key, shoot)
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		m.log.V(log_level.DEBUG).Info("Failed to get Gardener shoot from the cache, reading it from the Gardener cluster", "error", err)
+	}
+	return m.GardenClient.Get(ctx, key, shoot)
+}
+
+// shootRequeueDuration returns the delay of the next check of the Shoot operation of the Runtime. A watched Shoot
+// triggers the reconciliation itself, so it is checked only in the resync period as a safety net for missed events.
+func shootRequeueDuration(m *fsm, s *systemState, pollingDuration time.Duration) time.Duration {
+	if m.ShootWatchResyncPeriod > 0 && watchesShoots(m, gardenerNamespace(m, s)) {
+		return m.ShootWatchResyncPeriod
+	}
+	return pollingDuration
+}
+
+func watchesShoots(m *fsm, namespace string) bool {
+	return m.ShootCache != nil && m.ShootCache.Watches(namespace)
+}
@@ -0,0 +1,189 @@
+package fsm
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	fsm_testing "github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm/testing"
+	. "github.com/onsi/gomega" //nolint:revive
+	api "k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFSMTakeSnapshot(t *testing.T) {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	testScheme := api.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	util.Must(gardener.AddToScheme(testScheme))
+
+	RegisterTestingT(t)
+
+	t.Run("should read the shoot from the shoot cache", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+
+		cachedShoot := fsm_testing.TestShootForPatch()
+		cachedShoot.Namespace = gardenerNamespace(testFsm, &systemState{instance: *inputRuntime})
+		cachedShoot.Status.LastOperation.State = gardener.LastOperationStateProcessing
+		testFsm.ShootCache = fakeShootCache{
+			Reader:     fake.NewClientBuilder().WithScheme(testScheme).WithObjects(cachedShoot).Build(),
+			namespaces: []string{cachedShoot.Namespace},
+		}
+
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := sFnTakeSnapshot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnInitialize"))
+		Expect(systemState.shoot).ToNot(BeNil())
+		Expect(systemState.shoot.Status.LastOperation.State).To(Equal(gardener.LastOperationStateProcessing))
+	})
+
+	t.Run("should read the shoot from Gardener when the shoot cache doesn't know it yet", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		shoot := fsm_testing.TestShootForPatch()
+		shoot.Namespace = gardenerNamespace(testFsm, &systemState{instance: *inputRuntime})
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+
+		testFsm.ShootCache = fakeShootCache{
+			Reader:     fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			namespaces: []string{shoot.Namespace},
+		}
+
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := sFnTakeSnapshot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnInitialize"))
+		Expect(systemState.shoot).ToNot(BeNil())
+		Expect(systemState.shoot.Name).To(Equal(shoot.Name))
+	})
+
+	t.Run("should read the shoot from Gardener when the shoot cache doesn't watch its namespace", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+
+		shoot := fsm_testing.TestShootForPatch()
+		shoot.Namespace = gardenerNamespace(testFsm, &systemState{instance: *inputRuntime})
+		Expect(testFsm.GardenClient.Create(testCtx, shoot)).To(Succeed())
+
+		cachedShoot := shoot.DeepCopy()
+		cachedShoot.ResourceVersion = ""
+		cachedShoot.Status.LastOperation.State = gardener.LastOperationStateProcessing
+		testFsm.ShootCache = fakeShootCache{
+			Reader:     fake.NewClientBuilder().WithScheme(testScheme).WithObjects(cachedShoot).Build(),
+			namespaces: []string{"garden-other"},
+		}
+
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := sFnTakeSnapshot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnInitialize"))
+		Expect(systemState.shoot).ToNot(BeNil())
+		Expect(systemState.shoot.Status.LastOperation.State).To(Equal(shoot.Status.LastOperation.State))
+	})
+
+	t.Run("should continue without shoot when it doesn't exist", func(t *testing.T) {
+		// given
+		inputRuntime := makeInputRuntimeWithAnnotation(nil)
+		testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+		testFsm.ShootCache = fakeShootCache{
+			Reader:     fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			namespaces: []string{gardenerNamespace(testFsm, &systemState{instance: *inputRuntime})},
+		}
+
+		systemState := &systemState{instance: *inputRuntime}
+
+		// when
+		sFn, _, err := sFnTakeSnapshot(testCtx, testFsm, systemState)
+
+		// then
+		Expect(err).To(BeNil())
+		Expect(sFn).To(haveName("sFnInitialize"))
+		Expect(systemState.shoot).To(BeNil())
+	})
+}
+
+func TestShootRequeueDuration(t *testing.T) {
+	RegisterTestingT(t)
+
+	testScheme := api.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+
+	inputRuntime := makeInputRuntimeWithAnnotation(nil)
+
+	for _, tc := range []struct {
+		name         string
+		watched      bool
+		resyncPeriod time.Duration
+		expected     time.Duration
+	}{
+		{
+			name:         "should use the resync period when the shoot is watched",
+			watched:      true,
+			resyncPeriod: 10 * time.Minute,
+			expected:     10 * time.Minute,
+		},
+		{
+			name:         "should poll the shoot when its namespace isn't watched",
+			resyncPeriod: 10 * time.Minute,
+			expected:     time.Minute,
+		},
+		{
+			name:     "should poll the shoot when the shoot watch is disabled",
+			expected: time.Minute,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			testFsm := setupFakeFSMForTest(testScheme, inputRuntime)
+			systemState := &systemState{instance: *inputRuntime}
+
+			if tc.resyncPeriod > 0 {
+				testFsm.ShootWatchResyncPeriod = tc.resyncPeriod
+				shootCache := fakeShootCache{namespaces: []string{"garden-other"}}
+				if tc.watched {
+					shootCache.namespaces = append(shootCache.namespaces, gardenerNamespace(testFsm, systemState))
+				}
+				testFsm.ShootCache = shootCache
+			}
+
+			// when
+			duration := shootRequeueDuration(testFsm, systemState, time.Minute)
+
+			// then
+			Expect(duration).To(Equal(tc.expected))
+		})
+	}
+}
+
+type fakeShootCache struct {
+	client.Reader
+	namespaces []string
+}
+
+func (c fakeShootCache) Watches(namespace string) bool {
+	return slices.Contains(c.namespaces, namespace)
+}
@@ -0,0 +1,66 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var errTracingTest = errors.New("test error")
+
+func sFnTracingTestFirst(_ context.Context, _ *fsm, _ *systemState) (stateFn, *ctrl.Result, error) {
+	return switchState(sFnTracingTestFailing)
+}
+
+func sFnTracingTestFailing(_ context.Context, _ *fsm, _ *systemState) (stateFn, *ctrl.Result, error) {
+	return nil, nil, errTracingTest
+}
+
+func TestFSMRunTracing(t *testing.T) {
+	// given
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	testFsm, err := newFakeFSM(withFn(sFnTracingTestFirst), withMockedMetrics())
+	require.NoError(t, err)
+
+	runtime := imv1.Runtime{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-runtime",
+		Namespace: "kcp-system",
+		Labels:    map[string]string{imv1.LabelKymaRuntimeID: "test-runtime"},
+	}}
+
+	// when
+	_, err = testFsm.Run(context.Background(), runtime)
+
+	// then
+	require.ErrorIs(t, err, errTracingTest)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	first, failing, reconciliation := spans[0], spans[1], spans[2]
+	assert.Equal(t, "Runtime reconciliation", reconciliation.Name)
+	assert.Equal(t, codes.Error, reconciliation.Status.Code)
+
+	assert.Equal(t, "sFnTracingTestFirst", first.Name)
+	assert.Equal(t, reconciliation.SpanContext.SpanID(), first.Parent.SpanID())
+	assert.Equal(t, codes.Unset, first.Status.Code)
+
+	assert.Equal(t, "sFnTracingTestFailing", failing.Name)
+	assert.Equal(t, reconciliation.SpanContext.SpanID(), failing.Parent.SpanID())
+	assert.Equal(t, codes.Error, failing.Status.Code)
+}
@@ -3,6 +3,7 @@ package fsm
 import (
 	"context"
 	"reflect"
+	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -13,6 +14,8 @@ func sFnUpdateStatus(result *ctrl.Result, err error) stateFn {
 			m.Metrics.IncRuntimeFSMStopCounter()
 		}
 
+		recordNotifications(m, s)
+
 		// make sure there is a change in status
 		if reflect.DeepEqual(s.instance.Status, s.snapshot) {
 			return nil, result, err
@@ -29,6 +32,13 @@ func sFnUpdateStatus(result *ctrl.Result, err error) stateFn {
 		}
 
 		m.Metrics.SetRuntimeStates(s.instance)
+		recordStatusMetrics(m, s, time.Now())
+		if publishErr := publishPendingNotifications(ctx, m, s); publishErr != nil {
+			m.log.Error(publishErr, "Failed to publish notifications")
+			if err == nil {
+				err = publishErr
+			}
+		}
 		next := sFnEmmitEventfunc(nil, result, err)
 		return next, nil, nil
 	}
@@ -0,0 +1,43 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// validateWithCloudProfile checks the Shoot against its CloudProfile before it is sent to Gardener.
+// Settings not offered by the CloudProfile fail the Runtime; the current Shoot is nil when the Shoot is being created.
+// Returns nil state function if the Shoot is valid or the validation is disabled.
+func validateWithCloudProfile(ctx context.Context, m *fsm, s *systemState, shoot gardener.Shoot, current *gardener.Shoot) (stateFn, *ctrl.Result, error) {
+	if !m.CloudProfileValidationEnabled {
+		return nil, nil, nil
+	}
+
+	err := m.CloudProfileValidator.Validate(ctx, shoot, current)
+	if err == nil {
+		return nil, nil, nil
+	}
+
+	var validationErr *cloudprofile.ValidationError
+	if errors.As(err, &validationErr) {
+		m.log.Info("Shoot uses settings not offered by the CloudProfile, exiting with no retry", "RuntimeCR", s.instance.Name, "reason", validationErr.Reason, "message", validationErr.Message)
+		m.Metrics.IncRuntimeFSMStopCounter()
+		return updateStateFailedWithErrorAndStop(m, s, imv1.ConditionTypeRuntimeProvisioned, validationErr.Reason, validationErr.Message)
+	}
+
+	m.log.Error(err, "Failed to validate Shoot against CloudProfile, scheduling for retry", "RuntimeCR", s.instance.Name)
+	s.instance.UpdateStatePending(
+		imv1.ConditionTypeRuntimeProvisioned,
+		imv1.ConditionReasonGardenerError,
+		metav1.ConditionFalse,
+		fmt.Sprintf("CloudProfile validation error: %v", err),
+	)
+	return updateStatusAndRequeueAfter(m.GardenerRequeueDuration)
+}
@@ -0,0 +1,109 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/log_level"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sFnWaitForShootHibernation tracks Gardener's hibernation and wake-up operations.
+// A hibernated Shoot has no running nodes and no reachable API server, so the SKR configuration is skipped
+// and the Runtime is kept in Ready state with the Hibernated condition set.
+func sFnWaitForShootHibernation(_ context.Context, m *fsm, s *systemState) (stateFn, *ctrl.Result, error) {
+	// failed hibernation or wake-up operations are handled as any other failed reconciliation
+	if s.shoot.Status.LastOperation.State == gardener.LastOperationStateFailed {
+		return switchState(sFnWaitForShootReconcile)
+	}
+
+	switch {
+	case isShootHibernated(s.shoot):
+		if s.instance.IsStateWithConditionAndStatusSet(imv1.RuntimeStateReady, imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonHibernationCompleted, metav1.ConditionTrue) {
+			m.log.V(log_level.DEBUG).Info("Shoot is hibernated, stopping processing", "RuntimeCR", s.instance.Name, "shoot", s.shoot.Name)
+			return stop()
+		}
+
+		m.log.Info(fmt.Sprintf("Shoot %s is hibernated", s.shoot.Name))
+		meta.RemoveStatusCondition(&s.instance.Status.Conditions, string(imv1.ConditionTypeRuntimeWakingUp))
+		s.instance.UpdateStateReady(
+			imv1.ConditionTypeRuntimeHibernated,
+			imv1.ConditionReasonHibernationCompleted,
+			"Runtime is hibernated")
+		return updateStatusAndStop()
+
+	case isShootHibernating(s.shoot):
+		m.log.V(log_level.DEBUG).Info(fmt.Sprintf("Shoot %s is being hibernated, scheduling for retry", s.shoot.Name))
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeHibernated,
+			imv1.ConditionReasonHibernationInProgress,
+			metav1.ConditionUnknown,
+			"Runtime hibernation in progress")
+		return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootReconcile))
+
+	case isShootWakingUp(s.shoot):
+		m.log.V(log_level.DEBUG).Info(fmt.Sprintf("Shoot %s is waking up, scheduling for retry", s.shoot.Name))
+		s.instance.SetCondition(
+			imv1.ConditionTypeRuntimeHibernated,
+			imv1.ConditionReasonWakeUpInProgress,
+			metav1.ConditionTrue,
+			"Runtime is hibernated and waking up")
+		s.instance.UpdateStatePending(
+			imv1.ConditionTypeRuntimeWakingUp,
+			imv1.ConditionReasonWakeUpInProgress,
+			metav1.ConditionTrue,
+			"Runtime wake-up in progress")
+		return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootReconcile))
+	}
+
+	// The Shoot is awake again. The conditions are persisted with the next status update,
+	// and the SKR is reconfigured the same way as after any other reconciliation.
+	m.log.Info(fmt.Sprintf("Shoot %s woke up from hibernation", s.shoot.Name))
+	s.instance.SetCondition(
+		imv1.ConditionTypeRuntimeHibernated,
+		imv1.ConditionReasonWakeUpCompleted,
+		metav1.ConditionFalse,
+		"Runtime is not hibernated")
+	s.instance.SetCondition(
+		imv1.ConditionTypeRuntimeWakingUp,
+		imv1.ConditionReasonWakeUpCompleted,
+		metav1.ConditionFalse,
+		"Runtime wake-up completed")
+	return switchState(sFnWaitForShootReconcile)
+}
+
+// shouldProcessHibernation returns true when the Shoot is hibernated, is being hibernated or woken up,
+// or when the Runtime still reports a hibernation which has to be cleared.
+func shouldProcessHibernation(runtime *imv1.Runtime, shoot *gardener.Shoot) bool {
+	if isShootHibernationEnabled(shoot) || shoot.Status.IsHibernated {
+		return true
+	}
+
+	hibernatedCondition := meta.FindStatusCondition(runtime.Status.Conditions, string(imv1.ConditionTypeRuntimeHibernated))
+	if hibernatedCondition != nil && hibernatedCondition.Status != metav1.ConditionFalse {
+		return true
+	}
+
+	return meta.IsStatusConditionTrue(runtime.Status.Conditions, string(imv1.ConditionTypeRuntimeWakingUp))
+}
+
+func isShootHibernationEnabled(shoot *gardener.Shoot) bool {
+	return shoot.Spec.Hibernation != nil && shoot.Spec.Hibernation.Enabled != nil && *shoot.Spec.Hibernation.Enabled
+}
+
+func isShootHibernated(shoot *gardener.Shoot) bool {
+	return isShootHibernationEnabled(shoot) && shoot.Status.IsHibernated &&
+		shoot.Status.LastOperation.State == gardener.LastOperationStateSucceeded
+}
+
+func isShootHibernating(shoot *gardener.Shoot) bool {
+	return isShootHibernationEnabled(shoot) && !isShootHibernated(shoot)
+}
+
+func isShootWakingUp(shoot *gardener.Shoot) bool {
+	return !isShootHibernationEnabled(shoot) && shoot.Status.IsHibernated
+}
@@ -0,0 +1,157 @@
+package fsm
+
+import (
+	"context"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	"github.com/onsi/gomega/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("KIM sFnWaitForShootHibernation", func() {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// GIVEN
+	testScheme := runtime.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+	withTestSchemeAndObjects := func(objs ...client.Object) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			return withFakedK8sClient(testScheme, objs...)(fsm)
+		}
+	}
+
+	fixShoot := func(hibernationEnabled, isHibernated bool, lastOpState gardener.LastOperationState) *gardener.Shoot {
+		return &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot",
+				Namespace: "garden-",
+			},
+			Spec: gardener.ShootSpec{
+				Hibernation: &gardener.Hibernation{Enabled: ptr.To(hibernationEnabled)},
+			},
+			Status: gardener.ShootStatus{
+				IsHibernated: isHibernated,
+				LastOperation: &gardener.LastOperation{
+					State: lastOpState,
+					Type:  gardener.LastOperationTypeReconcile,
+				},
+			},
+		}
+	}
+
+	inputRtPending := makeInputRuntimeWithAnnotation(nil)
+	inputRtPending.Status.State = imv1.RuntimeStatePending
+
+	inputRtHibernated := makeInputRuntimeWithAnnotation(nil)
+	inputRtHibernated.UpdateStateReady(imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonHibernationCompleted, "Runtime is hibernated")
+
+	testFunction := buildTestFunction(sFnWaitForShootHibernation)
+
+	DescribeTable(
+		"transition graph validation for sFnWaitForShootHibernation",
+		testFunction,
+		Entry(
+			"should set Ready state with Hibernated condition when Shoot is hibernated",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtPending, shoot: fixShoot(true, true, gardener.LastOperationStateSucceeded)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStateReady),
+					haveCondition(imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonHibernationCompleted, metav1.ConditionTrue),
+				},
+			},
+		),
+		Entry(
+			"should stop when Runtime already reports hibernation",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtHibernated, shoot: fixShoot(true, true, gardener.LastOperationStateSucceeded)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: BeNil(),
+			},
+		),
+		Entry(
+			"should set Pending state when Shoot is being hibernated",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtHibernated, shoot: fixShoot(true, false, gardener.LastOperationStateProcessing)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStatePending),
+					haveCondition(imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonHibernationInProgress, metav1.ConditionUnknown),
+				},
+			},
+		),
+		Entry(
+			"should set Pending state with WakingUp condition when Shoot is waking up",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtHibernated, shoot: fixShoot(false, true, gardener.LastOperationStateProcessing)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStatePending),
+					haveCondition(imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonWakeUpInProgress, metav1.ConditionTrue),
+					haveCondition(imv1.ConditionTypeRuntimeWakingUp, imv1.ConditionReasonWakeUpInProgress, metav1.ConditionTrue),
+				},
+			},
+		),
+		Entry(
+			"should switch to sFnWaitForShootReconcile when Shoot woke up",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtHibernated, shoot: fixShoot(false, false, gardener.LastOperationStateSucceeded)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnWaitForShootReconcile"),
+				StateMatch: []types.GomegaMatcher{
+					haveCondition(imv1.ConditionTypeRuntimeHibernated, imv1.ConditionReasonWakeUpCompleted, metav1.ConditionFalse),
+					haveCondition(imv1.ConditionTypeRuntimeWakingUp, imv1.ConditionReasonWakeUpCompleted, metav1.ConditionFalse),
+				},
+			},
+		),
+		Entry(
+			"should switch to sFnWaitForShootReconcile when hibernation failed",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withTestSchemeAndObjects()),
+			&systemState{instance: *inputRtPending, shoot: fixShoot(true, false, gardener.LastOperationStateFailed)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnWaitForShootReconcile"),
+			},
+		),
+	)
+})
+
+func haveState(state imv1.State) types.GomegaMatcher {
+	return WithTransform(func(rt *imv1.Runtime) imv1.State {
+		return rt.Status.State
+	}, Equal(state))
+}
+
+func haveCondition(conditionType imv1.RuntimeConditionType, reason imv1.RuntimeConditionReason, status metav1.ConditionStatus) types.GomegaMatcher {
+	return WithTransform(func(rt *imv1.Runtime) *metav1.Condition {
+		return meta.FindStatusCondition(rt.Status.Conditions, string(conditionType))
+	}, And(
+		Not(BeNil()),
+		HaveField("Reason", string(reason)),
+		HaveField("Status", status),
+	))
+}
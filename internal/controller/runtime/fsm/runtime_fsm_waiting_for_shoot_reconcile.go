@@ -3,12 +3,13 @@ package fsm
 import (
 	"context"
 	"fmt"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
-	imgardenerhandler "github.com/kyma-project/infrastructure-manager/pkg/gardener"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -23,34 +24,32 @@ func sFnWaitForShootReconcile(_ context.Context, m *fsm, s *systemState) (stateF
 			metav1.ConditionUnknown,
 			"Shoot update is in progress")
 
-		return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
+		return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootReconcile))
 
 	case gardener.LastOperationStateFailed:
-		lastErrors := s.shoot.Status.LastErrors
-		reason := imgardenerhandler.ToErrReason(lastErrors...)
+		classification := classifyLastErrors(m, s.shoot.Status.LastErrors)
 
-		if imgardenerhandler.IsRetryable(lastErrors) {
-			m.log.Info(fmt.Sprintf("Retryable gardener errors during cluster provisioning for Shoot %s, reason: %s, scheduling for retry", s.shoot.Name, reason))
+		if classification.Retryable {
+			m.log.Info(fmt.Sprintf("Retryable gardener errors during cluster reconcile for Shoot %s, reason: %s, codes: %s, scheduling for retry", s.shoot.Name, classification.Reason, classification.Codes))
 			s.instance.UpdateStatePending(
 				imv1.ConditionTypeRuntimeProvisioned,
-				imv1.ConditionReasonShootCreationPending,
+				classification.Reason,
 				metav1.ConditionUnknown,
-				"Retryable gardener errors during cluster reconcile")
-			return updateStatusAndRequeueAfter(m.RequeueDurationShootReconcile)
+				classification.Message)
+			return updateStatusAndRequeueAfter(classification.RequeueAfter(time.Now(), shootRequeueDuration(m, s, m.RequeueDurationShootReconcile)))
 		}
 
-		msg := fmt.Sprintf("error during cluster processing: reconcilation failed for shoot %s, reason: %s, exiting with no retry", s.shoot.Name, reason)
+		msg := fmt.Sprintf("error during cluster processing: reconcilation failed for shoot %s, reason: %s, codes: %s, exiting with no retry", s.shoot.Name, classification.Reason, classification.Codes)
 		m.log.Info(msg)
 
-		s.instance.UpdateStateFailed(
-			imv1.ConditionTypeRuntimeProvisioned,
-			imv1.ConditionReasonProcessingErr,
-			string(reason),
-		)
 		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatusAndStop()
+		return updateStateFailedWithClassificationAndStop(m, s, imv1.ConditionTypeRuntimeProvisioned, classification)
 
 	case gardener.LastOperationStateSucceeded:
+		if isShootHibernated(s.shoot) {
+			return switchState(sFnWaitForShootHibernation)
+		}
+
 		m.log.Info(fmt.Sprintf("Shoot %s successfully updated, moving to processing", s.shoot.Name))
 		return ensureStatusConditionIsSetAndContinue(
 			m.StatusRequeueDelay,
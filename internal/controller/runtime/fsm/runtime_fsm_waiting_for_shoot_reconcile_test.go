@@ -26,7 +26,11 @@ var _ = Describe("sFnWaitForShootReconcile re-enqueue delay", Label("issue-1413"
 		m := &mocks.Metrics{}
 		m.On("SetRuntimeStates", mock.Anything).Return()
 		m.On("CleanUpRuntimeGauge", mock.Anything, mock.Anything).Return()
+		m.On("CleanUpShootDrift", mock.Anything, mock.Anything).Return()
 		m.On("IncRuntimeFSMStopCounter").Return()
+		m.On("ObserveRuntimeFSMStateDuration", mock.Anything, mock.Anything).Return()
+		m.On("IncRuntimeFSMTransitionCounter", mock.Anything, mock.Anything).Return()
+		m.On("ObserveRuntimeOperationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
 		return withMetrics(m)
 	}
 
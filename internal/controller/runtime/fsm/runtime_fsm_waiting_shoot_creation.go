@@ -9,7 +9,6 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
-	imgardenerhandler "github.com/kyma-project/infrastructure-manager/pkg/gardener"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -36,11 +35,11 @@ func sFnWaitForShootCreation(_ context.Context, m *fsm, s *systemState) (stateFn
 	case gardener.LastOperationStateProcessing, gardener.LastOperationStatePending, gardener.LastOperationStateAborted, gardener.LastOperationStateError:
 		if stateNoMatchingSeeds(s.shoot) {
 			m.log.Info(fmt.Sprintf("Shoot %s has no matching seeds, setting error state", s.shoot.Name))
-			s.instance.UpdateStateFailed(
+			return updateStateFailedWithErrorAndStop(
+				m, s,
 				imv1.ConditionTypeRuntimeProvisioned,
 				imv1.ConditionReasonCreationError,
 				"Shoot creation failed, no matching seeds")
-			return updateStatusAndStop()
 		}
 
 		m.log.V(log_level.DEBUG).Info(fmt.Sprintf("Shoot %s is in %s state, scheduling for retry", s.shoot.Name, s.shoot.Status.LastOperation.State))
@@ -51,34 +50,32 @@ func sFnWaitForShootCreation(_ context.Context, m *fsm, s *systemState) (stateFn
 			metav1.ConditionUnknown,
 			"Shoot creation in progress")
 
-		return updateStatusAndRequeueAfter(m.RequeueDurationShootCreate)
+		return updateStatusAndRequeueAfter(shootRequeueDuration(m, s, m.RequeueDurationShootCreate))
 
 	case gardener.LastOperationStateFailed:
-		lastErrors := s.shoot.Status.LastErrors
-		reason := imgardenerhandler.ToErrReason(lastErrors...)
+		classification := classifyLastErrors(m, s.shoot.Status.LastErrors)
 
-		if imgardenerhandler.IsRetryable(lastErrors) {
-			m.log.Info(fmt.Sprintf("Retryable gardener errors during cluster provisioning for Shoot %s, reason: %s, scheduling for retry", s.shoot.Name, reason))
+		if classification.Retryable {
+			m.log.Info(fmt.Sprintf("Retryable gardener errors during cluster provisioning for Shoot %s, reason: %s, codes: %s, scheduling for retry", s.shoot.Name, classification.Reason, classification.Codes))
 			s.instance.UpdateStatePending(
 				imv1.ConditionTypeRuntimeProvisioned,
-				imv1.ConditionReasonShootCreationPending,
+				classification.Reason,
 				metav1.ConditionUnknown,
-				"Retryable gardener errors during cluster provisioning")
-			return updateStatusAndRequeueAfter(m.RequeueDurationShootCreate)
+				classification.Message)
+			return updateStatusAndRequeueAfter(classification.RequeueAfter(time.Now(), shootRequeueDuration(m, s, m.RequeueDurationShootCreate)))
 		}
 
 		msg := fmt.Sprintf("Provisioning failed for shoot: %s ! Last state: %s, Description: %s", s.shoot.Name, s.shoot.Status.LastOperation.State, s.shoot.Status.LastOperation.Description)
-		m.log.Info(msg)
-
-		s.instance.UpdateStateFailed(
-			imv1.ConditionTypeRuntimeProvisioned,
-			imv1.ConditionReasonCreationError,
-			"Shoot creation failed")
+		m.log.Info(msg, "reason", classification.Reason, "codes", classification.Codes)
 
 		m.Metrics.IncRuntimeFSMStopCounter()
-		return updateStatusAndStop()
+		return updateStateFailedWithClassificationAndStop(m, s, imv1.ConditionTypeRuntimeProvisioned, classification)
 
 	case gardener.LastOperationStateSucceeded:
+		if isShootHibernated(s.shoot) {
+			return switchState(sFnWaitForShootHibernation)
+		}
+
 		m.log.Info(fmt.Sprintf("Shoot %s successfully created", s.shoot.Name))
 		return ensureStatusConditionIsSetAndContinue(
 			m.StatusRequeueDelay,
@@ -0,0 +1,97 @@
+package fsm
+
+import (
+	"context"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	util "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+var _ = Describe("KIM sFnWaitForShootCreation", func() {
+	testCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// GIVEN
+	testScheme := runtime.NewScheme()
+	util.Must(imv1.AddToScheme(testScheme))
+
+	fixFailedShoot := func(codes ...gardener.ErrorCode) *gardener.Shoot {
+		return &gardener.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot",
+				Namespace: "garden-",
+			},
+			Status: gardener.ShootStatus{
+				LastOperation: &gardener.LastOperation{
+					State: gardener.LastOperationStateFailed,
+					Type:  gardener.LastOperationTypeCreate,
+				},
+				LastErrors: []gardener.LastError{
+					{
+						Description: "infrastructure reconciliation failed",
+						Codes:       codes,
+					},
+				},
+			},
+		}
+	}
+
+	inputRtPending := makeInputRuntimeWithAnnotation(nil)
+	inputRtPending.Status.State = imv1.RuntimeStatePending
+
+	testFunction := buildTestFunction(sFnWaitForShootCreation)
+
+	DescribeTable(
+		"transition graph validation for sFnWaitForShootCreation with a failed Shoot",
+		testFunction,
+		Entry(
+			"should set Pending state with the catalog reason for retryable errors",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withFakedK8sClient(testScheme)),
+			&systemState{instance: *inputRtPending, shoot: fixFailedShoot(gardener.ErrorInfraQuotaExceeded)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStatePending),
+					haveCondition(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonInfrastructureQuotaExceeded, metav1.ConditionUnknown),
+				},
+			},
+		),
+		Entry(
+			"should set Failed state with the catalog reason for non-retryable errors",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withFakedK8sClient(testScheme), withMockedMetrics()),
+			&systemState{instance: *inputRtPending, shoot: fixFailedShoot(gardener.ErrorInfraUnauthorized, gardener.ErrorInfraQuotaExceeded)},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStateFailed),
+					haveCondition(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonInfrastructureUnauthorized, metav1.ConditionFalse),
+				},
+			},
+		),
+		Entry(
+			"should set Pending state with the Gardener error reason for unknown errors",
+			testCtx,
+			must(newFakeFSM, withTestFinalizer, withFakedK8sClient(testScheme)),
+			&systemState{instance: *inputRtPending, shoot: fixFailedShoot("ERR_NEW")},
+			testOpts{
+				MatchExpectedErr: BeNil(),
+				MatchNextFnState: haveName("sFnUpdateStatus"),
+				StateMatch: []types.GomegaMatcher{
+					haveState(imv1.RuntimeStatePending),
+					haveCondition(imv1.ConditionTypeRuntimeProvisioned, imv1.ConditionReasonGardenerError, metav1.ConditionUnknown),
+				},
+			},
+		),
+	)
+})
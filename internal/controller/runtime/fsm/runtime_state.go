@@ -3,6 +3,7 @@ package fsm
 import (
 	gardener_api "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // the state of controlled system (k8s cluster)
@@ -24,5 +25,19 @@ func exposeShootStatusInfo(s *systemState) {
 	if s.shoot != nil {
 		s.instance.Status.ShootLastOperation = s.shoot.Status.LastOperation
 		s.instance.Status.ShootLastErrors = s.shoot.Status.LastErrors
+		setShootCreationTime(&s.instance, *s.shoot)
 	}
 }
+
+// setShootCreationTime records that the Shoot of the runtime was created, it is also set for the Shoots created before it was recorded
+func setShootCreationTime(runtime *imv1.Runtime, shoot gardener_api.Shoot) {
+	if runtime.Status.ShootCreationTime != nil {
+		return
+	}
+
+	creationTime := shoot.CreationTimestamp
+	if creationTime.IsZero() {
+		creationTime = metav1.Now()
+	}
+	runtime.Status.ShootCreationTime = &creationTime
+}
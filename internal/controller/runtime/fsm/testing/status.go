@@ -18,6 +18,7 @@ func PendingStatusShootPatched() imv1.RuntimeStatus {
 		Message: "Shoot is pending for update after patch",
 	}
 	meta.SetStatusCondition(&result.Conditions, condition)
+	result.MachineImage = &imv1.RuntimeMachineImage{Name: "garden-linux", Version: "1.19.8"}
 	return result
 }
 
@@ -33,6 +34,7 @@ func PendingStatusShootNoChanged() imv1.RuntimeStatus {
 		Message: "Shoot patched without changes",
 	}
 	meta.SetStatusCondition(&result.Conditions, condition)
+	result.MachineImage = &imv1.RuntimeMachineImage{Name: "garden-linux", Version: "1.19.8"}
 	return result
 }
 
@@ -57,20 +57,19 @@ var (
 		}
 	}
 
-	withShootNamespace = func(ns string) fakeFSMOpt {
-		return func(fsm *fsm) error {
-			fsm.ShootNamesapace = ns
-			return nil
-		}
-	}
-
 	withTestFinalizer = withFinalizer("test-me-plz")
 
 	withMockedMetrics = func() fakeFSMOpt {
 		m := &metrics_mocks.Metrics{}
 		m.On("SetRuntimeStates", mock.Anything).Return()
 		m.On("CleanUpRuntimeGauge", mock.Anything, mock.Anything).Return()
+		m.On("CleanUpShootDrift", mock.Anything, mock.Anything).Return()
 		m.On("IncRuntimeFSMStopCounter").Return()
+		m.On("SetShootDrift", mock.Anything, mock.Anything).Return()
+		m.On("ObserveRuntimeOperationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
+		m.On("IncRuntimeFailureCounter", mock.Anything, mock.Anything).Return()
+		m.On("ObserveRuntimeFSMStateDuration", mock.Anything, mock.Anything).Return()
+		m.On("IncRuntimeFSMTransitionCounter", mock.Anything, mock.Anything).Return()
 		return withMetrics(m)
 	}
 
@@ -102,6 +101,27 @@ var (
 		}
 	}
 
+	withDeletionGracePeriod = func(gracePeriod time.Duration) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			fsm.DeletionGracePeriod = gracePeriod
+			return nil
+		}
+	}
+
+	withShootDriftDetectionInterval = func(interval time.Duration) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			fsm.ShootDriftDetectionInterval = interval
+			return nil
+		}
+	}
+
+	withGardenerConfig = func(gardenerConfig config.GardenerConfig) fakeFSMOpt {
+		return func(fsm *fsm) error {
+			fsm.ConverterConfig.Gardener = gardenerConfig
+			return nil
+		}
+	}
+
 	withMetrics = func(m metrics.Metrics) fakeFSMOpt {
 		return func(fsm *fsm) error {
 			fsm.Metrics = m
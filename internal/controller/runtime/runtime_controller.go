@@ -21,16 +21,26 @@ import (
 	"github.com/kyma-project/infrastructure-manager/internal/rtbootstrapper"
 	"sync/atomic"
 
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/go-logr/logr"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/internal/controller/runtime/fsm"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/sharding"
 	"github.com/kyma-project/infrastructure-manager/internal/log_level"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/landscape"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shootcache"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // RuntimeReconciler reconciles a Runtime object
@@ -38,20 +48,23 @@ import (
 type RuntimeReconciler struct {
 	KcpClient client.Client
 	Scheme    *runtime.Scheme
-	// GardenClient is the client for the Garden cluster, used to manage shoots (please see the docs: https://github.com/gardener/gardener/blob/master/docs/concepts/architecture.md).
-	GardenClient                 client.Client
+	// Landscapes provide the clients for the Garden clusters, used to manage shoots (please see the docs: https://github.com/gardener/gardener/blob/master/docs/concepts/architecture.md).
+	Landscapes                   *landscape.Registry
 	Log                          logr.Logger
 	Cfg                          fsm.RCCfg
 	EventRecorder                record.EventRecorder
 	RequestID                    atomic.Uint64
 	RuntimeClientGetter          fsm.RuntimeClientGetter
 	RuntimeBootstrapperInstaller *rtbootstrapper.Installer
+	// Shards limits the reconciliation to the Runtime CRs of the shards owned by the replica, all Runtime CRs are reconciled when it is nil
+	Shards *sharding.Coordinator
 }
 
 //+kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=runtimes,verbs=get;list;watch;create;update;patch,namespace=kcp-system
 //+kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=runtimes/status,verbs=get;list;delete;create;update;patch,namespace=kcp-system
 //+kubebuilder:rbac:groups=infrastructuremanager.kyma-project.io,resources=runtimes/finalizers,verbs=get;list;delete;create;update;patch,namespace=kcp-system
 //+kubebuilder:rbac:groups=auditlogmanager.kyma-project.io,resources=auditlogs,verbs=get;list;watch;update;patch,namespace=kcp-system
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update,namespace=kcp-system
 //+kubebuilder:rbac:groups=auditlogmanager.kyma-project.io,resources=auditlogs/status,verbs=get;list;watch,namespace=kcp-system
 
 func (r *RuntimeReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
@@ -64,32 +77,67 @@ func (r *RuntimeReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		}, client.IgnoreNotFound(err)
 	}
 
+	if r.Shards.Skips(&runtime) {
+		r.Log.V(log_level.DEBUG).Info("Skipping Runtime of a shard owned by another replica", "Name", runtime.Name)
+		return ctrl.Result{}, nil
+	}
+
 	runtimeID, ok := runtime.Labels["kyma-project.io/runtime-id"]
 	if !ok {
 		runtimeID = runtime.Name
 	}
 
-	log := r.Log.WithValues("runtimeID", runtimeID, "shootName", runtime.Spec.Shoot.Name, "requestID", r.RequestID.Add(1))
+	landscapeName := landscape.NameFromLabels(runtime.Labels)
+	log := r.Log.WithValues("runtimeID", runtimeID, "shootName", runtime.Spec.Shoot.Name, "landscape", landscapeName, "requestID", r.RequestID.Add(1))
+
+	gardenerLandscape, err := r.Landscapes.Get(landscapeName)
+	if err != nil {
+		log.Error(err, "Failed to resolve the Gardener landscape of the Runtime")
+		return ctrl.Result{}, err
+	}
+
 	log.Info("Reconciling Runtime", "Name", runtime.Name, "Namespace", runtime.Namespace)
 
-	stateFSM := fsm.NewFsm(
-		log,
-		r.Cfg,
-		fsm.K8s{
-			KcpClient:           r.KcpClient,
-			GardenClient:        r.GardenClient,
-			EventRecorder:       r.EventRecorder,
-			RuntimeClientGetter: r.RuntimeClientGetter,
-		})
+	k8s := fsm.K8s{
+		KcpClient:           r.KcpClient,
+		GardenClient:        gardenerLandscape.Client,
+		EventRecorder:       r.EventRecorder,
+		RuntimeClientGetter: r.RuntimeClientGetter,
+	}
+	if gardenerLandscape.ShootCache != nil {
+		k8s.ShootCache = gardenerLandscape.ShootCache
+	}
+
+	cfg := r.cfgForLandscape(gardenerLandscape)
+	cfg.ConfigRevision = runtime.Annotations[reconciler.ConverterConfigRevisionAnnotation]
+
+	stateFSM := fsm.NewFsm(log, cfg, k8s)
 
 	return stateFSM.Run(ctx, runtime)
 }
 
-func NewRuntimeReconciler(mgr ctrl.Manager, gardenClient client.Client, runtimeClientGetter fsm.RuntimeClientGetter, RuntimeBootstrapperInstaller *rtbootstrapper.Installer, logger logr.Logger, cfg fsm.RCCfg) *RuntimeReconciler {
+// cfgForLandscape returns the configuration of the Runtime controller for the runtimes of the Gardener landscape
+func (r *RuntimeReconciler) cfgForLandscape(gardenerLandscape landscape.Landscape) fsm.RCCfg {
+	cfg := r.Cfg
+	if gardenerLandscape.IsDefault() {
+		return cfg
+	}
+
+	cfg.LandscapeProjectName = gardenerLandscape.ProjectName
+	if gardenerLandscape.CloudProfileReader != nil {
+		cfg.CloudProfileReader = gardenerLandscape.CloudProfileReader
+		if cfg.CloudProfileValidator != nil {
+			cfg.CloudProfileValidator = cloudprofile.NewValidator(gardenerLandscape.CloudProfileReader)
+		}
+	}
+	return cfg
+}
+
+func NewRuntimeReconciler(mgr ctrl.Manager, landscapes *landscape.Registry, runtimeClientGetter fsm.RuntimeClientGetter, RuntimeBootstrapperInstaller *rtbootstrapper.Installer, logger logr.Logger, cfg fsm.RCCfg) *RuntimeReconciler {
 	return &RuntimeReconciler{
-		KcpClient:    mgr.GetClient(),
-		Scheme:       mgr.GetScheme(),
-		GardenClient: gardenClient,
+		KcpClient:  mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Landscapes: landscapes,
 		//nolint:staticcheck // SA1019: GetEventRecorderFor is used, which is the correct API for this version
 		EventRecorder:                mgr.GetEventRecorderFor("runtime-controller"),
 		Log:                          logger,
@@ -101,13 +149,62 @@ func NewRuntimeReconciler(mgr ctrl.Manager, gardenClient client.Client, runtimeC
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RuntimeReconciler) SetupWithManager(mgr ctrl.Manager, numberOfWorkers int) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&imv1.Runtime{}).
-		WithOptions(controller.Options{MaxConcurrentReconciles: numberOfWorkers}).
+	options := controller.Options{MaxConcurrentReconciles: numberOfWorkers}
+	var forOpts []builder.ForOption
+	var shardSource source.Source
+	if r.Shards != nil {
+		var shardPredicate predicate.Predicate
+		shardPredicate, shardSource = r.Shards.Setup(&options, func() client.ObjectList { return &imv1.RuntimeList{} })
+		forOpts = append(forOpts, builder.WithPredicates(shardPredicate))
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&imv1.Runtime{}, forOpts...).
+		WithOptions(options).
 		WithEventFilter(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.LabelChangedPredicate{},
 			predicate.AnnotationChangedPredicate{},
-		)).
-		Complete(r)
+		))
+
+	if shardSource != nil {
+		b = b.WatchesRawSource(shardSource)
+	}
+
+	// the progress of the Shoots triggers the reconciliation, the requeue durations of the FSM are only a safety net
+	for _, name := range r.Landscapes.Names() {
+		gardenerLandscape, err := r.Landscapes.Get(name)
+		if err != nil {
+			return err
+		}
+		if gardenerLandscape.ShootCache == nil {
+			continue
+		}
+		b = b.WatchesRawSource(source.Kind(
+			gardenerLandscape.ShootCache,
+			&gardener.Shoot{},
+			handler.TypedEnqueueRequestsFromMapFunc(r.runtimesForShoot),
+			shootcache.ProgressPredicate(),
+		))
+	}
+	return b.Complete(r)
+}
+
+// runtimesForShoot maps a Shoot to the Runtime CR with the runtime ID annotated on the Shoot
+func (r *RuntimeReconciler) runtimesForShoot(ctx context.Context, shoot *gardener.Shoot) []reconcile.Request {
+	var runtimes imv1.RuntimeList
+	if err := r.KcpClient.List(ctx, &runtimes, client.MatchingLabels{imv1.LabelKymaRuntimeID: shootcache.RuntimeID(shoot)}); err != nil {
+		r.Log.Error(err, "Failed to list the Runtime CRs of a Shoot", "shoot", shoot.Name, "namespace", shoot.Namespace)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(runtimes.Items))
+	for _, runtime := range runtimes.Items {
+		if runtime.Spec.Shoot.Name != shoot.Name {
+			continue
+		}
+		r.Log.V(log_level.DEBUG).Info("Shoot changed, enqueuing Runtime", "Name", runtime.Name, "shoot", shoot.Name)
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&runtime)})
+	}
+	return requests
 }
@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRuntimesForShoot(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+
+	fixRuntime := func(name, runtimeID, shootName string) *imv1.Runtime {
+		return &imv1.Runtime{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "kcp-system",
+				Labels:    map[string]string{imv1.LabelKymaRuntimeID: runtimeID},
+			},
+			Spec: imv1.RuntimeSpec{Shoot: imv1.RuntimeShoot{Name: shootName}},
+		}
+	}
+
+	reconciler := &RuntimeReconciler{
+		KcpClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			fixRuntime("runtime-a", "runtime-a", "shoot-a"),
+			fixRuntime("runtime-b", "runtime-b", "shoot-b"),
+		).Build(),
+		Log: logr.Discard(),
+	}
+
+	fixShoot := func(name, runtimeID string) *gardener.Shoot {
+		return &gardener.Shoot{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "garden-test",
+			Annotations: map[string]string{extender.ShootRuntimeIDAnnotation: runtimeID},
+		}}
+	}
+
+	t.Run("should enqueue the Runtime of the Shoot", func(t *testing.T) {
+		// when
+		requests := reconciler.runtimesForShoot(context.Background(), fixShoot("shoot-a", "runtime-a"))
+
+		// then
+		require.Len(t, requests, 1)
+		assert.Equal(t, types.NamespacedName{Namespace: "kcp-system", Name: "runtime-a"}, requests[0].NamespacedName)
+	})
+
+	t.Run("should not enqueue a Runtime with another Shoot", func(t *testing.T) {
+		// when
+		requests := reconciler.runtimesForShoot(context.Background(), fixShoot("shoot-c", "runtime-b"))
+
+		// then
+		assert.Empty(t, requests)
+	})
+}
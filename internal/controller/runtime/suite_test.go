@@ -33,6 +33,7 @@ import (
 	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
 	auditlogmocks "github.com/kyma-project/infrastructure-manager/pkg/auditlog/mocks"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/landscape"
 	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/extensions"
 	. "github.com/onsi/ginkgo/v2" //nolint:revive
@@ -122,6 +123,11 @@ var _ = BeforeSuite(func() {
 	mm.On("SetRuntimeStates", mock.Anything).Return()
 	mm.On("IncRuntimeFSMStopCounter").Return()
 	mm.On("CleanUpRuntimeGauge", mock.Anything, mock.Anything).Return()
+	mm.On("CleanUpShootDrift", mock.Anything, mock.Anything).Return()
+	mm.On("ObserveRuntimeFSMStateDuration", mock.Anything, mock.Anything).Return()
+	mm.On("IncRuntimeFSMTransitionCounter", mock.Anything, mock.Anything).Return()
+	mm.On("ObserveRuntimeOperationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
+	mm.On("IncRuntimeFailureCounter", mock.Anything, mock.Anything).Return()
 
 	runtimeClientScheme := runtime.NewScheme()
 	_ = rbacv1.AddToScheme(runtimeClientScheme)
@@ -168,7 +174,7 @@ var _ = BeforeSuite(func() {
 		StatusRequeueDelay:            1 * time.Second,
 	}
 
-	runtimeReconciler = NewRuntimeReconciler(mgr, gardenerTestClient, runtimeClientGetterMock, nil, logger, fsmCfg)
+	runtimeReconciler = NewRuntimeReconciler(mgr, newTestLandscapes(gardenerTestClient), runtimeClientGetterMock, nil, logger, fsmCfg)
 	Expect(runtimeReconciler).NotTo(BeNil())
 	err = runtimeReconciler.SetupWithManager(mgr, 1)
 	Expect(err).To(BeNil())
@@ -240,7 +246,13 @@ func setupGardenerClientWithSequence(shoots []*gardener_api.Shoot, seeds []*gard
 		WithInterceptorFuncs(interceptor.Funcs{
 			Patch: fsm_testing.GetFakePatchInterceptorForShootsAndConfigMaps(true),
 		}).Build()
-	runtimeReconciler.GardenClient = gardenerTestClient
+	runtimeReconciler.Landscapes = newTestLandscapes(gardenerTestClient)
+}
+
+func newTestLandscapes(gardenClient client.Client) *landscape.Registry {
+	landscapes, err := landscape.NewRegistry(landscape.Landscape{Client: gardenClient})
+	Expect(err).NotTo(HaveOccurred())
+	return landscapes
 }
 
 func getBaseShootForTestingSequence() gardener_api.Shoot {
@@ -0,0 +1,475 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// LeaseTypeLabel marks the Leases of the shard coordination, the value is either `member` or `shard`
+	LeaseTypeLabel = "infrastructuremanager.kyma-project.io/shard-lease"
+
+	leaseTypeMember = "member"
+	leaseTypeShard  = "shard"
+	leasePrefix     = "infrastructure-manager"
+
+	releaseTimeout = 5 * time.Second
+)
+
+type Config struct {
+	// Shards is the number of shards the runtime IDs are distributed to, it must be the same for all replicas
+	Shards int
+	// Namespace of the Leases and of the sharded Runtime and GardenerCluster CRs
+	Namespace string
+	// Identity of the replica, for example, the Pod name
+	Identity      string
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration after the last successful renewal in which the replica still processes its shards,
+	// it must be shorter than LeaseDuration, so that the replica stops before other replicas take the shards over
+	RenewDeadline time.Duration
+	RenewInterval time.Duration
+	// Elected is closed when the replica becomes the leader, the leader deletes the member Leases of the replicas which stopped
+	// without releasing them. No member Leases are deleted when it is nil.
+	Elected <-chan struct{}
+}
+
+// observedLease is the last version of a Lease seen by the replica and the local time it was seen at
+type observedLease struct {
+	resourceVersion string
+	observedAt      time.Time
+}
+
+type subscriber struct {
+	newList func() client.ObjectList
+	events  chan event.GenericEvent
+}
+
+// Coordinator distributes the shards among the replicas of KIM. Each replica renews its member Lease,
+// computes the assignment of the shards among the live members and holds a Lease for every shard it owns.
+// A shard is taken over only after its previous owner released the Lease or the Lease expired.
+type Coordinator struct {
+	cfg    Config
+	client client.Client
+	// reader reads the Leases directly from the API server, as the Leases are not cached
+	reader client.Reader
+	log    logr.Logger
+	now    func() time.Time
+
+	// observed is only accessed by the coordination loop
+	observed map[string]observedLease
+
+	mu          sync.RWMutex
+	owned       map[int]bool
+	renewedAt   time.Time
+	subscribers []subscriber
+}
+
+func NewCoordinator(cfg Config, kcpClient client.Client, reader client.Reader, logger logr.Logger) *Coordinator {
+	return &Coordinator{
+		cfg:      cfg,
+		client:   kcpClient,
+		reader:   reader,
+		log:      logger.WithValues("identity", cfg.Identity),
+		now:      time.Now,
+		observed: map[string]observedLease{},
+		owned:    map[int]bool{},
+	}
+}
+
+// Owns returns true when the replica owns the shard of the Runtime or GardenerCluster CR
+func (c *Coordinator) Owns(obj client.Object) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.renewedInTime(c.now()) && c.owned[ShardOf(RuntimeID(obj), c.cfg.Shards)]
+}
+
+// OwnedShards returns the sorted shards owned by the replica
+func (c *Coordinator) OwnedShards() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.renewedInTime(c.now()) {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(c.owned))
+}
+
+// renewedInTime returns true when the Leases were renewed within the renew deadline. The deadline is checked whenever the ownership
+// is queried, so that the replica stops processing its shards before they expire, even if the coordination loop is blocked.
+// It must be called with the lock held.
+func (c *Coordinator) renewedInTime(now time.Time) bool {
+	return now.Before(c.renewedAt.Add(c.cfg.RenewDeadline))
+}
+
+// Skips returns true when the replica must not process the object of a queued request. The events of the objects of other shards
+// are filtered out, but the shard may have been handed over to another replica after the request was queued.
+// No object is skipped when the shards are not coordinated.
+func (c *Coordinator) Skips(obj client.Object) bool {
+	return c != nil && !c.Owns(obj)
+}
+
+// Setup limits a controller to the objects of the shards owned by the replica. As every replica reconciles the objects of its shards,
+// the controller runs regardless of the leader election. The returned predicate filters out the events of the objects of other shards,
+// and the returned source enqueues the objects of the shards taken over by the replica. Setup must be called before the manager is started.
+func (c *Coordinator) Setup(options *controller.Options, newList func() client.ObjectList) (predicate.Predicate, source.Source) {
+	options.NeedLeaderElection = ptr.To(false)
+	return predicate.NewPredicateFuncs(c.Owns), c.source(newList)
+}
+
+// source enqueues the objects of the shards taken over by the replica, so that they are reconciled without waiting for a change
+func (c *Coordinator) source(newList func() client.ObjectList) source.Source {
+	events := make(chan event.GenericEvent)
+
+	c.mu.Lock()
+	c.subscribers = append(c.subscribers, subscriber{newList: newList, events: events})
+	c.mu.Unlock()
+
+	return source.Channel(events, &handler.EnqueueRequestForObject{})
+}
+
+// NeedLeaderElection returns false, as the shards are coordinated among all replicas
+func (c *Coordinator) NeedLeaderElection() bool {
+	return false
+}
+
+func (c *Coordinator) Start(ctx context.Context) error {
+	c.log.Info("Starting shard coordination", "shards", c.cfg.Shards)
+
+	ticker := time.NewTicker(c.cfg.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.sync(ctx); err != nil {
+			c.log.Error(err, "Failed to coordinate shards")
+		}
+
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+			defer cancel()
+			return c.release(releaseCtx)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Coordinator) sync(ctx context.Context) error {
+	now := c.now()
+
+	var leases coordinationv1.LeaseList
+	if err := c.reader.List(ctx, &leases, client.InNamespace(c.cfg.Namespace), client.HasLabels{LeaseTypeLabel}); err != nil {
+		c.dropExpiredOwnership(now)
+		return errors.Wrap(err, "failed to list leases")
+	}
+
+	c.observe(leases.Items, now)
+
+	members := []string{c.cfg.Identity}
+	shardLeases := map[string]coordinationv1.Lease{}
+	var memberLease *coordinationv1.Lease
+	var expiredMembers []coordinationv1.Lease
+
+	for _, lease := range leases.Items {
+		switch lease.Labels[LeaseTypeLabel] {
+		case leaseTypeMember:
+			if lease.Name == c.memberLeaseName() {
+				memberLease = &lease
+				continue
+			}
+			holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+			if holder == "" || c.expired(lease, now) {
+				expiredMembers = append(expiredMembers, lease)
+				continue
+			}
+			members = append(members, holder)
+		case leaseTypeShard:
+			shardLeases[lease.Name] = lease
+		}
+	}
+
+	if c.elected() {
+		c.deleteMemberLeases(ctx, expiredMembers)
+	}
+
+	if err := c.renew(ctx, memberLease, c.memberLeaseName(), leaseTypeMember, now); err != nil {
+		c.dropExpiredOwnership(now)
+		return errors.Wrap(err, "failed to renew the member lease")
+	}
+
+	desired := map[int]bool{}
+	for _, shard := range Assign(c.cfg.Shards, members)[c.cfg.Identity] {
+		desired[shard] = true
+	}
+
+	// the shards are dropped before their Leases are released, so that they are not processed after another replica takes them over
+	c.keepOwned(desired, now)
+
+	owned := map[int]bool{}
+	var syncErr error
+	for shard := 0; shard < c.cfg.Shards; shard++ {
+		name := shardLeaseName(shard)
+		lease, found := shardLeases[name]
+		holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+
+		switch {
+		case desired[shard] && (!found || holder == "" || holder == c.cfg.Identity || c.expired(lease, now)):
+			var current *coordinationv1.Lease
+			if found {
+				current = &lease
+			}
+			if err := c.renew(ctx, current, name, leaseTypeShard, now); err != nil {
+				syncErr = errors.Wrapf(err, "failed to acquire the lease of shard %d", shard)
+				continue
+			}
+			owned[shard] = true
+		case !desired[shard] && holder == c.cfg.Identity:
+			if err := c.releaseLease(ctx, lease); err != nil {
+				syncErr = errors.Wrapf(err, "failed to release the lease of shard %d", shard)
+			}
+		}
+	}
+
+	acquired := c.setOwned(owned, now)
+	if len(acquired) > 0 {
+		c.log.Info("Acquired shards", "acquired", acquired, "owned", c.OwnedShards(), "members", len(members))
+		c.resync(ctx, acquired)
+	}
+	return syncErr
+}
+
+// renew creates the Lease or takes it over for the replica
+func (c *Coordinator) renew(ctx context.Context, lease *coordinationv1.Lease, name, leaseType string, now time.Time) error {
+	renewTime := metav1.NewMicroTime(now)
+
+	if lease == nil {
+		return c.client.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: c.cfg.Namespace,
+				Labels:    map[string]string{LeaseTypeLabel: leaseType},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(c.cfg.Identity),
+				LeaseDurationSeconds: ptr.To(int32(c.cfg.LeaseDuration.Seconds())),
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		})
+	}
+
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != c.cfg.Identity {
+		lease.Spec.HolderIdentity = ptr.To(c.cfg.Identity)
+		lease.Spec.AcquireTime = &renewTime
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.cfg.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &renewTime
+	return c.client.Update(ctx, lease)
+}
+
+func (c *Coordinator) releaseLease(ctx context.Context, lease coordinationv1.Lease) error {
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	lease.Spec.RenewTime = nil
+	return c.client.Update(ctx, &lease)
+}
+
+// release gives up all shards and the membership of the replica, so that the other replicas take over without waiting for the Leases to expire
+func (c *Coordinator) release(ctx context.Context) error {
+	c.setOwned(map[int]bool{}, time.Time{})
+
+	var leases coordinationv1.LeaseList
+	if err := c.reader.List(ctx, &leases, client.InNamespace(c.cfg.Namespace), client.HasLabels{LeaseTypeLabel}); err != nil {
+		return errors.Wrap(err, "failed to list leases")
+	}
+
+	for _, lease := range leases.Items {
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != c.cfg.Identity {
+			continue
+		}
+
+		var err error
+		if lease.Labels[LeaseTypeLabel] == leaseTypeMember {
+			err = c.client.Delete(ctx, &lease)
+		} else {
+			err = c.releaseLease(ctx, lease)
+		}
+		if client.IgnoreNotFound(err) != nil && !k8serrors.IsConflict(err) {
+			return errors.Wrapf(err, "failed to release lease %s", lease.Name)
+		}
+	}
+
+	c.log.Info("Released shards")
+	return nil
+}
+
+// setOwned replaces the owned shards and returns the newly acquired ones
+func (c *Coordinator) setOwned(owned map[int]bool, renewedAt time.Time) []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var acquired []int
+	for shard := range owned {
+		if !c.owned[shard] {
+			acquired = append(acquired, shard)
+		}
+	}
+	for shard := range c.owned {
+		if !owned[shard] {
+			c.log.Info("Lost shard", "shard", shard)
+		}
+	}
+
+	c.owned = owned
+	c.renewedAt = renewedAt
+	slices.Sort(acquired)
+	return acquired
+}
+
+// keepOwned drops the owned shards which are not desired anymore. All shards are dropped when the renew deadline passed,
+// so that the shards acquired again are resynced.
+func (c *Coordinator) keepOwned(desired map[int]bool, now time.Time) {
+	c.mu.RLock()
+	kept := map[int]bool{}
+	for shard := range c.owned {
+		if desired[shard] && c.renewedInTime(now) {
+			kept[shard] = true
+		}
+	}
+	renewedAt := c.renewedAt
+	c.mu.RUnlock()
+
+	c.setOwned(kept, renewedAt)
+}
+
+// dropExpiredOwnership gives up the shards when the Leases could not be renewed in time, as other replicas may take them over
+func (c *Coordinator) dropExpiredOwnership(now time.Time) {
+	c.mu.RLock()
+	renewedAt := c.renewedAt
+	renewedInTime := c.renewedInTime(now)
+	c.mu.RUnlock()
+
+	if !renewedInTime {
+		c.setOwned(map[int]bool{}, renewedAt)
+	}
+}
+
+// resync enqueues the objects of the acquired shards
+func (c *Coordinator) resync(ctx context.Context, acquired []int) {
+	c.mu.RLock()
+	subscribers := slices.Clone(c.subscribers)
+	c.mu.RUnlock()
+
+	for _, s := range subscribers {
+		list := s.newList()
+		if err := c.client.List(ctx, list, client.InNamespace(c.cfg.Namespace)); err != nil {
+			c.log.Error(err, "Failed to list objects of the acquired shards")
+			continue
+		}
+
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			c.log.Error(err, "Failed to extract objects of the acquired shards")
+			continue
+		}
+
+		var toEnqueue []client.Object
+		for _, item := range objects {
+			obj, ok := item.(client.Object)
+			if ok && slices.Contains(acquired, ShardOf(RuntimeID(obj), c.cfg.Shards)) {
+				toEnqueue = append(toEnqueue, obj)
+			}
+		}
+
+		// the controllers receive the events after they are started, so the coordination is not blocked
+		go func() {
+			for _, obj := range toEnqueue {
+				select {
+				case s.events <- event.GenericEvent{Object: obj}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+func (c *Coordinator) memberLeaseName() string {
+	return fmt.Sprintf("%s-member-%s", leasePrefix, c.cfg.Identity)
+}
+
+func shardLeaseName(shard int) string {
+	return fmt.Sprintf("%s-shard-%d", leasePrefix, shard)
+}
+
+// observe records the changes of the Leases with the local clock and drops the observations of the deleted Leases
+func (c *Coordinator) observe(leases []coordinationv1.Lease, now time.Time) {
+	existing := map[string]bool{}
+	for _, lease := range leases {
+		existing[lease.Name] = true
+		if observed, found := c.observed[lease.Name]; !found || observed.resourceVersion != lease.ResourceVersion {
+			c.observed[lease.Name] = observedLease{resourceVersion: lease.ResourceVersion, observedAt: now}
+		}
+	}
+	maps.DeleteFunc(c.observed, func(name string, _ observedLease) bool {
+		return !existing[name]
+	})
+}
+
+// expired returns true when the Lease was not renewed for its duration. As in the leader election of client-go, the renew time
+// written by the holder is not compared with the local clock, so that a clock skew between the replicas doesn't matter.
+// Instead, a Lease expires when the replica observes no change of it for the lease duration, so a Lease seen for the first time
+// is never expired.
+func (c *Coordinator) expired(lease coordinationv1.Lease, now time.Time) bool {
+	observed, found := c.observed[lease.Name]
+	if !found || observed.resourceVersion != lease.ResourceVersion {
+		return false
+	}
+
+	leaseDuration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+	return now.After(observed.observedAt.Add(leaseDuration))
+}
+
+func (c *Coordinator) elected() bool {
+	if c.cfg.Elected == nil {
+		return false
+	}
+
+	select {
+	case <-c.cfg.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// deleteMemberLeases deletes the member Leases of the replicas which stopped without releasing them, for example, after a crash.
+// The Lease is deleted only if it was not renewed in the meantime.
+func (c *Coordinator) deleteMemberLeases(ctx context.Context, leases []coordinationv1.Lease) {
+	for _, lease := range leases {
+		err := c.client.Delete(ctx, &lease, client.Preconditions{UID: &lease.UID, ResourceVersion: &lease.ResourceVersion})
+		if client.IgnoreNotFound(err) != nil && !k8serrors.IsConflict(err) {
+			c.log.Error(err, "Failed to delete the expired member lease", "lease", lease.Name)
+			continue
+		}
+		c.log.Info("Deleted the expired member lease", "lease", lease.Name)
+		delete(c.observed, lease.Name)
+	}
+}
@@ -0,0 +1,283 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const shards = 8
+
+func TestCoordinator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, coordinationv1.AddToScheme(scheme))
+
+	t.Run("Should distribute the shards among the replicas", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+
+		// when
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		assert.Equal(t, Assign(shards, []string{"kim-1", "kim-2"})["kim-1"], kim1.OwnedShards())
+		assert.Equal(t, Assign(shards, []string{"kim-1", "kim-2"})["kim-2"], kim2.OwnedShards())
+	})
+
+	t.Run("Should not take over a shard before it is released", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		require.Len(t, kim1.OwnedShards(), shards)
+
+		// when
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		assert.Empty(t, kim2.OwnedShards())
+		assertDisjoint(t, kim1.OwnedShards(), kim2.OwnedShards())
+	})
+
+	t.Run("Should take over the shards of a replica which stopped", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// when
+		require.NoError(t, kim1.release(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		assert.Empty(t, kim1.OwnedShards())
+		assert.Len(t, kim2.OwnedShards(), shards)
+	})
+
+	t.Run("Should take over the shards of a replica whose leases expired", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+		require.Empty(t, kim2.OwnedShards())
+
+		// when
+		now = now.Add(time.Minute)
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		assert.Len(t, kim2.OwnedShards(), shards)
+	})
+
+	t.Run("Should not take over renewed shards regardless of the renew time written by their owner", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kim1Clock := now.Add(-time.Hour)
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &kim1Clock)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// when
+		now = now.Add(20 * time.Second)
+		require.NoError(t, kim1.sync(context.Background()))
+		now = now.Add(20 * time.Second)
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		assert.Equal(t, Assign(shards, []string{"kim-1", "kim-2"})["kim-1"], kim1.OwnedShards())
+		assert.Equal(t, Assign(shards, []string{"kim-1", "kim-2"})["kim-2"], kim2.OwnedShards())
+	})
+
+	t.Run("Should stop owning the shards after the renew deadline", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		cluster := fixGardenerCluster("runtime-1")
+		require.True(t, kim1.Owns(cluster))
+
+		// when
+		now = now.Add(21 * time.Second)
+
+		// then
+		assert.False(t, kim1.Owns(cluster))
+		assert.Empty(t, kim1.OwnedShards())
+	})
+
+	t.Run("Should drop a shard before its lease is released", func(t *testing.T) {
+		// given
+		now := time.Now()
+		var kim1 *Coordinator
+		ownedOnRelease := map[string][]int{}
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if lease, ok := obj.(*coordinationv1.Lease); ok && lease.Spec.HolderIdentity == nil {
+					ownedOnRelease[lease.Name] = kim1.OwnedShards()
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).Build()
+		kim1 = newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// when
+		require.NoError(t, kim1.sync(context.Background()))
+
+		// then
+		released := Assign(shards, []string{"kim-1", "kim-2"})["kim-2"]
+		require.NotEmpty(t, released)
+		require.Len(t, ownedOnRelease, len(released))
+		for _, shard := range released {
+			assert.NotContains(t, ownedOnRelease[shardLeaseName(shard)], shard)
+		}
+	})
+
+	t.Run("Should delete the member leases of stopped replicas in the leader", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		kim3 := newTestCoordinator(kcpClient, "kim-3", &now)
+		elected := make(chan struct{})
+		close(elected)
+		kim2.cfg.Elected = elected
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+		require.NoError(t, kim3.sync(context.Background()))
+
+		// when
+		now = now.Add(time.Minute)
+		require.NoError(t, kim3.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		var leases coordinationv1.LeaseList
+		require.NoError(t, kcpClient.List(context.Background(), &leases, client.MatchingLabels{LeaseTypeLabel: leaseTypeMember}))
+		var members []string
+		for _, lease := range leases.Items {
+			members = append(members, lease.Name)
+		}
+		assert.ElementsMatch(t, []string{"infrastructure-manager-member-kim-2", "infrastructure-manager-member-kim-3"}, members)
+	})
+
+	t.Run("Should keep the member leases of stopped replicas in other replicas than the leader", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		kim2.cfg.Elected = make(chan struct{})
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// when
+		now = now.Add(time.Minute)
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// then
+		var leases coordinationv1.LeaseList
+		require.NoError(t, kcpClient.List(context.Background(), &leases, client.MatchingLabels{LeaseTypeLabel: leaseTypeMember}))
+		assert.Len(t, leases.Items, 2)
+	})
+
+	t.Run("Should filter objects of shards owned by other replicas", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		kim2 := newTestCoordinator(kcpClient, "kim-2", &now)
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+		require.NoError(t, kim1.sync(context.Background()))
+		require.NoError(t, kim2.sync(context.Background()))
+
+		// when
+		var owners int
+		for _, runtimeID := range []string{"runtime-1", "runtime-2", "runtime-3", "runtime-4"} {
+			cluster := fixGardenerCluster(runtimeID)
+			if kim1.Owns(cluster) {
+				owners++
+			}
+			if kim2.Owns(cluster) {
+				owners++
+			}
+		}
+
+		// then
+		assert.Equal(t, 4, owners)
+	})
+
+	t.Run("Should skip only objects of shards owned by other replicas", func(t *testing.T) {
+		// given
+		now := time.Now()
+		kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		kim1 := newTestCoordinator(kcpClient, "kim-1", &now)
+		var notCoordinated *Coordinator
+		cluster := fixGardenerCluster("runtime-1")
+
+		// when
+		skippedBeforeSync := kim1.Skips(cluster)
+		require.NoError(t, kim1.sync(context.Background()))
+
+		// then
+		assert.True(t, skippedBeforeSync)
+		assert.False(t, kim1.Skips(cluster))
+		assert.False(t, notCoordinated.Skips(cluster))
+	})
+}
+
+func newTestCoordinator(kcpClient client.Client, identity string, now *time.Time) *Coordinator {
+	coordinator := NewCoordinator(Config{
+		Shards:        shards,
+		Namespace:     "kcp-system",
+		Identity:      identity,
+		LeaseDuration: 30 * time.Second,
+		RenewDeadline: 20 * time.Second,
+		RenewInterval: 10 * time.Second,
+	}, kcpClient, kcpClient, logr.Discard())
+	coordinator.now = func() time.Time { return *now }
+	return coordinator
+}
+
+func assertDisjoint(t *testing.T, a, b []int) {
+	for _, shard := range a {
+		assert.NotContains(t, b, shard)
+	}
+}
+
+func fixGardenerCluster(runtimeID string) *imv1.GardenerCluster {
+	return &imv1.GardenerCluster{ObjectMeta: metav1.ObjectMeta{Name: runtimeID, Namespace: "kcp-system"}}
+}
@@ -0,0 +1,63 @@
+package sharding
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RuntimeID returns the runtime ID of a Runtime or GardenerCluster CR, so that both CRs of a runtime belong to the same shard
+func RuntimeID(obj client.Object) string {
+	if runtimeID := obj.GetLabels()[imv1.LabelKymaRuntimeID]; runtimeID != "" {
+		return runtimeID
+	}
+	return obj.GetName()
+}
+
+// ShardOf returns the shard of the runtime ID
+func ShardOf(runtimeID string, shards int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(runtimeID))
+	return int(h.Sum32() % uint32(shards)) //nolint:gosec // shards is a small positive number
+}
+
+// Assign distributes the shards among the members with rendezvous hashing.
+// Every member computes the same assignment, and only the shards of a joining or leaving member are moved.
+func Assign(shards int, members []string) map[string][]int {
+	assignment := map[string][]int{}
+	if len(members) == 0 {
+		return assignment
+	}
+
+	for shard := 0; shard < shards; shard++ {
+		var owner string
+		var ownerScore uint64
+		for _, member := range members {
+			score := rendezvousScore(member, shard)
+			if owner == "" || score > ownerScore || (score == ownerScore && member < owner) {
+				owner, ownerScore = member, score
+			}
+		}
+		assignment[owner] = append(assignment[owner], shard)
+	}
+	return assignment
+}
+
+func rendezvousScore(member string, shard int) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(member + "/" + strconv.Itoa(shard)))
+	return mix(h.Sum64())
+}
+
+// mix spreads the differences of the FNV hash to all bits with the finalizer of MurmurHash3. The high bits of the FNV hash
+// of short keys differing only in a few bytes are too similar, so a single member would win the scores of all shards.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
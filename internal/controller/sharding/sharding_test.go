@@ -0,0 +1,88 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuntimeID(t *testing.T) {
+	t.Run("Should return the runtime ID label", func(t *testing.T) {
+		// given
+		runtime := &imv1.Runtime{ObjectMeta: metav1.ObjectMeta{Name: "runtime", Labels: map[string]string{imv1.LabelKymaRuntimeID: "runtime-id"}}}
+
+		// then
+		assert.Equal(t, "runtime-id", RuntimeID(runtime))
+	})
+
+	t.Run("Should fall back to the name", func(t *testing.T) {
+		// given
+		cluster := &imv1.GardenerCluster{ObjectMeta: metav1.ObjectMeta{Name: "runtime-id"}}
+
+		// then
+		assert.Equal(t, "runtime-id", RuntimeID(cluster))
+	})
+}
+
+func TestShardOf(t *testing.T) {
+	// when
+	shard := ShardOf("runtime-id", 16)
+
+	// then
+	assert.Equal(t, shard, ShardOf("runtime-id", 16))
+	assert.GreaterOrEqual(t, shard, 0)
+	assert.Less(t, shard, 16)
+}
+
+func TestAssign(t *testing.T) {
+	t.Run("Should assign every shard to exactly one member", func(t *testing.T) {
+		// when
+		assignment := Assign(16, []string{"kim-1", "kim-2", "kim-3"})
+
+		// then
+		assigned := map[int]string{}
+		for member, shards := range assignment {
+			for _, shard := range shards {
+				assert.NotContains(t, assigned, shard, "shard %d is assigned twice", shard)
+				assigned[shard] = member
+			}
+		}
+		assert.Len(t, assigned, 16)
+	})
+
+	t.Run("Should assign shards to every member", func(t *testing.T) {
+		// when
+		assignment := Assign(16, []string{"kim-1", "kim-2", "kim-3"})
+
+		// then
+		for _, member := range []string{"kim-1", "kim-2", "kim-3"} {
+			assert.NotEmpty(t, assignment[member], "member %s has no shards", member)
+		}
+	})
+
+	t.Run("Should compute the same assignment regardless of the order of members", func(t *testing.T) {
+		// then
+		assert.Equal(t, Assign(16, []string{"kim-1", "kim-2", "kim-3"}), Assign(16, []string{"kim-3", "kim-1", "kim-2"}))
+	})
+
+	t.Run("Should move only the shards of the leaving member", func(t *testing.T) {
+		// given
+		before := Assign(64, []string{"kim-1", "kim-2", "kim-3"})
+
+		// when
+		after := Assign(64, []string{"kim-1", "kim-2"})
+
+		// then
+		for _, member := range []string{"kim-1", "kim-2"} {
+			assert.Subset(t, after[member], before[member], fmt.Sprintf("member %s lost shards", member))
+		}
+	})
+
+	t.Run("Should not assign shards without members", func(t *testing.T) {
+		// then
+		assert.Empty(t, Assign(16, nil))
+	})
+}
@@ -0,0 +1,305 @@
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/kubeconfig"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const namespace = "kcp-system"
+
+func TestList(t *testing.T) {
+	runtimes := []client.Object{
+		fixRuntime("runtime-c", "aws", "eu-central-1", imv1.RuntimeStateReady),
+		fixRuntime("runtime-a", "gcp", "europe-west3", imv1.RuntimeStateFailed, withConditionReason(imv1.ConditionReasonConversionError)),
+		fixRuntime("runtime-b", "aws", "us-east-1", imv1.RuntimeStateFailed, withLabel("tier", "trial")),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		selector Selector
+		expected []string
+	}{
+		{
+			name:     "Should list all Runtimes sorted by name",
+			expected: []string{"runtime-a", "runtime-b", "runtime-c"},
+		},
+		{
+			name:     "Should select by label",
+			selector: Selector{Labels: labels.SelectorFromSet(labels.Set{"tier": "trial"})},
+			expected: []string{"runtime-b"},
+		},
+		{
+			name:     "Should select by provider and state",
+			selector: Selector{Providers: []string{"aws"}, States: []imv1.State{imv1.RuntimeStateFailed}},
+			expected: []string{"runtime-b"},
+		},
+		{
+			name:     "Should select any of the regions",
+			selector: Selector{Regions: []string{"europe-west3", "eu-central-1"}},
+			expected: []string{"runtime-a", "runtime-c"},
+		},
+		{
+			name:     "Should select by condition reason",
+			selector: Selector{ConditionReasons: []string{string(imv1.ConditionReasonConversionError)}},
+			expected: []string{"runtime-a"},
+		},
+		{
+			name:     "Should select nothing when no Runtime matches",
+			selector: Selector{Providers: []string{"azure"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(runtimes...).Build()
+
+			// when
+			selected, err := List(context.Background(), c, namespace, tc.selector)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, names(selected))
+		})
+	}
+}
+
+func TestSelectorIsEmpty(t *testing.T) {
+	assert.True(t, Selector{}.IsEmpty())
+	assert.True(t, Selector{Labels: labels.Everything()}.IsEmpty())
+	assert.False(t, Selector{States: []imv1.State{imv1.RuntimeStateFailed}}.IsEmpty())
+}
+
+func TestExecutor(t *testing.T) {
+	t.Run("Should annotate the selected Runtimes", func(t *testing.T) {
+		// given
+		runtimes := []imv1.Runtime{
+			*fixRuntime("runtime-a", "aws", "eu-central-1", imv1.RuntimeStateReady),
+			*fixRuntime("runtime-b", "aws", "eu-central-1", imv1.RuntimeStateReady, withAnnotation(reconciler.ForceReconcileAnnotation, "true")),
+		}
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(&runtimes[0], &runtimes[1]).Build()
+		var out bytes.Buffer
+		executor := Executor{Client: c, Out: &out}
+
+		// when
+		report, err := executor.Run(context.Background(), ForcePatch, runtimes)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Changed: 1, Unchanged: 1}, report)
+		assert.Equal(t, "runtime-a: force-patch\nruntime-b: unchanged\n", out.String())
+		assert.Equal(t, "true", getRuntime(t, c, "runtime-a").Annotations[reconciler.ForceReconcileAnnotation])
+	})
+
+	t.Run("Should not change the Runtimes in dry run", func(t *testing.T) {
+		// given
+		runtime := fixRuntime("runtime-a", "aws", "eu-central-1", imv1.RuntimeStateReady)
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(runtime).Build()
+		var out bytes.Buffer
+		executor := Executor{Client: c, DryRun: true, Out: &out}
+
+		// when
+		report, err := executor.Run(context.Background(), Suspend, []imv1.Runtime{*runtime})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Changed: 1}, report)
+		assert.Equal(t, "runtime-a: suspend (dry run)\n", out.String())
+		assert.NotContains(t, getRuntime(t, c, "runtime-a").Annotations, reconciler.SuspendReconcileAnnotation)
+	})
+
+	t.Run("Should remove the suspend annotation on resume", func(t *testing.T) {
+		// given
+		runtime := fixRuntime("runtime-a", "aws", "eu-central-1", imv1.RuntimeStateReady, withAnnotation(reconciler.SuspendReconcileAnnotation, "true"))
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(runtime).Build()
+		executor := Executor{Client: c, Out: &bytes.Buffer{}}
+
+		// when
+		report, err := executor.Run(context.Background(), Resume, []imv1.Runtime{*runtime})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Changed: 1}, report)
+		assert.NotContains(t, getRuntime(t, c, "runtime-a").Annotations, reconciler.SuspendReconcileAnnotation)
+	})
+
+	t.Run("Should annotate the GardenerCluster to rotate the kubeconfig", func(t *testing.T) {
+		// given
+		runtimes := []imv1.Runtime{
+			*fixRuntime("runtime-a", "aws", "eu-central-1", imv1.RuntimeStateReady),
+			*fixRuntime("runtime-b", "aws", "eu-central-1", imv1.RuntimeStateReady),
+		}
+		cluster := &imv1.GardenerCluster{ObjectMeta: metav1.ObjectMeta{Name: "runtime-a", Namespace: namespace}}
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(cluster).Build()
+		var out bytes.Buffer
+		executor := Executor{Client: c, Out: &out}
+
+		// when
+		report, err := executor.Run(context.Background(), RotateKubeconfig, runtimes)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Changed: 1, Failed: 1}, report)
+		assert.Contains(t, out.String(), "runtime-b: failed to get resource")
+
+		var updated imv1.GardenerCluster
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "runtime-a", Namespace: namespace}, &updated))
+		assert.Equal(t, "true", updated.Annotations[kubeconfig.ForceKubeconfigRotationAnnotation])
+	})
+
+	t.Run("Should continue after a failed patch", func(t *testing.T) {
+		// given
+		runtimes := []imv1.Runtime{
+			*fixRuntime("runtime-a", "aws", "eu-central-1", imv1.RuntimeStateReady),
+			*fixRuntime("runtime-b", "aws", "eu-central-1", imv1.RuntimeStateReady),
+		}
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(&runtimes[0], &runtimes[1]).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if obj.GetName() == "runtime-a" {
+						return errors.New("conflict")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		var out bytes.Buffer
+		executor := Executor{Client: c, Out: &out}
+
+		// when
+		report, err := executor.Run(context.Background(), ForcePatch, runtimes)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Changed: 1, Failed: 1}, report)
+		assert.Equal(t, "runtime-a: force-patch failed: conflict\nruntime-b: force-patch\n", out.String())
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	// given
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	runtimes := []imv1.Runtime{
+		*fixRuntime("runtime-a", "aws", "eu-central-1", imv1.RuntimeStateReady),
+		*fixRuntime("runtime-b", "aws", "eu-central-1", imv1.RuntimeStatePending, withCondition(now.Add(-time.Hour), "ShootCreationPending")),
+		*fixRuntime("runtime-c", "aws", "eu-central-1", imv1.RuntimeStatePending, withCondition(now.Add(-3*time.Hour), "ShootCreationPending")),
+		*fixRuntime("runtime-d", "aws", "eu-central-1", imv1.RuntimeStatePending, withCondition(now.Add(-2*time.Hour), "ShootModificationPending")),
+		*fixRuntime("runtime-e", "aws", "eu-central-1", imv1.RuntimeStateFailed,
+			withLastErrors(
+				gardener.LastError{Description: "quota exceeded", Codes: []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded}},
+				gardener.LastError{Description: "quota exceeded again", Codes: []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded}},
+			)),
+		*fixRuntime("runtime-f", "aws", "eu-central-1", imv1.RuntimeStateFailed,
+			withLastErrors(
+				gardener.LastError{Description: "quota exceeded", Codes: []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded}},
+				gardener.LastError{Description: "webhook failed\nwith details"},
+			)),
+	}
+
+	// when
+	summary := Summarize(runtimes, 2)
+
+	// then
+	assert.Equal(t, 6, summary.Total)
+	assert.Equal(t, map[imv1.State]int{imv1.RuntimeStateReady: 1, imv1.RuntimeStatePending: 3, imv1.RuntimeStateFailed: 2}, summary.States)
+	assert.Equal(t, []PendingRuntime{
+		{Name: "runtime-c", Since: now.Add(-3 * time.Hour), Reason: "ShootCreationPending"},
+		{Name: "runtime-d", Since: now.Add(-2 * time.Hour), Reason: "ShootModificationPending"},
+	}, summary.OldestPending)
+	assert.Equal(t, []FailureReason{
+		{Reason: string(gardener.ErrorInfraQuotaExceeded), Runtimes: 2},
+		{Reason: "webhook failed", Runtimes: 1},
+	}, summary.FailureReasons)
+
+	var out bytes.Buffer
+	require.NoError(t, summary.Print(&out, now))
+	assert.Regexp(t, `runtime-c\s+3h0m0s\s+ShootCreationPending`, out.String())
+}
+
+func newScheme(t *testing.T) *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+	return scheme
+}
+
+type runtimeOption func(*imv1.Runtime)
+
+func fixRuntime(name, provider, region string, state imv1.State, opts ...runtimeOption) *imv1.Runtime {
+	runtime := &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{imv1.LabelKymaRuntimeID: name},
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name:     "shoot-" + name,
+				Region:   region,
+				Provider: imv1.Provider{Type: provider},
+			},
+		},
+		Status: imv1.RuntimeStatus{State: state},
+	}
+	for _, opt := range opts {
+		opt(runtime)
+	}
+	return runtime
+}
+
+func withLabel(name, value string) runtimeOption {
+	return func(runtime *imv1.Runtime) {
+		runtime.Labels[name] = value
+	}
+}
+
+func withAnnotation(name, value string) runtimeOption {
+	return func(runtime *imv1.Runtime) {
+		runtime.Annotations = map[string]string{name: value}
+	}
+}
+
+func withConditionReason(reason imv1.RuntimeConditionReason) runtimeOption {
+	return func(runtime *imv1.Runtime) {
+		runtime.Status.Conditions = append(runtime.Status.Conditions, metav1.Condition{Reason: string(reason)})
+	}
+}
+
+func withCondition(since time.Time, reason string) runtimeOption {
+	return func(runtime *imv1.Runtime) {
+		runtime.Status.Conditions = append(runtime.Status.Conditions, metav1.Condition{Reason: reason, LastTransitionTime: metav1.NewTime(since)})
+	}
+}
+
+func withLastErrors(lastErrors ...gardener.LastError) runtimeOption {
+	return func(runtime *imv1.Runtime) {
+		runtime.Status.ShootLastErrors = lastErrors
+	}
+}
+
+func getRuntime(t *testing.T, c client.Client, name string) imv1.Runtime {
+	var runtime imv1.Runtime
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &runtime))
+	return runtime
+}
+
+func names(runtimes []imv1.Runtime) []string {
+	var result []string
+	for _, runtime := range runtimes {
+		result = append(result, runtime.Name)
+	}
+	return result
+}
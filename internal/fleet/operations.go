@@ -0,0 +1,145 @@
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/controller/kubeconfig"
+	"github.com/kyma-project/infrastructure-manager/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Operation changes the annotations of a resource belonging to a Runtime.
+type Operation struct {
+	Name string
+	// target returns the resource to change, the Runtime CR itself or its GardenerCluster CR
+	target func(ctx context.Context, c client.Client, runtime imv1.Runtime) (client.Object, error)
+	// mutate changes the annotations and returns false when the resource is already in the desired state
+	mutate func(annotations map[string]string) bool
+}
+
+var (
+	ForcePatch = Operation{
+		Name:   "force-patch",
+		target: runtimeTarget,
+		mutate: setAnnotation(reconciler.ForceReconcileAnnotation),
+	}
+	Suspend = Operation{
+		Name:   "suspend",
+		target: runtimeTarget,
+		mutate: setAnnotation(reconciler.SuspendReconcileAnnotation),
+	}
+	Resume = Operation{
+		Name:   "resume",
+		target: runtimeTarget,
+		mutate: removeAnnotation(reconciler.SuspendReconcileAnnotation),
+	}
+	RotateKubeconfig = Operation{
+		Name:   "rotate-kubeconfig",
+		target: gardenerClusterTarget,
+		mutate: setAnnotation(kubeconfig.ForceKubeconfigRotationAnnotation),
+	}
+)
+
+// Executor applies an operation to Runtimes one after another, limited by the rate limiter.
+type Executor struct {
+	Client      client.Client
+	DryRun      bool
+	RateLimiter flowcontrol.RateLimiter
+	Out         io.Writer
+}
+
+// Report counts the Runtimes processed by the Executor.
+type Report struct {
+	Changed   int
+	Unchanged int
+	Failed    int
+}
+
+// Run applies the operation to the Runtimes. Failures of single Runtimes are reported and don't stop the run.
+func (e Executor) Run(ctx context.Context, operation Operation, runtimes []imv1.Runtime) (Report, error) {
+	var report Report
+
+	for _, runtime := range runtimes {
+		object, err := operation.target(ctx, e.Client, runtime)
+		if err != nil {
+			report.Failed++
+			fmt.Fprintf(e.Out, "%s: failed to get resource: %v\n", runtime.Name, err)
+			continue
+		}
+
+		original := object.DeepCopyObject().(client.Object)
+		annotations := object.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		if !operation.mutate(annotations) {
+			report.Unchanged++
+			fmt.Fprintf(e.Out, "%s: unchanged\n", runtime.Name)
+			continue
+		}
+		object.SetAnnotations(annotations)
+
+		if e.DryRun {
+			report.Changed++
+			fmt.Fprintf(e.Out, "%s: %s (dry run)\n", runtime.Name, operation.Name)
+			continue
+		}
+
+		if e.RateLimiter != nil {
+			if err := e.RateLimiter.Wait(ctx); err != nil {
+				return report, err
+			}
+		}
+
+		if err := e.Client.Patch(ctx, object, client.MergeFrom(original)); err != nil {
+			report.Failed++
+			fmt.Fprintf(e.Out, "%s: %s failed: %v\n", runtime.Name, operation.Name, err)
+			continue
+		}
+
+		report.Changed++
+		fmt.Fprintf(e.Out, "%s: %s\n", runtime.Name, operation.Name)
+	}
+
+	return report, nil
+}
+
+func runtimeTarget(_ context.Context, _ client.Client, runtime imv1.Runtime) (client.Object, error) {
+	return runtime.DeepCopy(), nil
+}
+
+// gardenerClusterTarget returns the GardenerCluster CR which holds the kubeconfig of the Runtime.
+func gardenerClusterTarget(ctx context.Context, c client.Client, runtime imv1.Runtime) (client.Object, error) {
+	var cluster imv1.GardenerCluster
+	key := types.NamespacedName{Name: runtime.Labels[imv1.LabelKymaRuntimeID], Namespace: runtime.Namespace}
+	if err := c.Get(ctx, key, &cluster); err != nil {
+		return nil, err
+	}
+	return &cluster, nil
+}
+
+func setAnnotation(name string) func(map[string]string) bool {
+	return func(annotations map[string]string) bool {
+		if annotations[name] == "true" {
+			return false
+		}
+		annotations[name] = "true"
+		return true
+	}
+}
+
+func removeAnnotation(name string) func(map[string]string) bool {
+	return func(annotations map[string]string) bool {
+		if _, found := annotations[name]; !found {
+			return false
+		}
+		delete(annotations, name)
+		return true
+	}
+}
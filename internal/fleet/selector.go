@@ -0,0 +1,79 @@
+package fleet
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Selector selects Runtime CRs. Runtimes match when they match all the set criteria; within a criterion any value matches.
+type Selector struct {
+	Labels           labels.Selector
+	Providers        []string
+	Regions          []string
+	States           []imv1.State
+	ConditionReasons []string
+}
+
+// IsEmpty returns true when the selector selects all Runtimes.
+func (s Selector) IsEmpty() bool {
+	return (s.Labels == nil || s.Labels.Empty()) &&
+		len(s.Providers) == 0 && len(s.Regions) == 0 && len(s.States) == 0 && len(s.ConditionReasons) == 0
+}
+
+func (s Selector) Matches(runtime imv1.Runtime) bool {
+	if s.Labels != nil && !s.Labels.Matches(labels.Set(runtime.Labels)) {
+		return false
+	}
+
+	if len(s.Providers) > 0 && !slices.Contains(s.Providers, runtime.Spec.Shoot.Provider.Type) {
+		return false
+	}
+
+	if len(s.Regions) > 0 && !slices.Contains(s.Regions, runtime.Spec.Shoot.Region) {
+		return false
+	}
+
+	if len(s.States) > 0 && !slices.Contains(s.States, runtime.Status.State) {
+		return false
+	}
+
+	if len(s.ConditionReasons) > 0 && !slices.ContainsFunc(runtime.Status.Conditions, func(condition metav1.Condition) bool {
+		return slices.Contains(s.ConditionReasons, condition.Reason)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// List returns the Runtime CRs in the namespace matching the selector, sorted by name.
+func List(ctx context.Context, c client.Reader, namespace string, selector Selector) ([]imv1.Runtime, error) {
+	opts := []client.ListOption{client.InNamespace(namespace)}
+	if selector.Labels != nil {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector.Labels})
+	}
+
+	var runtimes imv1.RuntimeList
+	if err := c.List(ctx, &runtimes, opts...); err != nil {
+		return nil, err
+	}
+
+	var selected []imv1.Runtime
+	for _, runtime := range runtimes.Items {
+		if selector.Matches(runtime) {
+			selected = append(selected, runtime)
+		}
+	}
+
+	slices.SortFunc(selected, func(a, b imv1.Runtime) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return selected, nil
+}
@@ -0,0 +1,174 @@
+package fleet
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+)
+
+const maxFailureReasonLength = 80
+
+// Summary describes the state of the selected Runtimes.
+type Summary struct {
+	Total          int
+	States         map[imv1.State]int
+	OldestPending  []PendingRuntime
+	FailureReasons []FailureReason
+}
+
+type PendingRuntime struct {
+	Name   string
+	Since  time.Time
+	Reason string
+}
+
+// FailureReason counts the Runtimes reporting a Shoot error. Errors are grouped by their Gardener error code,
+// errors without a code by the beginning of their description.
+type FailureReason struct {
+	Reason   string
+	Runtimes int
+}
+
+// Summarize computes the summary of the Runtimes, listing at most top entries of the oldest Pending Runtimes and failure reasons.
+func Summarize(runtimes []imv1.Runtime, top int) Summary {
+	summary := Summary{
+		Total:  len(runtimes),
+		States: map[imv1.State]int{},
+	}
+
+	failures := map[string]int{}
+	for _, runtime := range runtimes {
+		state := runtime.Status.State
+		if state == "" {
+			state = "Unknown"
+		}
+		summary.States[state]++
+
+		if runtime.Status.State == imv1.RuntimeStatePending {
+			summary.OldestPending = append(summary.OldestPending, pendingRuntime(runtime))
+		}
+
+		for _, reason := range failureReasons(runtime) {
+			failures[reason]++
+		}
+	}
+
+	slices.SortFunc(summary.OldestPending, func(a, b PendingRuntime) int {
+		return cmp.Or(a.Since.Compare(b.Since), strings.Compare(a.Name, b.Name))
+	})
+	if len(summary.OldestPending) > top {
+		summary.OldestPending = summary.OldestPending[:top]
+	}
+
+	for reason, count := range failures {
+		summary.FailureReasons = append(summary.FailureReasons, FailureReason{Reason: reason, Runtimes: count})
+	}
+	slices.SortFunc(summary.FailureReasons, func(a, b FailureReason) int {
+		return cmp.Or(cmp.Compare(b.Runtimes, a.Runtimes), strings.Compare(a.Reason, b.Reason))
+	})
+	if len(summary.FailureReasons) > top {
+		summary.FailureReasons = summary.FailureReasons[:top]
+	}
+
+	return summary
+}
+
+// pendingRuntime uses the last condition transition as the time since when the Runtime is Pending.
+func pendingRuntime(runtime imv1.Runtime) PendingRuntime {
+	since, reason := lastTransition(runtime)
+	return PendingRuntime{Name: runtime.Name, Since: since, Reason: reason}
+}
+
+// lastTransition returns the time and reason of the latest condition change of the Runtime.
+func lastTransition(runtime imv1.Runtime) (time.Time, string) {
+	since, reason := runtime.CreationTimestamp.Time, ""
+	for _, condition := range runtime.Status.Conditions {
+		if !condition.LastTransitionTime.Time.Before(since) {
+			since, reason = condition.LastTransitionTime.Time, condition.Reason
+		}
+	}
+	return since, reason
+}
+
+// failureReasons returns the distinct failure reasons of the Runtime, so that every Runtime is counted once per reason.
+func failureReasons(runtime imv1.Runtime) []string {
+	var reasons []string
+	for _, lastError := range runtime.Status.ShootLastErrors {
+		if len(lastError.Codes) == 0 {
+			reasons = append(reasons, shorten(lastError.Description))
+			continue
+		}
+		for _, code := range lastError.Codes {
+			reasons = append(reasons, string(code))
+		}
+	}
+
+	slices.Sort(reasons)
+	return slices.Compact(reasons)
+}
+
+func shorten(description string) string {
+	description, _, _ = strings.Cut(strings.TrimSpace(description), "\n")
+	if len(description) > maxFailureReasonLength {
+		return description[:maxFailureReasonLength] + "..."
+	}
+	return description
+}
+
+// Print writes the summary as tables.
+func (s Summary) Print(w io.Writer, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "RUNTIMES\t%d\n\n", s.Total)
+
+	fmt.Fprintln(tw, "STATE\tCOUNT")
+	states := make([]imv1.State, 0, len(s.States))
+	for state := range s.States {
+		states = append(states, state)
+	}
+	slices.Sort(states)
+	for _, state := range states {
+		fmt.Fprintf(tw, "%s\t%d\n", state, s.States[state])
+	}
+
+	if len(s.OldestPending) > 0 {
+		fmt.Fprintln(tw, "\nOLDEST PENDING\tPENDING FOR\tREASON")
+		for _, pending := range s.OldestPending {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", pending.Name, now.Sub(pending.Since).Truncate(time.Second), pending.Reason)
+		}
+	}
+
+	if len(s.FailureReasons) > 0 {
+		fmt.Fprintln(tw, "\nSHOOT ERROR\tRUNTIMES")
+		for _, failure := range s.FailureReasons {
+			fmt.Fprintf(tw, "%s\t%d\n", failure.Reason, failure.Runtimes)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// PrintList writes the Runtimes as a table.
+func PrintList(w io.Writer, runtimes []imv1.Runtime) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NAME\tSHOOT\tPROVIDER\tREGION\tSTATE\tREASON")
+	for _, runtime := range runtimes {
+		_, reason := lastTransition(runtime)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			runtime.Name,
+			runtime.Spec.Shoot.Name,
+			runtime.Spec.Shoot.Provider.Type,
+			runtime.Spec.Shoot.Region,
+			runtime.Status.State,
+			reason)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	checkpointNamePrefix = "kim-migration-"
+	checkpointLabel      = "operator.kyma-project.io/migration"
+)
+
+// ItemState is stored in the checkpoint for every completed item.
+type ItemState string
+
+const (
+	ItemStateMigrated  ItemState = "Migrated"
+	ItemStateUnchanged ItemState = "Unchanged"
+)
+
+// Checkpoint stores the completed items of a migration in a ConfigMap, so that an interrupted run can be resumed.
+type Checkpoint struct {
+	Client    client.Client
+	Namespace string
+}
+
+// Load returns the completed items of the migration.
+func (c Checkpoint) Load(ctx context.Context, migration string) (map[string]ItemState, error) {
+	var configMap corev1.ConfigMap
+	err := c.Client.Get(ctx, c.key(migration), &configMap)
+	if apierrors.IsNotFound(err) {
+		return map[string]ItemState{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get checkpoint")
+	}
+
+	completed := make(map[string]ItemState, len(configMap.Data))
+	for key, state := range configMap.Data {
+		completed[decodeKey(key)] = ItemState(state)
+	}
+	return completed, nil
+}
+
+// Save stores the state of a completed item.
+func (c Checkpoint) Save(ctx context.Context, migration, item string, state ItemState) error {
+	var configMap corev1.ConfigMap
+	err := c.Client.Get(ctx, c.key(migration), &configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      checkpointNamePrefix + migration,
+				Namespace: c.Namespace,
+				Labels:    map[string]string{checkpointLabel: migration},
+			},
+			Data: map[string]string{encodeKey(item): string(state)},
+		}
+		return errors.Wrap(c.Client.Create(ctx, &configMap), "failed to create checkpoint")
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to get checkpoint")
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[encodeKey(item)] = string(state)
+	return errors.Wrap(c.Client.Update(ctx, &configMap), "failed to update checkpoint")
+}
+
+// Reset removes the checkpoint, so that the next run processes all items again.
+func (c Checkpoint) Reset(ctx context.Context, migration string) error {
+	configMap := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: checkpointNamePrefix + migration, Namespace: c.Namespace}}
+	err := c.Client.Delete(ctx, &configMap)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, "failed to delete checkpoint")
+}
+
+func (c Checkpoint) key(migration string) types.NamespacedName {
+	return types.NamespacedName{Name: checkpointNamePrefix + migration, Namespace: c.Namespace}
+}
+
+// ConfigMap keys must not contain slashes, and underscores are not allowed in Kubernetes resource names.
+func encodeKey(item string) string {
+	return strings.ReplaceAll(item, "/", "_")
+}
+
+func decodeKey(key string) string {
+	return strings.ReplaceAll(key, "_", "/")
+}
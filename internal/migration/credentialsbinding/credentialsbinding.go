@@ -0,0 +1,142 @@
+package credentialsbinding
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/internal/migration"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const Name = "credentials-binding"
+
+// Migration creates a CredentialsBinding for every SecretBinding in the Gardener project namespace.
+// The CredentialsBinding has the name of the SecretBinding, so that Shoots can switch from
+// spec.secretBindingName to spec.credentialsBindingName without other changes.
+type Migration struct {
+	Client    client.Client
+	Namespace string
+}
+
+var _ migration.Migration = Migration{}
+
+func (m Migration) Name() string {
+	return Name
+}
+
+func (m Migration) List(ctx context.Context) ([]migration.Item, error) {
+	var secretBindings gardener.SecretBindingList //nolint:staticcheck
+	if err := m.Client.List(ctx, &secretBindings, client.InNamespace(m.Namespace)); err != nil {
+		return nil, err
+	}
+
+	items := make([]migration.Item, 0, len(secretBindings.Items))
+	for _, secretBinding := range secretBindings.Items {
+		items = append(items, migration.Item{
+			Key:    secretBinding.Namespace + "/" + secretBinding.Name,
+			Object: secretBinding,
+		})
+	}
+	return items, nil
+}
+
+func (m Migration) Plan(ctx context.Context, item migration.Item) (migration.Plan, error) {
+	secretBinding := item.Object.(gardener.SecretBinding) //nolint:staticcheck
+
+	desired, err := toCredentialsBinding(secretBinding)
+	if err != nil {
+		return migration.Plan{}, err
+	}
+
+	var existing gardenersecurity.CredentialsBinding
+	err = m.Client.Get(ctx, client.ObjectKeyFromObject(&desired), &existing)
+	if apierrors.IsNotFound(err) {
+		return migration.Plan{
+			Item:        item,
+			Action:      migration.ActionApply,
+			Description: fmt.Sprintf("create CredentialsBinding %s referencing Secret %s", item.Key, secretReference(desired)),
+			Object:      desired,
+		}, nil
+	}
+	if err != nil {
+		return migration.Plan{}, err
+	}
+
+	if err := matches(existing, desired); err != nil {
+		return migration.Plan{}, errors.Wrap(err, "conflicting CredentialsBinding exists")
+	}
+
+	return migration.Plan{Item: item, Action: migration.ActionNone, Object: desired}, nil
+}
+
+func (m Migration) Apply(ctx context.Context, plan migration.Plan) error {
+	credentialsBinding := plan.Object.(gardenersecurity.CredentialsBinding)
+
+	err := m.Client.Create(ctx, &credentialsBinding)
+	if apierrors.IsAlreadyExists(err) {
+		// created in the meantime, Verify checks whether it matches
+		return nil
+	}
+	return err
+}
+
+func (m Migration) Verify(ctx context.Context, plan migration.Plan) error {
+	desired := plan.Object.(gardenersecurity.CredentialsBinding)
+
+	var existing gardenersecurity.CredentialsBinding
+	if err := m.Client.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing); err != nil {
+		return err
+	}
+	return matches(existing, desired)
+}
+
+func toCredentialsBinding(secretBinding gardener.SecretBinding) (gardenersecurity.CredentialsBinding, error) { //nolint:staticcheck
+	if secretBinding.Provider == nil {
+		return gardenersecurity.CredentialsBinding{}, errors.New("SecretBinding is missing provider type")
+	}
+
+	return gardenersecurity.CredentialsBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gardenersecurity.SchemeGroupVersion.String(),
+			Kind:       "CredentialsBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretBinding.Name,
+			Namespace:   secretBinding.Namespace,
+			Labels:      secretBinding.Labels,
+			Annotations: secretBinding.Annotations,
+		},
+		CredentialsRef: corev1.ObjectReference{
+			Kind:       "Secret",
+			APIVersion: "v1",
+			Namespace:  secretBinding.SecretRef.Namespace,
+			Name:       secretBinding.SecretRef.Name,
+		},
+		Provider: gardenersecurity.CredentialsBindingProvider{
+			Type: secretBinding.Provider.Type,
+		},
+	}, nil
+}
+
+func matches(existing, desired gardenersecurity.CredentialsBinding) error {
+	if existing.CredentialsRef.Kind != desired.CredentialsRef.Kind ||
+		existing.CredentialsRef.Namespace != desired.CredentialsRef.Namespace ||
+		existing.CredentialsRef.Name != desired.CredentialsRef.Name {
+		return fmt.Errorf("CredentialsBinding references %s %s instead of Secret %s", existing.CredentialsRef.Kind, secretReference(existing), secretReference(desired))
+	}
+	if existing.Provider.Type != desired.Provider.Type {
+		return fmt.Errorf("CredentialsBinding has provider type %q instead of %q", existing.Provider.Type, desired.Provider.Type)
+	}
+	return nil
+}
+
+func secretReference(credentialsBinding gardenersecurity.CredentialsBinding) string {
+	return credentialsBinding.CredentialsRef.Namespace + "/" + credentialsBinding.CredentialsRef.Name
+}
@@ -0,0 +1,130 @@
+package credentialsbinding
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/internal/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "garden-kyma"
+
+func TestMigration(t *testing.T) {
+	t.Run("Should plan a CredentialsBinding for every SecretBinding", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).
+			WithObjects(fixSecretBinding("aws-1", "aws"), fixSecretBinding("gcp-1", "gcp")).Build()
+		m := Migration{Client: c, Namespace: namespace}
+
+		// when
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+		plan, err := m.Plan(context.Background(), items[0])
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "garden-kyma/aws-1", items[0].Key)
+		assert.Equal(t, migration.ActionApply, plan.Action)
+		assert.Equal(t, "create CredentialsBinding garden-kyma/aws-1 referencing Secret garden-kyma/secret-aws-1", plan.Description)
+	})
+
+	t.Run("Should create and verify the CredentialsBinding", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(fixSecretBinding("aws-1", "aws")).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+		plan, err := m.Plan(context.Background(), items[0])
+		require.NoError(t, err)
+
+		// when
+		require.NoError(t, m.Apply(context.Background(), plan))
+		err = m.Verify(context.Background(), plan)
+
+		// then
+		require.NoError(t, err)
+		var credentialsBinding gardenersecurity.CredentialsBinding
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "aws-1", Namespace: namespace}, &credentialsBinding))
+		assert.Equal(t, "aws", credentialsBinding.Provider.Type)
+		assert.Equal(t, corev1.ObjectReference{Kind: "Secret", APIVersion: "v1", Namespace: namespace, Name: "secret-aws-1"}, credentialsBinding.CredentialsRef)
+		assert.Equal(t, map[string]string{"purpose": "kyma"}, credentialsBinding.Labels)
+	})
+
+	t.Run("Should not change an existing CredentialsBinding", func(t *testing.T) {
+		// given
+		existing, err := toCredentialsBinding(*fixSecretBinding("aws-1", "aws"))
+		require.NoError(t, err)
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(fixSecretBinding("aws-1", "aws"), &existing).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+
+		// when
+		plan, err := m.Plan(context.Background(), items[0])
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, migration.ActionNone, plan.Action)
+	})
+
+	t.Run("Should fail for a conflicting CredentialsBinding", func(t *testing.T) {
+		// given
+		existing, err := toCredentialsBinding(*fixSecretBinding("aws-1", "gcp"))
+		require.NoError(t, err)
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(fixSecretBinding("aws-1", "aws"), &existing).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+
+		// when
+		_, err = m.Plan(context.Background(), items[0])
+
+		// then
+		require.ErrorContains(t, err, `conflicting CredentialsBinding exists: CredentialsBinding has provider type "gcp" instead of "aws"`)
+	})
+
+	t.Run("Should fail for a SecretBinding without provider", func(t *testing.T) {
+		// given
+		secretBinding := fixSecretBinding("aws-1", "aws")
+		secretBinding.Provider = nil
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(secretBinding).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+
+		// when
+		_, err = m.Plan(context.Background(), items[0])
+
+		// then
+		require.ErrorContains(t, err, "SecretBinding is missing provider type")
+	})
+}
+
+func newScheme(t *testing.T) *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, gardener.AddToScheme(scheme))
+	require.NoError(t, gardenersecurity.AddToScheme(scheme))
+	return scheme
+}
+
+func fixSecretBinding(name, provider string) *gardener.SecretBinding { //nolint:staticcheck
+	return &gardener.SecretBinding{ //nolint:staticcheck
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"purpose": "kyma"},
+		},
+		SecretRef: corev1.SecretReference{Name: "secret-" + name, Namespace: namespace},
+		Provider:  &gardener.SecretBindingProvider{Type: provider}, //nolint:staticcheck
+	}
+}
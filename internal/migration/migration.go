@@ -0,0 +1,45 @@
+package migration
+
+import "context"
+
+// Migration migrates a set of resources item by item. The Runner calls the steps in order:
+// List once, then Plan for every item, and Apply followed by Verify for every item which needs a change.
+// Steps must be idempotent, as an interrupted run is resumed from its last checkpoint.
+type Migration interface {
+	// Name identifies the migration and its checkpoint. It must be a valid Kubernetes resource name.
+	Name() string
+	// List returns the items to migrate.
+	List(ctx context.Context) ([]Item, error)
+	// Plan returns the change required for the item. It must not modify any resource.
+	Plan(ctx context.Context, item Item) (Plan, error)
+	// Apply executes the planned change.
+	Apply(ctx context.Context, plan Plan) error
+	// Verify checks that the item is migrated after the change was applied.
+	Verify(ctx context.Context, plan Plan) error
+}
+
+// Item is a single resource handled by a migration.
+type Item struct {
+	// Key identifies the item in the checkpoint, for example, the namespace and name of the resource.
+	Key string
+	// Object is the migration specific data of the item.
+	Object any
+}
+
+type Action string
+
+const (
+	// ActionNone means that the item is already migrated.
+	ActionNone  Action = "None"
+	ActionApply Action = "Apply"
+)
+
+// Plan describes the change required for an item.
+type Plan struct {
+	Item   Item
+	Action Action
+	// Description is printed in the progress and dry-run report.
+	Description string
+	// Object is the migration specific data used by Apply and Verify.
+	Object any
+}
@@ -0,0 +1,132 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Runner runs migrations item by item and records every completed item in the checkpoint.
+// Items completed in a previous run are skipped, failed items are reported and retried in the next run.
+type Runner struct {
+	Checkpoint Checkpoint
+	// DryRun only plans the changes. The checkpoint is read but not written.
+	DryRun bool
+	// Restart processes all items again. The checkpoint is removed, or ignored in dry run.
+	Restart bool
+	Out     io.Writer
+}
+
+// Report counts the items processed by the Runner.
+type Report struct {
+	Total int
+	// Migrated counts the changed items, or the items which would be changed in dry run.
+	Migrated  int
+	Unchanged int
+	// Resumed counts the items completed in a previous run.
+	Resumed int
+	Failed  int
+}
+
+// Run executes the migration. Failures of single items are reported and don't stop the run.
+func (r Runner) Run(ctx context.Context, migration Migration) (Report, error) {
+	var report Report
+
+	completed, err := r.loadCheckpoint(ctx, migration.Name())
+	if err != nil {
+		return report, err
+	}
+
+	items, err := migration.List(ctx)
+	if err != nil {
+		return report, errors.Wrap(err, "failed to list items")
+	}
+	report.Total = len(items)
+
+	for i, item := range items {
+		progress := fmt.Sprintf("[%d/%d] %s", i+1, len(items), item.Key)
+
+		if state, found := completed[item.Key]; found {
+			report.Resumed++
+			fmt.Fprintf(r.Out, "%s: %s in a previous run\n", progress, state)
+			continue
+		}
+
+		state, description, err := r.migrate(ctx, migration, item)
+		if err != nil {
+			report.Failed++
+			fmt.Fprintf(r.Out, "%s: failed: %v\n", progress, err)
+			continue
+		}
+
+		switch {
+		case state == ItemStateUnchanged:
+			report.Unchanged++
+			fmt.Fprintf(r.Out, "%s: unchanged\n", progress)
+		case r.DryRun:
+			report.Migrated++
+			fmt.Fprintf(r.Out, "%s: would %s\n", progress, description)
+		default:
+			report.Migrated++
+			fmt.Fprintf(r.Out, "%s: %s\n", progress, description)
+		}
+	}
+
+	return report, nil
+}
+
+func (r Runner) loadCheckpoint(ctx context.Context, migration string) (map[string]ItemState, error) {
+	if !r.Restart {
+		return r.Checkpoint.Load(ctx, migration)
+	}
+
+	if !r.DryRun {
+		if err := r.Checkpoint.Reset(ctx, migration); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]ItemState{}, nil
+}
+
+func (r Runner) migrate(ctx context.Context, migration Migration, item Item) (ItemState, string, error) {
+	plan, err := migration.Plan(ctx, item)
+	if err != nil {
+		return "", "", errors.Wrap(err, "plan")
+	}
+
+	state := ItemStateMigrated
+	if plan.Action == ActionNone {
+		state = ItemStateUnchanged
+	}
+
+	if r.DryRun {
+		return state, plan.Description, nil
+	}
+
+	if plan.Action == ActionApply {
+		if err := migration.Apply(ctx, plan); err != nil {
+			return "", "", errors.Wrap(err, "apply")
+		}
+		if err := migration.Verify(ctx, plan); err != nil {
+			return "", "", errors.Wrap(err, "verify")
+		}
+	}
+
+	if err := r.Checkpoint.Save(ctx, migration.Name(), item.Key, state); err != nil {
+		return "", "", err
+	}
+
+	return state, plan.Description, nil
+}
+
+// Print writes the summary of the run.
+func (r Report) Print(w io.Writer, migration string, dryRun bool) {
+	verb := "migrated"
+	if dryRun {
+		verb = "to migrate"
+	}
+	fmt.Fprintf(w, "\n%s: %d items, %s %d, unchanged %d, completed in a previous run %d, failed %d\n",
+		migration, r.Total, verb, r.Migrated, r.Unchanged, r.Resumed, r.Failed)
+}
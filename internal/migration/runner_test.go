@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "garden-kyma"
+
+func TestRunner(t *testing.T) {
+	t.Run("Should migrate the items and record them in the checkpoint", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		m := &testMigration{keys: []string{"ns/a", "ns/b", "ns/c"}, migrated: map[string]bool{"ns/b": true}}
+		var out bytes.Buffer
+		runner := Runner{Checkpoint: Checkpoint{Client: c, Namespace: namespace}, Out: &out}
+
+		// when
+		report, err := runner.Run(context.Background(), m)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Total: 3, Migrated: 2, Unchanged: 1}, report)
+		assert.Equal(t, []string{"ns/a", "ns/c"}, m.applied)
+		assert.Equal(t, "[1/3] ns/a: migrate ns/a\n[2/3] ns/b: unchanged\n[3/3] ns/c: migrate ns/c\n", out.String())
+		assert.Equal(t, map[string]string{"ns_a": "Migrated", "ns_b": "Unchanged", "ns_c": "Migrated"}, getCheckpoint(t, c).Data)
+	})
+
+	t.Run("Should resume from the checkpoint and retry failed items", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(fixCheckpoint(map[string]string{"ns_a": "Migrated"})).Build()
+		m := &testMigration{keys: []string{"ns/a", "ns/b", "ns/c"}, failVerify: map[string]bool{"ns/b": true}}
+		var out bytes.Buffer
+		runner := Runner{Checkpoint: Checkpoint{Client: c, Namespace: namespace}, Out: &out}
+
+		// when
+		report, err := runner.Run(context.Background(), m)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Total: 3, Migrated: 1, Resumed: 1, Failed: 1}, report)
+		assert.Equal(t, []string{"ns/b", "ns/c"}, m.applied)
+		assert.Contains(t, out.String(), "[1/3] ns/a: Migrated in a previous run\n")
+		assert.Contains(t, out.String(), "[2/3] ns/b: failed: verify: not migrated\n")
+		assert.Equal(t, map[string]string{"ns_a": "Migrated", "ns_c": "Migrated"}, getCheckpoint(t, c).Data)
+	})
+
+	t.Run("Should only plan the changes in dry run", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(fixCheckpoint(map[string]string{"ns_a": "Migrated"})).Build()
+		m := &testMigration{keys: []string{"ns/a", "ns/b"}}
+		var out bytes.Buffer
+		runner := Runner{Checkpoint: Checkpoint{Client: c, Namespace: namespace}, DryRun: true, Out: &out}
+
+		// when
+		report, err := runner.Run(context.Background(), m)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Total: 2, Migrated: 1, Resumed: 1}, report)
+		assert.Empty(t, m.applied)
+		assert.Contains(t, out.String(), "[2/2] ns/b: would migrate ns/b\n")
+		assert.Equal(t, map[string]string{"ns_a": "Migrated"}, getCheckpoint(t, c).Data)
+	})
+
+	t.Run("Should process all items again on restart", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(fixCheckpoint(map[string]string{"ns_a": "Migrated", "ns_old": "Migrated"})).Build()
+		m := &testMigration{keys: []string{"ns/a"}}
+		runner := Runner{Checkpoint: Checkpoint{Client: c, Namespace: namespace}, Restart: true, Out: &bytes.Buffer{}}
+
+		// when
+		report, err := runner.Run(context.Background(), m)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, Report{Total: 1, Migrated: 1}, report)
+		assert.Equal(t, map[string]string{"ns_a": "Migrated"}, getCheckpoint(t, c).Data)
+	})
+
+	t.Run("Should fail when the items cannot be listed", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+		m := &testMigration{listErr: errors.New("forbidden")}
+		runner := Runner{Checkpoint: Checkpoint{Client: c, Namespace: namespace}, Out: &bytes.Buffer{}}
+
+		// when
+		_, err := runner.Run(context.Background(), m)
+
+		// then
+		require.ErrorContains(t, err, "forbidden")
+	})
+}
+
+type testMigration struct {
+	keys       []string
+	listErr    error
+	migrated   map[string]bool
+	failVerify map[string]bool
+	applied    []string
+}
+
+func (m *testMigration) Name() string {
+	return "test"
+}
+
+func (m *testMigration) List(_ context.Context) ([]Item, error) {
+	items := make([]Item, 0, len(m.keys))
+	for _, key := range m.keys {
+		items = append(items, Item{Key: key})
+	}
+	return items, m.listErr
+}
+
+func (m *testMigration) Plan(_ context.Context, item Item) (Plan, error) {
+	if m.migrated[item.Key] {
+		return Plan{Item: item, Action: ActionNone}, nil
+	}
+	return Plan{Item: item, Action: ActionApply, Description: "migrate " + item.Key}, nil
+}
+
+func (m *testMigration) Apply(_ context.Context, plan Plan) error {
+	m.applied = append(m.applied, plan.Item.Key)
+	return nil
+}
+
+func (m *testMigration) Verify(_ context.Context, plan Plan) error {
+	if m.failVerify[plan.Item.Key] {
+		return errors.New("not migrated")
+	}
+	return nil
+}
+
+func fixCheckpoint(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kim-migration-test", Namespace: namespace},
+		Data:       data,
+	}
+}
+
+func getCheckpoint(t *testing.T, c client.Client) corev1.ConfigMap {
+	var configMap corev1.ConfigMap
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "kim-migration-test", Namespace: namespace}, &configMap))
+	return configMap
+}
@@ -0,0 +1,105 @@
+package shootcredentialsbinding
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/internal/migration"
+	"github.com/kyma-project/infrastructure-manager/internal/migration/credentialsbinding"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	Name = "shoot-credentials-binding"
+
+	// fieldManager is the field manager of the Runtime controller, which owns the binding fields of the Shoots
+	fieldManager = "kim"
+)
+
+// Migration switches every Shoot in the Gardener project namespace from spec.secretBindingName to
+// spec.credentialsBindingName. Gardener doesn't accept this change in an apply patch, so the Shoot is updated instead.
+// The CredentialsBinding with the name of the SecretBinding must exist, see the credentials-binding migration.
+type Migration struct {
+	Client    client.Client
+	Namespace string
+}
+
+var _ migration.Migration = Migration{}
+
+func (m Migration) Name() string {
+	return Name
+}
+
+func (m Migration) List(ctx context.Context) ([]migration.Item, error) {
+	var shoots gardener.ShootList
+	if err := m.Client.List(ctx, &shoots, client.InNamespace(m.Namespace)); err != nil {
+		return nil, err
+	}
+
+	items := make([]migration.Item, 0, len(shoots.Items))
+	for _, shoot := range shoots.Items {
+		items = append(items, migration.Item{
+			Key:    shoot.Namespace + "/" + shoot.Name,
+			Object: types.NamespacedName{Name: shoot.Name, Namespace: shoot.Namespace},
+		})
+	}
+	return items, nil
+}
+
+func (m Migration) Plan(ctx context.Context, item migration.Item) (migration.Plan, error) {
+	var shoot gardener.Shoot
+	if err := m.Client.Get(ctx, item.Object.(types.NamespacedName), &shoot); err != nil {
+		return migration.Plan{}, err
+	}
+
+	bindingName := ptr.Deref(shoot.Spec.SecretBindingName, "") //nolint:staticcheck
+	if bindingName == "" {
+		return migration.Plan{Item: item, Action: migration.ActionNone}, nil
+	}
+
+	var credentialsBinding gardenersecurity.CredentialsBinding
+	err := m.Client.Get(ctx, types.NamespacedName{Name: bindingName, Namespace: shoot.Namespace}, &credentialsBinding)
+	if apierrors.IsNotFound(err) {
+		return migration.Plan{}, fmt.Errorf("CredentialsBinding %s doesn't exist, run the %s migration first", bindingName, credentialsbinding.Name)
+	}
+	if err != nil {
+		return migration.Plan{}, err
+	}
+
+	return migration.Plan{
+		Item:        item,
+		Action:      migration.ActionApply,
+		Description: fmt.Sprintf("replace SecretBinding %s with CredentialsBinding %s", bindingName, bindingName),
+		Object:      bindingName,
+	}, nil
+}
+
+func (m Migration) Apply(ctx context.Context, plan migration.Plan) error {
+	var shoot gardener.Shoot
+	if err := m.Client.Get(ctx, plan.Item.Object.(types.NamespacedName), &shoot); err != nil {
+		return err
+	}
+
+	shoot.Spec.CredentialsBindingName = ptr.To(plan.Object.(string))
+	shoot.Spec.SecretBindingName = nil //nolint:staticcheck
+
+	return m.Client.Update(ctx, &shoot, &client.UpdateOptions{FieldManager: fieldManager})
+}
+
+func (m Migration) Verify(ctx context.Context, plan migration.Plan) error {
+	var shoot gardener.Shoot
+	if err := m.Client.Get(ctx, plan.Item.Object.(types.NamespacedName), &shoot); err != nil {
+		return err
+	}
+
+	if shoot.Spec.SecretBindingName != nil || ptr.Deref(shoot.Spec.CredentialsBindingName, "") != plan.Object.(string) { //nolint:staticcheck
+		return errors.Errorf("Shoot doesn't reference CredentialsBinding %s", plan.Object.(string))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package shootcredentialsbinding
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/internal/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "garden-kyma"
+
+func TestMigration(t *testing.T) {
+	t.Run("Should replace the SecretBinding of the Shoot with the CredentialsBinding", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).
+			WithObjects(fixShoot("shoot", ptr.To("aws-1"), nil), fixCredentialsBinding("aws-1")).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+		plan, err := m.Plan(context.Background(), items[0])
+		require.NoError(t, err)
+
+		// when
+		require.NoError(t, m.Apply(context.Background(), plan))
+		err = m.Verify(context.Background(), plan)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, migration.ActionApply, plan.Action)
+		assert.Equal(t, "replace SecretBinding aws-1 with CredentialsBinding aws-1", plan.Description)
+
+		var shoot gardener.Shoot
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Name: "shoot", Namespace: namespace}, &shoot))
+		assert.Nil(t, shoot.Spec.SecretBindingName) //nolint:staticcheck
+		assert.Equal(t, ptr.To("aws-1"), shoot.Spec.CredentialsBindingName)
+	})
+
+	t.Run("Should not change a Shoot which uses a CredentialsBinding", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(fixShoot("shoot", nil, ptr.To("aws-1"))).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+
+		// when
+		plan, err := m.Plan(context.Background(), items[0])
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, migration.ActionNone, plan.Action)
+	})
+
+	t.Run("Should fail when the CredentialsBinding doesn't exist", func(t *testing.T) {
+		// given
+		c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(fixShoot("shoot", ptr.To("aws-1"), nil)).Build()
+		m := Migration{Client: c, Namespace: namespace}
+		items, err := m.List(context.Background())
+		require.NoError(t, err)
+
+		// when
+		_, err = m.Plan(context.Background(), items[0])
+
+		// then
+		require.EqualError(t, err, "CredentialsBinding aws-1 doesn't exist, run the credentials-binding migration first")
+	})
+}
+
+func newScheme(t *testing.T) *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	require.NoError(t, gardener.AddToScheme(scheme))
+	require.NoError(t, gardenersecurity.AddToScheme(scheme))
+	return scheme
+}
+
+func fixShoot(name string, secretBindingName, credentialsBindingName *string) client.Object {
+	return &gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gardener.ShootSpec{
+			SecretBindingName:      secretBindingName, //nolint:staticcheck
+			CredentialsBindingName: credentialsBindingName,
+		},
+	}
+}
+
+func fixCredentialsBinding(name string) client.Object {
+	return &gardenersecurity.CredentialsBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+}
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		imv1.AddToScheme,
+		gardener.AddToScheme,
+		gardenersecurity.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	return scheme, nil
+}
+
+// LoadConverterConfig reads the converter configuration the same way as KIM does on startup.
+func LoadConverterConfig(path string) (config.Config, error) {
+	var cfg config.Config
+	err := cfg.Load(func() (io.Reader, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	})
+	return cfg, err
+}
+
+// LoadObjects reads the Kubernetes resources from a YAML or JSON file, which may contain multiple documents.
+func LoadObjects(path string) ([]client.Object, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []client.Object
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		decoded, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+
+		object, ok := decoded.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource in %s: %T", path, decoded)
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// LoadRuntime reads a single Runtime CR from the file.
+func LoadRuntime(path string) (imv1.Runtime, error) {
+	object, err := loadSingle[*imv1.Runtime](path)
+	if err != nil {
+		return imv1.Runtime{}, err
+	}
+	return *object, nil
+}
+
+// LoadShoot reads a single Shoot from the file.
+func LoadShoot(path string) (*gardener.Shoot, error) {
+	return loadSingle[*gardener.Shoot](path)
+}
+
+// LoadCloudProfile reads a single CloudProfile from the file.
+func LoadCloudProfile(path string) (*gardener.CloudProfile, error) {
+	return loadSingle[*gardener.CloudProfile](path)
+}
+
+func loadSingle[T client.Object](path string) (T, error) {
+	var empty T
+
+	objects, err := LoadObjects(path)
+	if err != nil {
+		return empty, err
+	}
+
+	if len(objects) != 1 {
+		return empty, fmt.Errorf("expected a single resource in %s, found %d", path, len(objects))
+	}
+
+	object, ok := objects[0].(T)
+	if !ok {
+		return empty, fmt.Errorf("unexpected resource in %s: %s", path, objects[0].GetObjectKind().GroupVersionKind().Kind)
+	}
+	return object, nil
+}
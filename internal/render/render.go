@@ -0,0 +1,173 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/internal/registrycache"
+	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
+	gardener_shoot "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var ErrCloudProfileRequired = errors.New("a CloudProfile is required when the machine image version strategy is not pinned")
+
+// Options contain the inputs which KIM reads from the KCP and the Gardener cluster when it generates a Shoot.
+type Options struct {
+	Runtime         imv1.Runtime
+	ConverterConfig config.ConverterConfig
+	// AuditLogConfiguration is the shared audit log tenant configuration, nil when not provided
+	AuditLogConfiguration auditlog.Configuration
+	AuditLogMandatory     bool
+	// ExistingShoot switches to the patch conversion, as done for a Shoot which already exists
+	ExistingShoot *gardener.Shoot
+	// CloudProfile provides the machine images when the machine image version strategy is not pinned
+	CloudProfile *gardener.CloudProfile
+	// KcpObjects replace the resources read from the KCP, for example, the API server ACL ConfigMap
+	KcpObjects []client.Object
+	// GardenObjects replace the resources read from the Gardener cluster, for example, the registry cache Secrets
+	// or the bindings of the hyperscaler account in the Gardener projects
+	GardenObjects                   []client.Object
+	ApiServerAclEnabled             bool
+	NetworkRestrictionGlobalEnabled bool
+	RegistryCacheEnabled            bool
+}
+
+// Result is the rendered Shoot, with the warnings KIM would only log during the reconciliation.
+type Result struct {
+	Shoot    gardener.Shoot
+	Warnings []string
+}
+
+// Shoot renders the Shoot which KIM generates for the Runtime without accessing any cluster.
+func Shoot(ctx context.Context, opts Options) (Result, error) {
+	var result Result
+
+	if err := opts.Runtime.ValidateRequiredLabels(); err != nil {
+		return result, err
+	}
+
+	auditLogData, err := getAuditLogData(opts)
+	if err != nil && opts.AuditLogMandatory {
+		return result, err
+	}
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+
+	machineImages, err := getMachineImages(opts)
+	if err != nil {
+		return result, err
+	}
+
+	kcpClient, err := newFakeClient(opts.KcpObjects)
+	if err != nil {
+		return result, err
+	}
+
+	gardenClient, err := newFakeClient(opts.GardenObjects)
+	if err != nil {
+		return result, err
+	}
+
+	// Runtimes outside of the default Gardener project use the binding of the same Secret in their project, as the Runtime controller does
+	resolver := project.BindingResolver{Config: opts.ConverterConfig.Gardener, GardenClient: gardenClient}
+	bindingName, err := resolver.Resolve(ctx, opts.Runtime)
+	if err != nil {
+		return result, err
+	}
+
+	inputs := gardener_shoot.OptsInputs{
+		ConverterConfig:                 opts.ConverterConfig,
+		AuditLogData:                    auditLogData,
+		KcpClient:                       kcpClient,
+		MachineImages:                   machineImages,
+		ApiServerAclEnabled:             opts.ApiServerAclEnabled,
+		NetworkRestrictionGlobalEnabled: opts.NetworkRestrictionGlobalEnabled,
+		BindingName:                     bindingName,
+	}
+
+	var converter gardener_shoot.Converter
+	if opts.ExistingShoot == nil {
+		createOpts, err := gardener_shoot.NewCreateOpts(opts.Runtime, inputs)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to get maintenance window data for region: %v", err))
+		}
+		converter = gardener_shoot.NewConverterCreate(ctx, createOpts)
+	} else {
+		if opts.RegistryCacheEnabled {
+			inputs.RegistryCacheGardenSecretNames, err = getRegistryCacheGardenSecretNames(ctx, gardenClient, opts)
+			if err != nil {
+				return result, err
+			}
+		}
+
+		patchOpts, err := gardener_shoot.NewPatchOpts(opts.Runtime, *opts.ExistingShoot, inputs)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to get maintenance window data for region: %v", err))
+		}
+		converter = gardener_shoot.NewConverterPatch(ctx, patchOpts)
+	}
+
+	shoot, err := converter.ToShoot(opts.Runtime)
+	if err != nil {
+		return result, fmt.Errorf("runtime conversion error: %w", err)
+	}
+
+	shoot.SetGroupVersionKind(gardener.SchemeGroupVersion.WithKind("Shoot"))
+	result.Shoot = shoot
+
+	return result, nil
+}
+
+func getAuditLogData(opts Options) (auditlogs.AuditLogData, error) {
+	if opts.AuditLogConfiguration == nil {
+		return auditlogs.AuditLogData{}, errors.New("audit log configuration not provided")
+	}
+
+	data, err := opts.AuditLogConfiguration.GetAuditLogData(opts.Runtime.Spec.Shoot.Provider.Type, opts.Runtime.Spec.Shoot.Region)
+	if err != nil {
+		return auditlogs.AuditLogData{}, err
+	}
+
+	return auditlogs.AuditLogData{
+		TenantID:   data.TenantID,
+		ServiceURL: data.ServiceURL,
+		SecretName: data.SecretName,
+	}, nil
+}
+
+// getRegistryCacheGardenSecretNames reads the registry cache Secrets from the Gardener project of the Runtime, as the Runtime controller does.
+func getRegistryCacheGardenSecretNames(ctx context.Context, gardenClient client.Client, opts Options) (map[string]string, error) {
+	gardenNamespace := project.NamespaceForRuntime(opts.Runtime, opts.ConverterConfig.Gardener.ProjectName)
+	secretManager := registrycache.NewGardenSecretManager(gardenClient, gardenNamespace, opts.Runtime.Labels[imv1.LabelKymaRuntimeID])
+	return secretManager.GetCacheUIDToSecretNameMap(ctx)
+}
+
+func getMachineImages(opts Options) ([]gardener.MachineImage, error) {
+	if opts.ConverterConfig.MachineImage.IsPinned() {
+		return nil, nil
+	}
+
+	if opts.CloudProfile == nil {
+		return nil, ErrCloudProfileRequired
+	}
+
+	return opts.CloudProfile.Spec.MachineImages, nil
+}
+
+func newFakeClient(objects []client.Object) (client.Client, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), nil
+}
@@ -0,0 +1,223 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	imregistrycache "github.com/kyma-project/infrastructure-manager/internal/registrycache"
+	"github.com/kyma-project/infrastructure-manager/pkg/auditlog"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	registrycache "github.com/kyma-project/registry-cache/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestShoot(t *testing.T) {
+	fixOptions := func(t *testing.T) Options {
+		runtime, err := LoadRuntime("testdata/runtime.yaml")
+		require.NoError(t, err)
+
+		cfg, err := LoadConverterConfig("testdata/converter_config.json")
+		require.NoError(t, err)
+		cfg.ConverterConfig.MaintenanceWindow.WindowMapPath = "testdata/maintenanceWindow.json"
+
+		auditLogConfiguration, err := auditlog.LoadConfiguration("testdata/auditConfig.json")
+		require.NoError(t, err)
+
+		return Options{
+			Runtime:                         runtime,
+			ConverterConfig:                 cfg.ConverterConfig,
+			AuditLogConfiguration:           auditLogConfiguration,
+			AuditLogMandatory:               true,
+			NetworkRestrictionGlobalEnabled: true,
+		}
+	}
+
+	t.Run("Should render the Shoot created for the Runtime", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+
+		// when
+		result, err := Shoot(context.Background(), opts)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, result.Warnings)
+
+		shoot := result.Shoot
+		assert.Equal(t, "Shoot", shoot.Kind)
+		assert.Equal(t, "shoot-name", shoot.Name)
+		assert.Equal(t, "garden-kyma-dev", shoot.Namespace)
+		assert.Equal(t, "1.33", shoot.Spec.Kubernetes.Version)
+		assert.Equal(t, "1592.1.0", *shoot.Spec.Provider.Workers[0].Machine.Image.Version)
+		assert.Equal(t, &gardener.MaintenanceTimeWindow{Begin: "200000+0000", End: "000000+0000"}, shoot.Spec.Maintenance.TimeWindow)
+		assert.NotNil(t, findExtension(shoot, "shoot-auditlog-service"))
+		assert.Nil(t, findExtension(shoot, "acl"))
+	})
+
+	t.Run("Should render the Shoot patched for the Runtime", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		existingShoot, err := LoadShoot("testdata/shoot.yaml")
+		require.NoError(t, err)
+		opts.ExistingShoot = existingShoot
+
+		// when
+		result, err := Shoot(context.Background(), opts)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.33.5", result.Shoot.Spec.Kubernetes.Version)
+		assert.Equal(t, "1592.4.0", *result.Shoot.Spec.Provider.Workers[0].Machine.Image.Version)
+	})
+
+	t.Run("Should render the Shoot in the Gardener project of the Runtime", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		opts.Runtime.Annotations = map[string]string{imv1.AnnotationGardenerProject: "kyma-dev-2"}
+		opts.GardenObjects = []client.Object{
+			&gardenersecurity.CredentialsBinding{
+				ObjectMeta:     metav1.ObjectMeta{Name: "hyperscaler-secret", Namespace: "garden-kyma-dev"},
+				CredentialsRef: corev1.ObjectReference{Kind: "Secret", APIVersion: "v1", Name: "hyperscaler-account", Namespace: "garden-kyma-dev"},
+			},
+			&gardenersecurity.CredentialsBinding{
+				ObjectMeta:     metav1.ObjectMeta{Name: "hyperscaler-secret-shared", Namespace: "garden-kyma-dev-2"},
+				CredentialsRef: corev1.ObjectReference{Kind: "Secret", APIVersion: "v1", Name: "hyperscaler-account", Namespace: "garden-kyma-dev"},
+			},
+		}
+
+		// when
+		result, err := Shoot(context.Background(), opts)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "garden-kyma-dev-2", result.Shoot.Namespace)
+		assert.Equal(t, "hyperscaler-secret-shared", *result.Shoot.Spec.CredentialsBindingName)
+	})
+
+	t.Run("Should reference the registry cache Secrets from the provided Gardener resources", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		existingShoot, err := LoadShoot("testdata/shoot.yaml")
+		require.NoError(t, err)
+		opts.ExistingShoot = existingShoot
+		opts.RegistryCacheEnabled = true
+		opts.Runtime.Spec.Caching = []imv1.ImageRegistryCache{{
+			Name:   "cache",
+			UID:    "cache-uid",
+			Config: registrycache.RegistryCacheConfigSpec{SecretReferenceName: ptr.To("cache-credentials")},
+		}}
+		opts.GardenObjects = []client.Object{&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reg-cache-abcde",
+				Namespace: "garden-kyma-dev",
+				Labels: map[string]string{
+					imregistrycache.RuntimeSecretLabel: "runtime-id",
+					imregistrycache.ManagedByLabel:     imregistrycache.ManagedByValue,
+					imregistrycache.CacheIDLabel:       "cache-uid",
+				},
+			},
+		}}
+
+		// when
+		result, err := Shoot(context.Background(), opts)
+
+		// then
+		require.NoError(t, err)
+		require.NotEmpty(t, result.Shoot.Spec.Resources)
+		assert.Equal(t, "reg-cache-abcde", result.Shoot.Spec.Resources[len(result.Shoot.Spec.Resources)-1].ResourceRef.Name)
+	})
+
+	t.Run("Should read the API server ACL from the provided KCP resources", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		opts.ApiServerAclEnabled = true
+		opts.KcpObjects, _ = LoadObjects("testdata/acl-configmap.yaml")
+
+		// when
+		result, err := Shoot(context.Background(), opts)
+
+		// then
+		require.NoError(t, err)
+		acl := findExtension(result.Shoot, "acl")
+		require.NotNil(t, acl)
+		assert.Contains(t, string(acl.ProviderConfig.Raw), "1.1.1.1/32")
+	})
+
+	t.Run("Should fail when the ACL ConfigMap is not provided", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		opts.ApiServerAclEnabled = true
+
+		// when
+		_, err := Shoot(context.Background(), opts)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("Should fail without audit log tenant when audit log is mandatory", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		opts.AuditLogConfiguration = nil
+
+		// when
+		_, err := Shoot(context.Background(), opts)
+
+		// then
+		require.Error(t, err)
+	})
+
+	t.Run("Should warn about a missing audit log tenant when audit log is not mandatory", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		opts.AuditLogConfiguration = auditlog.Configuration{}
+		opts.AuditLogMandatory = false
+
+		// when
+		result, err := Shoot(context.Background(), opts)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "missing providerType")
+	})
+
+	t.Run("Should require a CloudProfile when the machine image version is not pinned", func(t *testing.T) {
+		// given
+		opts := fixOptions(t)
+		opts.ConverterConfig.MachineImage.VersionStrategy = config.MachineImageVersionLatestSupported
+
+		// when
+		_, err := Shoot(context.Background(), opts)
+
+		// then
+		require.ErrorIs(t, err, ErrCloudProfileRequired)
+	})
+}
+
+func TestLoadObjects(t *testing.T) {
+	t.Run("Should reject a file with a different resource", func(t *testing.T) {
+		// when
+		_, err := LoadShoot("testdata/runtime.yaml")
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func findExtension(shoot gardener.Shoot, extensionType string) *gardener.Extension {
+	for _, extension := range shoot.Spec.Extensions {
+		if extension.Type == extensionType {
+			return &extension
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/maintenance"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/provider"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/networking"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookOptions selects which admission webhooks for Runtime CRs are registered in the manager.
+type WebhookOptions struct {
+	ValidationEnabled bool
+	DefaultingEnabled bool
+}
+
+// SetupRuntimeWebhookWithManager registers the admission webhooks for Runtime CRs in the manager.
+// The conversion webhook between the Runtime API versions is registered as well, if the v2 API is part of the manager scheme.
+// The webhooks read the converter configuration from the holder, so that they follow reloads of the configuration.
+func SetupRuntimeWebhookWithManager(mgr ctrl.Manager, configHolder *config.Holder, opts WebhookOptions) error {
+	builder := ctrl.NewWebhookManagedBy(mgr, &imv1.Runtime{})
+
+	if opts.ValidationEnabled {
+		builder = builder.WithValidator(&RuntimeValidator{ConfigHolder: configHolder})
+	}
+
+	if opts.DefaultingEnabled {
+		builder = builder.WithDefaulter(&RuntimeDefaulter{ConfigHolder: configHolder})
+	}
+
+	return builder.Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructuremanager-kyma-project-io-v1-runtime,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructuremanager.kyma-project.io,resources=runtimes,verbs=create,versions=v1,name=mruntime-v1.kb.io,admissionReviewVersions=v1
+
+// RuntimeDefaulter writes the defaults applied by the Shoot converter into the spec of newly created Runtime CRs.
+// Thanks to that the Runtime CR shows what is actually requested from Gardener, and later changes of
+// `converter_config.json` do not silently change already existing Runtimes.
+// Defaults are applied on create only; existing Runtimes are left untouched.
+type RuntimeDefaulter struct {
+	ConverterConfig config.ConverterConfig
+	// ConfigHolder takes precedence over ConverterConfig when set
+	ConfigHolder *config.Holder
+}
+
+var _ admission.Defaulter[*imv1.Runtime] = &RuntimeDefaulter{}
+
+func (d *RuntimeDefaulter) Default(_ context.Context, runtime *imv1.Runtime) error {
+	shoot := &runtime.Spec.Shoot
+	converterConfig := d.converterConfig()
+
+	if shoot.Kubernetes.Version == nil || *shoot.Kubernetes.Version == "" {
+		shoot.Kubernetes.Version = ptr.To(converterConfig.Kubernetes.DefaultVersion)
+	}
+
+	if err := applyWorkerDefaults(shoot.Provider.Workers, converterConfig); err != nil {
+		return err
+	}
+
+	if shoot.Provider.AdditionalWorkers != nil {
+		return applyWorkerDefaults(*shoot.Provider.AdditionalWorkers, converterConfig)
+	}
+
+	return nil
+}
+
+func (d *RuntimeDefaulter) converterConfig() config.ConverterConfig {
+	if d.ConfigHolder != nil {
+		return d.ConfigHolder.Get().ConverterConfig
+	}
+	return d.ConverterConfig
+}
+
+func applyWorkerDefaults(workers []gardener.Worker, converterConfig config.ConverterConfig) error {
+	if err := provider.ApplyWorkerDefaults(workers, converterConfig.MachineImage, converterConfig.Provider.Worker); err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("failed to apply worker defaults: %v", err))
+	}
+
+	if err := extender.ApplyGVisorNetRawDefault(workers); err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("failed to apply gVisor defaults: %v", err))
+	}
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructuremanager-kyma-project-io-v1-runtime,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructuremanager.kyma-project.io,resources=runtimes,verbs=create;update,versions=v1,name=vruntime-v1.kb.io,admissionReviewVersions=v1
+
+// RuntimeValidator rejects Runtime CRs which would fail the conversion to a Gardener Shoot.
+// It reuses the checks executed by the Shoot converter, so that broker mistakes are reported at admission time
+// instead of ending up as a Failed Runtime with the ConversionErr reason.
+type RuntimeValidator struct {
+	ConverterConfig config.ConverterConfig
+	// ConfigHolder takes precedence over ConverterConfig when set
+	ConfigHolder *config.Holder
+}
+
+var _ admission.Validator[*imv1.Runtime] = &RuntimeValidator{}
+
+func (v *RuntimeValidator) ValidateCreate(_ context.Context, runtime *imv1.Runtime) (admission.Warnings, error) {
+	if gardenerProject := runtime.Annotations[imv1.AnnotationGardenerProject]; gardenerProject != "" {
+		projects := v.converterConfig().Gardener.AllProjects()
+		if !slices.Contains(projects, gardenerProject) {
+			return nil, apierrors.NewInvalid(imv1.GroupVersion.WithKind("Runtime").GroupKind(), runtime.Name, field.ErrorList{
+				field.NotSupported(gardenerProjectPath, gardenerProject, projects),
+			})
+		}
+	}
+
+	return nil, v.validate(runtime)
+}
+
+func (v *RuntimeValidator) ValidateUpdate(_ context.Context, oldRuntime, newRuntime *imv1.Runtime) (admission.Warnings, error) {
+	// The Shoot of the Runtime exists in the Gardener project, so the project must not change even when the Runtime is being deleted
+	if err := validateGardenerProjectUnchanged(oldRuntime, newRuntime); err != nil {
+		return nil, apierrors.NewInvalid(imv1.GroupVersion.WithKind("Runtime").GroupKind(), newRuntime.Name, field.ErrorList{err})
+	}
+
+	// Runtimes being deleted must stay updatable, otherwise the finalizer could never be removed
+	if !newRuntime.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	// Updates which do not touch the spec or labels (finalizers, annotations) are not validated,
+	// so that Runtimes created before the webhook was enabled can still be processed
+	if equality.Semantic.DeepEqual(oldRuntime.Spec, newRuntime.Spec) &&
+		equality.Semantic.DeepEqual(oldRuntime.Labels, newRuntime.Labels) {
+		return nil, nil
+	}
+
+	return nil, v.validate(newRuntime)
+}
+
+func (v *RuntimeValidator) ValidateDelete(_ context.Context, _ *imv1.Runtime) (admission.Warnings, error) {
+	return nil, nil
+}
+
+var gardenerProjectPath = field.NewPath("metadata", "annotations").Key(imv1.AnnotationGardenerProject)
+
+// validateGardenerProjectUnchanged allows setting the Gardener project only together with the finalizer, which is what
+// the Runtime controller does when it places a new Runtime. Runtimes with the finalizer and without the annotation
+// were created before the placement and stay in the default project.
+func validateGardenerProjectUnchanged(oldRuntime, newRuntime *imv1.Runtime) *field.Error {
+	oldProject := oldRuntime.Annotations[imv1.AnnotationGardenerProject]
+	newProject := newRuntime.Annotations[imv1.AnnotationGardenerProject]
+	if oldProject == newProject {
+		return nil
+	}
+
+	if oldProject == "" && len(oldRuntime.Finalizers) == 0 {
+		return nil
+	}
+
+	return field.Forbidden(gardenerProjectPath, fmt.Sprintf("the Gardener project %q of the Runtime can't be changed", oldProject))
+}
+
+func (v *RuntimeValidator) converterConfig() config.ConverterConfig {
+	if v.ConfigHolder != nil {
+		return v.ConfigHolder.Get().ConverterConfig
+	}
+	return v.ConverterConfig
+}
+
+func (v *RuntimeValidator) validate(runtime *imv1.Runtime) error {
+	var allErrs field.ErrorList
+
+	if err := runtime.ValidateRequiredLabels(); err != nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "labels"), err.Error()))
+	}
+
+	shootPath := field.NewPath("spec", "shoot")
+	allErrs = append(allErrs, validateNetworking(runtime.Spec.Shoot.Networking, shootPath.Child("networking"))...)
+	allErrs = append(allErrs, validateACL(runtime.Spec.Shoot.Kubernetes.KubeAPIServer.ACL, shootPath.Child("kubernetes", "kubeAPIServer", "acl"))...)
+	allErrs = append(allErrs, validateMaintenance(runtime.Spec.Shoot.Maintenance, shootPath.Child("maintenance"))...)
+
+	converterConfig := v.converterConfig()
+	if err := provider.ValidateProvider(*runtime, converterConfig.Networking.EnableDualStackIP, converterConfig.Provider.GDCH); err != nil {
+		allErrs = append(allErrs, field.Invalid(shootPath.Child("provider"), runtime.Spec.Shoot.Provider.Type, err.Error()))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(imv1.GroupVersion.WithKind("Runtime").GroupKind(), runtime.Name, allErrs)
+}
+
+func validateNetworking(nw imv1.Networking, path *field.Path) field.ErrorList {
+	err := networking.ValidateNonOverlappingCIDRs(
+		networking.NamedCIDR{Name: "nodes", CIDR: nw.Nodes},
+		networking.NamedCIDR{Name: "pods", CIDR: nw.Pods},
+		networking.NamedCIDR{Name: "services", CIDR: nw.Services},
+	)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, fmt.Sprintf("nodes=%s, pods=%s, services=%s", nw.Nodes, nw.Pods, nw.Services), err.Error())}
+	}
+
+	return nil
+}
+
+func validateMaintenance(m *imv1.Maintenance, path *field.Path) field.ErrorList {
+	if m == nil || m.TimeWindow == nil {
+		return nil
+	}
+
+	if err := maintenance.ValidateTimeWindow(*m.TimeWindow); err != nil {
+		return field.ErrorList{field.Invalid(path.Child("timeWindow"), fmt.Sprintf("begin=%s, end=%s", m.TimeWindow.Begin, m.TimeWindow.End), err.Error())}
+	}
+
+	return nil
+}
+
+func validateACL(acl *imv1.ACL, path *field.Path) field.ErrorList {
+	if acl == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for i, cidr := range acl.AllowedCIDRs {
+		if err := networking.ValidateCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("allowedCIDRs").Index(i), cidr, err.Error()))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,340 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRuntimeValidator_ValidateCreate(t *testing.T) {
+	validator := &RuntimeValidator{ConverterConfig: config.ConverterConfig{
+		Gardener: config.GardenerConfig{ProjectName: "kyma", Projects: []string{"kyma-2"}},
+	}}
+
+	for tname, tcase := range map[string]struct {
+		modify         func(rt *imv1.Runtime)
+		expectedErrors []string
+	}{
+		"Should accept valid Runtime": {
+			modify: func(_ *imv1.Runtime) {},
+		},
+		"Should reject Runtime with missing required label": {
+			modify: func(rt *imv1.Runtime) {
+				delete(rt.Labels, imv1.LabelKymaGlobalAccountID)
+			},
+			expectedErrors: []string{"metadata.labels", "missing required label kyma-project.io/global-account-id"},
+		},
+		"Should reject Runtime with two main workers": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers = append(rt.Spec.Shoot.Provider.Workers, rt.Spec.Shoot.Provider.Workers[0])
+			},
+			expectedErrors: []string{"spec.shoot.provider", "single main worker is required"},
+		},
+		"Should reject Runtime with malformed nodes CIDR": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Networking.Nodes = "10.250.0.0"
+			},
+			expectedErrors: []string{"spec.shoot.networking", `invalid nodes CIDR "10.250.0.0"`},
+		},
+		"Should reject Runtime with overlapping pods and services CIDRs": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Networking.Services = "100.64.0.0/13"
+			},
+			expectedErrors: []string{"spec.shoot.networking", "pods CIDR \"100.64.0.0/12\" overlaps with services CIDR \"100.64.0.0/13\""},
+		},
+		"Should reject Runtime with malformed ACL CIDR": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Kubernetes.KubeAPIServer.ACL = &imv1.ACL{AllowedCIDRs: []string{"192.168.0.0/24", "invalid"}}
+			},
+			expectedErrors: []string{"spec.shoot.kubernetes.kubeAPIServer.acl.allowedCIDRs[1]"},
+		},
+		"Should reject Runtime with too short maintenance time window": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Maintenance = &imv1.Maintenance{TimeWindow: &gardener.MaintenanceTimeWindow{Begin: "220000+0100", End: "221000+0100"}}
+			},
+			expectedErrors: []string{"spec.shoot.maintenance.timeWindow", "maintenance time window must be between 30m0s and 6h0m0s long"},
+		},
+		"Should reject Runtime exceeding provider zone limits": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Spec.Shoot.Provider.Workers[0].Zones = []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+			},
+			expectedErrors: []string{"spec.shoot.provider", "Number of networking zones must be between 1 and 8"},
+		},
+		"Should accept Runtime in a configured Gardener project": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Annotations = map[string]string{imv1.AnnotationGardenerProject: "kyma-2"}
+			},
+		},
+		"Should reject Runtime in a Gardener project which is not configured": {
+			modify: func(rt *imv1.Runtime) {
+				rt.Annotations = map[string]string{imv1.AnnotationGardenerProject: "other"}
+			},
+			expectedErrors: []string{"metadata.annotations[operator.kyma-project.io/gardener-project]", `Unsupported value: "other"`},
+		},
+		"Should report all violations at once": {
+			modify: func(rt *imv1.Runtime) {
+				delete(rt.Labels, imv1.LabelKymaRuntimeID)
+				rt.Spec.Shoot.Networking.Pods = "10.250.0.0/16"
+			},
+			expectedErrors: []string{"missing required label kyma-project.io/runtime-id", "nodes CIDR \"10.250.0.0/16\" overlaps with pods CIDR \"10.250.0.0/16\""},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			rt := fixValidRuntime()
+			tcase.modify(rt)
+
+			// when
+			_, err := validator.ValidateCreate(context.Background(), rt)
+
+			// then
+			if len(tcase.expectedErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			for _, expected := range tcase.expectedErrors {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestRuntimeValidator_ValidateUpdate(t *testing.T) {
+	validator := &RuntimeValidator{ConverterConfig: config.ConverterConfig{}}
+
+	t.Run("Should reject spec change which makes Runtime invalid", func(t *testing.T) {
+		// given
+		oldRuntime := fixValidRuntime()
+		newRuntime := fixValidRuntime()
+		newRuntime.Spec.Shoot.Provider.Workers = nil
+
+		// when
+		_, err := validator.ValidateUpdate(context.Background(), oldRuntime, newRuntime)
+
+		// then
+		assert.ErrorContains(t, err, "single main worker is required")
+	})
+
+	t.Run("Should accept update without spec and label changes of invalid Runtime", func(t *testing.T) {
+		// given
+		oldRuntime := fixValidRuntime()
+		oldRuntime.Spec.Shoot.Networking.Nodes = "invalid"
+		newRuntime := oldRuntime.DeepCopy()
+		newRuntime.Finalizers = []string{imv1.Finalizer}
+
+		// when
+		_, err := validator.ValidateUpdate(context.Background(), oldRuntime, newRuntime)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should accept Gardener project set together with the finalizer", func(t *testing.T) {
+		// given
+		oldRuntime := fixValidRuntime()
+		newRuntime := oldRuntime.DeepCopy()
+		newRuntime.Finalizers = []string{imv1.Finalizer}
+		newRuntime.Annotations = map[string]string{imv1.AnnotationGardenerProject: "kyma-2"}
+
+		// when
+		_, err := validator.ValidateUpdate(context.Background(), oldRuntime, newRuntime)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	for tname, modify := range map[string]func(rt *imv1.Runtime){
+		"Should reject change of the Gardener project": func(rt *imv1.Runtime) {
+			rt.Annotations[imv1.AnnotationGardenerProject] = "kyma"
+		},
+		"Should reject removal of the Gardener project": func(rt *imv1.Runtime) {
+			delete(rt.Annotations, imv1.AnnotationGardenerProject)
+		},
+		"Should reject change of the Gardener project of Runtime being deleted": func(rt *imv1.Runtime) {
+			rt.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			rt.Annotations[imv1.AnnotationGardenerProject] = "kyma"
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			oldRuntime := fixValidRuntime()
+			oldRuntime.Finalizers = []string{imv1.Finalizer}
+			oldRuntime.Annotations = map[string]string{imv1.AnnotationGardenerProject: "kyma-2"}
+			newRuntime := oldRuntime.DeepCopy()
+			modify(newRuntime)
+
+			// when
+			_, err := validator.ValidateUpdate(context.Background(), oldRuntime, newRuntime)
+
+			// then
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, `the Gardener project "kyma-2" of the Runtime can't be changed`)
+		})
+	}
+
+	t.Run("Should reject Gardener project added to Runtime placed before", func(t *testing.T) {
+		// given
+		oldRuntime := fixValidRuntime()
+		oldRuntime.Finalizers = []string{imv1.Finalizer}
+		newRuntime := oldRuntime.DeepCopy()
+		newRuntime.Annotations = map[string]string{imv1.AnnotationGardenerProject: "kyma-2"}
+
+		// when
+		_, err := validator.ValidateUpdate(context.Background(), oldRuntime, newRuntime)
+
+		// then
+		assert.ErrorContains(t, err, `the Gardener project "" of the Runtime can't be changed`)
+	})
+
+	t.Run("Should accept any update of Runtime being deleted", func(t *testing.T) {
+		// given
+		oldRuntime := fixValidRuntime()
+		newRuntime := fixValidRuntime()
+		newRuntime.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		newRuntime.Spec.Shoot.Networking.Nodes = "invalid"
+
+		// when
+		_, err := validator.ValidateUpdate(context.Background(), oldRuntime, newRuntime)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestRuntimeDefaulter_Default(t *testing.T) {
+	defaulter := &RuntimeDefaulter{ConverterConfig: config.ConverterConfig{
+		Kubernetes:   config.KubernetesConfig{DefaultVersion: "1.33"},
+		MachineImage: config.MachineImageConfig{DefaultName: "gardenlinux", DefaultVersion: "1877.1.0"},
+		Provider: config.ProviderConfig{
+			Worker: config.WorkerConfig{DefaultMachineDrainTimeout: "30m", DefaultMaxEvictRetries: "5"},
+		},
+	}}
+
+	t.Run("Should materialise converter defaults in Runtime spec", func(t *testing.T) {
+		// given
+		rt := fixValidRuntime()
+		rt.Spec.Shoot.Provider.Workers[0].Machine.Image = nil
+		rt.Spec.Shoot.Provider.AdditionalWorkers = &[]gardener.Worker{{
+			Name:    "additional",
+			Machine: gardener.Machine{Type: "m6i.large"},
+			CRI: &gardener.CRI{
+				Name:              gardener.CRINameContainerD,
+				ContainerRuntimes: []gardener.ContainerRuntime{{Type: "gvisor"}},
+			},
+		}}
+
+		// when
+		err := defaulter.Default(context.Background(), rt)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, rt.Spec.Shoot.Kubernetes.Version)
+		assert.Equal(t, "1.33", *rt.Spec.Shoot.Kubernetes.Version)
+
+		workers := append(rt.Spec.Shoot.Provider.Workers, *rt.Spec.Shoot.Provider.AdditionalWorkers...)
+		for _, worker := range workers {
+			require.NotNil(t, worker.Machine.Image)
+			assert.Equal(t, "gardenlinux", worker.Machine.Image.Name)
+			assert.Equal(t, "1877.1.0", *worker.Machine.Image.Version)
+			require.NotNil(t, worker.MachineControllerManagerSettings)
+			assert.Equal(t, 30*time.Minute, worker.MachineControllerManagerSettings.MachineDrainTimeout.Duration)
+			assert.Equal(t, int32(5), *worker.MachineControllerManagerSettings.MaxEvictRetries)
+		}
+
+		gvisorConfig := (*rt.Spec.Shoot.Provider.AdditionalWorkers)[0].CRI.ContainerRuntimes[0].ProviderConfig
+		require.NotNil(t, gvisorConfig)
+		assert.Contains(t, string(gvisorConfig.Raw), `"net-raw":"true"`)
+	})
+
+	t.Run("Should keep values requested in Runtime spec", func(t *testing.T) {
+		// given
+		rt := fixValidRuntime()
+		rt.Spec.Shoot.Kubernetes.Version = ptr.To("1.32")
+
+		// when
+		err := defaulter.Default(context.Background(), rt)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.32", *rt.Spec.Shoot.Kubernetes.Version)
+		assert.Equal(t, "1592.1.0", *rt.Spec.Shoot.Provider.Workers[0].Machine.Image.Version)
+	})
+
+	t.Run("Should reject Runtime when defaults cannot be applied", func(t *testing.T) {
+		// given
+		rt := fixValidRuntime()
+		invalidDefaulter := &RuntimeDefaulter{ConverterConfig: config.ConverterConfig{
+			Provider: config.ProviderConfig{Worker: config.WorkerConfig{DefaultMachineDrainTimeout: "invalid"}},
+		}}
+
+		// when
+		err := invalidDefaulter.Default(context.Background(), rt)
+
+		// then
+		require.Error(t, err)
+		assert.True(t, apierrors.IsBadRequest(err))
+	})
+}
+
+func fixValidRuntime() *imv1.Runtime {
+	machineImageVersion := "1592.1.0"
+
+	return &imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runtime-id",
+			Namespace: "kcp-system",
+			Labels: map[string]string{
+				imv1.LabelKymaInstanceID:      "instance-id",
+				imv1.LabelKymaRuntimeID:       "runtime-id",
+				imv1.LabelKymaRegion:          "eu-central-1",
+				imv1.LabelKymaName:            "kyma-name",
+				imv1.LabelKymaBrokerPlanID:    "broker-plan-id",
+				imv1.LabelKymaBrokerPlanName:  "aws",
+				imv1.LabelKymaGlobalAccountID: "global-account-id",
+				imv1.LabelKymaSubaccountID:    "subaccount-id",
+			},
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{
+				Name:   "test-shoot",
+				Region: "eu-central-1",
+				Provider: imv1.Provider{
+					Type: "aws",
+					Workers: []gardener.Worker{
+						{
+							Name: "cpu-worker-0",
+							Machine: gardener.Machine{
+								Type: "m6i.large",
+								Image: &gardener.ShootMachineImage{
+									Name:    "gardenlinux",
+									Version: &machineImageVersion,
+								},
+							},
+							Minimum: 1,
+							Maximum: 3,
+							Zones:   []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"},
+						},
+					},
+				},
+				Networking: imv1.Networking{
+					Nodes:    "10.250.0.0/16",
+					Pods:     "100.64.0.0/12",
+					Services: "100.104.0.0/13",
+				},
+			},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"io"
+	"slices"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 )
@@ -85,12 +86,64 @@ type MaintenanceWindowConfig struct {
 type GardenerConfig struct {
 	ProjectName             string `json:"projectName" validate:"required"`
 	EnableCredentialBinding bool   `json:"enableCredentialBinding"`
+	// Projects are the additional Gardener projects new runtimes can be placed in
+	Projects  []string        `json:"projects" validate:"dive,required"`
+	Placement PlacementConfig `json:"placement"`
 }
 
+// PlacementPolicy defines how the Gardener project of a new runtime is chosen
+type PlacementPolicy string
+
+const (
+	// PlacementByLabel uses the project named by the `labelKey` label of the Runtime CR
+	PlacementByLabel PlacementPolicy = "label"
+	// PlacementByRegion uses the project mapped to the region of the Shoot in `regions`
+	PlacementByRegion PlacementPolicy = "region"
+	// PlacementLeastLoaded uses the project with the fewest runtimes
+	PlacementLeastLoaded PlacementPolicy = "least-loaded"
+)
+
+type PlacementConfig struct {
+	Policy   PlacementPolicy   `json:"policy" validate:"omitempty,oneof=label region least-loaded"`
+	LabelKey string            `json:"labelKey" validate:"required_if=Policy label"`
+	Regions  map[string]string `json:"regions" validate:"required_if=Policy region"`
+}
+
+// AllProjects returns the default project followed by the additional projects
+func (c GardenerConfig) AllProjects() []string {
+	projects := []string{c.ProjectName}
+	for _, project := range c.Projects {
+		if !slices.Contains(projects, project) {
+			projects = append(projects, project)
+		}
+	}
+	return projects
+}
+
+// MachineImageVersionStrategy defines how the version of the default machine image is chosen
+type MachineImageVersionStrategy string
+
+const (
+	// MachineImageVersionPinned uses `defaultVersion` without looking at the CloudProfile
+	MachineImageVersionPinned MachineImageVersionStrategy = "pinned"
+	// MachineImageVersionLatestSupported uses the highest supported, not expired version offered by the CloudProfile
+	MachineImageVersionLatestSupported MachineImageVersionStrategy = "latest-supported"
+	// MachineImageVersionLatestPatch uses the highest not expired version of `minorVersion` offered by the CloudProfile
+	MachineImageVersionLatestPatch MachineImageVersionStrategy = "latest-patch"
+)
+
 type MachineImageConfig struct {
-	DefaultName    string `json:"defaultName" validate:"required"`
-	DefaultVersion string `json:"defaultVersion" validate:"required"`
+	DefaultName     string                      `json:"defaultName" validate:"required"`
+	DefaultVersion  string                      `json:"defaultVersion" validate:"required_without=VersionStrategy,required_if=VersionStrategy pinned"`
+	VersionStrategy MachineImageVersionStrategy `json:"versionStrategy" validate:"omitempty,oneof=pinned latest-supported latest-patch"`
+	MinorVersion    string                      `json:"minorVersion" validate:"required_if=VersionStrategy latest-patch"`
 }
+
+// IsPinned returns true when the default machine image version is taken from `defaultVersion`
+func (c MachineImageConfig) IsPinned() bool {
+	return c.VersionStrategy == "" || c.VersionStrategy == MachineImageVersionPinned
+}
+
 type ACL struct {
 	ConfigMapName string `json:"configMapName"`
 }
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// Holder keeps the configuration which is currently in use and allows replacing it while the manager is running.
+// A new configuration is validated before it is swapped in, so readers always get a complete and valid configuration.
+// A configuration can also be staged: it is then used only by the readers asking for its revision, until it is promoted.
+type Holder struct {
+	current  atomic.Pointer[loadedConfig]
+	validate func(Config) error
+	mutators []func(*Config)
+
+	stagedLock sync.RWMutex
+	staged     map[string]*loadedConfig
+}
+
+type loadedConfig struct {
+	config Config
+	hash   string
+}
+
+// NewHolder creates an empty Holder. The mutators are applied to every loaded configuration before it is validated.
+func NewHolder(validate func(Config) error, mutators ...func(*Config)) *Holder {
+	return &Holder{
+		validate: validate,
+		mutators: mutators,
+	}
+}
+
+// NewStaticHolder creates a Holder with a configuration which is never reloaded.
+func NewStaticHolder(cfg Config) *Holder {
+	h := &Holder{}
+	h.current.Store(&loadedConfig{config: cfg})
+	return h
+}
+
+// Load reads, validates, and swaps in a new configuration. The current configuration is kept when an error is returned.
+func (h *Holder) Load(f ReaderGetter) error {
+	loaded, err := h.read(f)
+	if err != nil {
+		return err
+	}
+
+	h.current.Store(loaded)
+	return nil
+}
+
+// Stage reads and validates a new configuration and keeps it next to the current one. It returns the revision of the configuration,
+// which is the hash of its content. The staged configuration is returned by GetRevision until it is promoted or discarded.
+func (h *Holder) Stage(f ReaderGetter) (string, error) {
+	loaded, err := h.read(f)
+	if err != nil {
+		return "", err
+	}
+
+	if loaded.hash == h.Hash() {
+		return loaded.hash, nil
+	}
+
+	h.stagedLock.Lock()
+	defer h.stagedLock.Unlock()
+
+	if h.staged == nil {
+		h.staged = map[string]*loadedConfig{}
+	}
+	h.staged[loaded.hash] = loaded
+	return loaded.hash, nil
+}
+
+// Promote makes the staged revision the current configuration. It returns false when the revision is not staged.
+func (h *Holder) Promote(revision string) bool {
+	h.stagedLock.Lock()
+	defer h.stagedLock.Unlock()
+
+	loaded, found := h.staged[revision]
+	if !found {
+		return false
+	}
+
+	h.current.Store(loaded)
+	delete(h.staged, revision)
+	return true
+}
+
+// Discard drops the staged revision.
+func (h *Holder) Discard(revision string) {
+	h.stagedLock.Lock()
+	defer h.stagedLock.Unlock()
+
+	delete(h.staged, revision)
+}
+
+func (h *Holder) read(f ReaderGetter) (*loadedConfig, error) {
+	r, err := f()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err = cfg.Load(func() (io.Reader, error) { return bytes.NewReader(data), nil }); err != nil {
+		return nil, err
+	}
+
+	for _, mutate := range h.mutators {
+		mutate(&cfg)
+	}
+
+	if h.validate != nil {
+		if err = h.validate(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	return &loadedConfig{
+		config: cfg,
+		hash:   hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Get returns the configuration which is currently in use. The returned value must not be modified.
+func (h *Holder) Get() Config {
+	loaded := h.current.Load()
+	if loaded == nil {
+		return Config{}
+	}
+	return loaded.config
+}
+
+// GetRevision returns the staged configuration of the revision. The current configuration is returned when the revision
+// is empty, current, or not staged anymore.
+func (h *Holder) GetRevision(revision string) Config {
+	if revision != "" {
+		h.stagedLock.RLock()
+		loaded, found := h.staged[revision]
+		h.stagedLock.RUnlock()
+
+		if found {
+			return loaded.config
+		}
+	}
+	return h.Get()
+}
+
+// Hash returns the SHA-256 hash of the content from which the current configuration was loaded.
+func (h *Holder) Hash() string {
+	loaded := h.current.Load()
+	if loaded == nil {
+		return ""
+	}
+	return loaded.hash
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolder(t *testing.T) {
+	reader := func(content string) ReaderGetter {
+		return func() (io.Reader, error) {
+			return strings.NewReader(content), nil
+		}
+	}
+
+	rejectEmptyVersion := func(cfg Config) error {
+		if cfg.ConverterConfig.Kubernetes.DefaultVersion == "" {
+			return errors.New("default version is required")
+		}
+		return nil
+	}
+
+	t.Run("Should load a valid configuration", func(t *testing.T) {
+		// given
+		holder := NewHolder(rejectEmptyVersion)
+
+		// when
+		err := holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+		assert.Len(t, holder.Hash(), 64)
+	})
+
+	t.Run("Should keep the current configuration when the new one is invalid", func(t *testing.T) {
+		// given
+		holder := NewHolder(rejectEmptyVersion)
+		require.NoError(t, holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`)))
+		hash := holder.Hash()
+
+		// when
+		validationErr := holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":""}}}`))
+		decodingErr := holder.Load(reader(`{"converter":`))
+
+		// then
+		require.Error(t, validationErr)
+		require.Error(t, decodingErr)
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+		assert.Equal(t, hash, holder.Hash())
+	})
+
+	t.Run("Should apply mutators before validation", func(t *testing.T) {
+		// given
+		holder := NewHolder(rejectEmptyVersion, func(cfg *Config) {
+			cfg.ConverterConfig.Kubernetes.DefaultVersion = "1.34"
+		})
+
+		// when
+		err := holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":""}}}`))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.34", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+	})
+
+	t.Run("Should return the static configuration", func(t *testing.T) {
+		// given
+		cfg := Config{ConverterConfig: ConverterConfig{Kubernetes: KubernetesConfig{DefaultVersion: "1.33"}}}
+
+		// when
+		holder := NewStaticHolder(cfg)
+
+		// then
+		assert.Equal(t, cfg, holder.Get())
+		assert.Empty(t, holder.Hash())
+	})
+
+	t.Run("Should use the staged configuration only for its revision until it is promoted", func(t *testing.T) {
+		// given
+		holder := NewHolder(rejectEmptyVersion)
+		require.NoError(t, holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`)))
+		currentHash := holder.Hash()
+
+		// when
+		revision, err := holder.Stage(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.34"}}}`))
+
+		// then
+		require.NoError(t, err)
+		assert.NotEqual(t, currentHash, revision)
+		assert.Equal(t, currentHash, holder.Hash())
+		assert.Equal(t, "1.33", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+		assert.Equal(t, "1.33", holder.GetRevision("").ConverterConfig.Kubernetes.DefaultVersion)
+		assert.Equal(t, "1.34", holder.GetRevision(revision).ConverterConfig.Kubernetes.DefaultVersion)
+
+		// when
+		promoted := holder.Promote(revision)
+
+		// then
+		assert.True(t, promoted)
+		assert.Equal(t, revision, holder.Hash())
+		assert.Equal(t, "1.34", holder.Get().ConverterConfig.Kubernetes.DefaultVersion)
+		assert.False(t, holder.Promote(revision))
+	})
+
+	t.Run("Should fall back to the current configuration for a discarded revision", func(t *testing.T) {
+		// given
+		holder := NewHolder(rejectEmptyVersion)
+		require.NoError(t, holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`)))
+		revision, err := holder.Stage(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.34"}}}`))
+		require.NoError(t, err)
+
+		// when
+		holder.Discard(revision)
+
+		// then
+		assert.Equal(t, "1.33", holder.GetRevision(revision).ConverterConfig.Kubernetes.DefaultVersion)
+		assert.False(t, holder.Promote(revision))
+	})
+
+	t.Run("Should reject an invalid staged configuration", func(t *testing.T) {
+		// given
+		holder := NewHolder(rejectEmptyVersion)
+		require.NoError(t, holder.Load(reader(`{"converter":{"kubernetes":{"defaultVersion":"1.33"}}}`)))
+
+		// when
+		_, err := holder.Stage(reader(`{"converter":{"kubernetes":{"defaultVersion":""}}}`))
+
+		// then
+		require.Error(t, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	gardeneroidc "github.com/gardener/oidc-webhook-authenticator/apis/authentication/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/tracing"
 	kyma "github.com/kyma-project/lifecycle-manager/api/v1beta2"
 	registrycacheapi "github.com/kyma-project/registry-cache/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -49,7 +50,7 @@ func GetRuntimeClientWithScheme(secret corev1.Secret, scheme *runtime.Scheme) (c
 		return nil, err
 	}
 
-	runtimeClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	runtimeClient, err := client.New(tracing.InstrumentRestConfig(restConfig), client.Options{Scheme: scheme})
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +103,8 @@ func GetDynamicRuntimeClient(secret corev1.Secret) (*dynamic.DynamicClient, *dis
 		return nil, nil, err
 	}
 
+	tracing.InstrumentRestConfig(restConfig)
+
 	dynClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("creating dynamic client: %w", err)
@@ -0,0 +1,32 @@
+package cloudprofile
+
+import (
+	"context"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// NewCache creates a cache of the CloudProfiles in the Garden cluster which is kept up to date with a watch.
+// The cache has to be added to the manager, so that it is started together with the controllers.
+func NewCache(ctx context.Context, gardenRestConfig *rest.Config) (cache.Cache, error) {
+	scheme := runtime.NewScheme()
+	if err := gardener.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrap(err, "failed to register Gardener schema")
+	}
+
+	cloudProfileCache, err := cache.New(gardenRestConfig, cache.Options{Scheme: scheme})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CloudProfile cache")
+	}
+
+	// register the informer upfront, so that the watch is started when the cache is started and not with the first lookup
+	if _, err = cloudProfileCache.GetInformer(ctx, &gardener.CloudProfile{}); err != nil {
+		return nil, errors.Wrap(err, "failed to create CloudProfile informer")
+	}
+
+	return cloudProfileCache, nil
+}
@@ -0,0 +1,267 @@
+package cloudprofile
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const cloudProfileKind = "CloudProfile"
+
+// ValidationError describes a Shoot setting which is not offered by the CloudProfile.
+type ValidationError struct {
+	Reason  imv1.RuntimeConditionReason
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Validator checks the machine types, machine images, Kubernetes version, region and zones of a Shoot against its CloudProfile.
+type Validator struct {
+	reader client.Reader
+}
+
+// NewValidator creates a Validator reading the CloudProfiles with the given reader, which is typically the CloudProfile cache.
+func NewValidator(reader client.Reader) *Validator {
+	return &Validator{reader: reader}
+}
+
+// Validate returns a ValidationError when the Shoot uses a setting which the CloudProfile doesn't offer.
+// The current Shoot is nil for Shoots being created, which are validated completely. For existing Shoots only the settings
+// differing from the current Shoot are validated, so Shoots using settings which were deprecated or removed from the CloudProfile
+// later can still be patched.
+func (v *Validator) Validate(ctx context.Context, shoot gardener.Shoot, current *gardener.Shoot) error {
+	cloudProfileName := getCloudProfileName(shoot)
+	if cloudProfileName == "" {
+		return nil
+	}
+
+	var cloudProfile gardener.CloudProfile
+	if err := v.reader.Get(ctx, client.ObjectKey{Name: cloudProfileName}, &cloudProfile); err != nil {
+		return fmt.Errorf("failed to get CloudProfile %s: %w", cloudProfileName, err)
+	}
+
+	now := time.Now()
+
+	if err := validateKubernetesVersion(cloudProfile, shoot, current, now); err != nil {
+		return err
+	}
+
+	region, err := validateRegion(cloudProfile, shoot, current)
+	if err != nil {
+		return err
+	}
+
+	for _, worker := range shoot.Spec.Provider.Workers {
+		currentWorker := findWorker(current, worker.Name)
+
+		if err := validateMachineType(cloudProfile, region, worker, currentWorker); err != nil {
+			return err
+		}
+
+		if err := validateMachineImage(cloudProfile, worker, currentWorker, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getCloudProfileName(shoot gardener.Shoot) string {
+	if shoot.Spec.CloudProfile != nil {
+		// NamespacedCloudProfiles are not cached
+		if shoot.Spec.CloudProfile.Kind != cloudProfileKind {
+			return ""
+		}
+		return shoot.Spec.CloudProfile.Name
+	}
+
+	if shoot.Spec.CloudProfileName != nil { //nolint:staticcheck
+		return *shoot.Spec.CloudProfileName //nolint:staticcheck
+	}
+
+	return ""
+}
+
+func validateKubernetesVersion(cloudProfile gardener.CloudProfile, shoot gardener.Shoot, current *gardener.Shoot, now time.Time) error {
+	version := shoot.Spec.Kubernetes.Version
+	if version == "" || (current != nil && current.Spec.Kubernetes.Version == version) {
+		return nil
+	}
+
+	var usable []string
+	for _, offered := range cloudProfile.Spec.Kubernetes.Versions {
+		if offered.Version == version && IsVersionUsable(offered, now) {
+			return nil
+		}
+		if IsVersionUsable(offered, now) {
+			usable = append(usable, offered.Version)
+		}
+	}
+
+	return &ValidationError{
+		Reason: imv1.ConditionReasonKubernetesVersionUnavailable,
+		Message: fmt.Sprintf("Kubernetes version %s is not available in CloudProfile %s. Available versions: %s",
+			version, cloudProfile.Name, formatAlternatives(usable)),
+	}
+}
+
+func validateRegion(cloudProfile gardener.CloudProfile, shoot gardener.Shoot, current *gardener.Shoot) (gardener.Region, error) {
+	var regions []string
+	for _, region := range cloudProfile.Spec.Regions {
+		if region.Name == shoot.Spec.Region {
+			return region, nil
+		}
+		regions = append(regions, region.Name)
+	}
+
+	// the zones of a region removed from the CloudProfile are validated only when they are added to a worker pool
+	if current != nil && current.Spec.Region == shoot.Spec.Region {
+		return gardener.Region{Name: shoot.Spec.Region}, nil
+	}
+
+	return gardener.Region{}, &ValidationError{
+		Reason: imv1.ConditionReasonRegionUnavailable,
+		Message: fmt.Sprintf("Region %s is not available in CloudProfile %s. Available regions: %s",
+			shoot.Spec.Region, cloudProfile.Name, formatAlternatives(regions)),
+	}
+}
+
+func validateMachineType(cloudProfile gardener.CloudProfile, region gardener.Region, worker gardener.Worker, currentWorker *gardener.Worker) error {
+	machineType := worker.Machine.Type
+	alreadyUsed := currentWorker != nil && currentWorker.Machine.Type == machineType
+
+	var machineTypes []string
+	found := false
+	for _, offered := range cloudProfile.Spec.MachineTypes {
+		if offered.Usable != nil && !*offered.Usable {
+			continue
+		}
+		if offered.Name == machineType {
+			found = true
+		}
+		machineTypes = append(machineTypes, offered.Name)
+	}
+
+	if !found && !alreadyUsed {
+		return &ValidationError{
+			Reason: imv1.ConditionReasonMachineTypeUnavailable,
+			Message: fmt.Sprintf("Machine type %s of worker pool %s is not available in CloudProfile %s. Available machine types: %s",
+				machineType, worker.Name, cloudProfile.Name, formatAlternatives(machineTypes)),
+		}
+	}
+
+	var zones, zonesOfferingMachineType []string
+	for _, zone := range region.Zones {
+		zones = append(zones, zone.Name)
+		if !slices.Contains(zone.UnavailableMachineTypes, machineType) {
+			zonesOfferingMachineType = append(zonesOfferingMachineType, zone.Name)
+		}
+	}
+
+	for _, zone := range worker.Zones {
+		if alreadyUsed && slices.Contains(currentWorker.Zones, zone) {
+			continue
+		}
+
+		if !slices.Contains(zones, zone) {
+			return &ValidationError{
+				Reason: imv1.ConditionReasonZoneUnavailable,
+				Message: fmt.Sprintf("Zone %s of worker pool %s is not available in region %s. Available zones: %s",
+					zone, worker.Name, region.Name, formatAlternatives(zones)),
+			}
+		}
+
+		if !slices.Contains(zonesOfferingMachineType, zone) {
+			return &ValidationError{
+				Reason: imv1.ConditionReasonMachineTypeUnavailable,
+				Message: fmt.Sprintf("Machine type %s of worker pool %s is not available in zone %s. Zones offering the machine type: %s",
+					machineType, worker.Name, zone, formatAlternatives(zonesOfferingMachineType)),
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateMachineImage(cloudProfile gardener.CloudProfile, worker gardener.Worker, currentWorker *gardener.Worker, now time.Time) error {
+	image := worker.Machine.Image
+	if image == nil || imageAlreadyUsed(image, currentWorker) {
+		return nil
+	}
+
+	var imageNames []string
+	for _, offered := range cloudProfile.Spec.MachineImages {
+		if offered.Name != image.Name {
+			imageNames = append(imageNames, offered.Name)
+			continue
+		}
+
+		if image.Version == nil {
+			return nil
+		}
+
+		var usable []string
+		for _, version := range offered.Versions {
+			if version.Version == *image.Version && IsVersionUsable(version.ExpirableVersion, now) {
+				return nil
+			}
+			if IsVersionUsable(version.ExpirableVersion, now) {
+				usable = append(usable, version.Version)
+			}
+		}
+
+		return &ValidationError{
+			Reason: imv1.ConditionReasonMachineImageUnavailable,
+			Message: fmt.Sprintf("Version %s of machine image %s of worker pool %s is not available in CloudProfile %s. Available versions: %s",
+				*image.Version, image.Name, worker.Name, cloudProfile.Name, formatAlternatives(usable)),
+		}
+	}
+
+	return &ValidationError{
+		Reason: imv1.ConditionReasonMachineImageUnavailable,
+		Message: fmt.Sprintf("Machine image %s of worker pool %s is not available in CloudProfile %s. Available machine images: %s",
+			image.Name, worker.Name, cloudProfile.Name, formatAlternatives(imageNames)),
+	}
+}
+
+func imageAlreadyUsed(image *gardener.ShootMachineImage, currentWorker *gardener.Worker) bool {
+	if currentWorker == nil || currentWorker.Machine.Image == nil || currentWorker.Machine.Image.Name != image.Name {
+		return false
+	}
+
+	currentVersion := currentWorker.Machine.Image.Version
+	return ptr.Deref(currentVersion, "") == ptr.Deref(image.Version, "")
+}
+
+func findWorker(shoot *gardener.Shoot, name string) *gardener.Worker {
+	if shoot == nil {
+		return nil
+	}
+
+	for i := range shoot.Spec.Provider.Workers {
+		if shoot.Spec.Provider.Workers[i].Name == name {
+			return &shoot.Spec.Provider.Workers[i]
+		}
+	}
+	return nil
+}
+
+func formatAlternatives(alternatives []string) string {
+	if len(alternatives) == 0 {
+		return "none"
+	}
+
+	sorted := slices.Clone(alternatives)
+	slices.Sort(sorted)
+	return strings.Join(slices.Compact(sorted), ", ")
+}
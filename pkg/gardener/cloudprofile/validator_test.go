@@ -0,0 +1,270 @@
+package cloudprofile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidate(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		modifyShoot     func(shoot *gardener.Shoot)
+		current         *gardener.Shoot
+		expectedReason  imv1.RuntimeConditionReason
+		expectedMessage string
+	}{
+		"Should accept shoot with settings offered by the CloudProfile": {
+			modifyShoot: func(_ *gardener.Shoot) {},
+		},
+		"Should reject unknown Kubernetes version": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.29.0"
+			},
+			expectedReason:  imv1.ConditionReasonKubernetesVersionUnavailable,
+			expectedMessage: "Kubernetes version 1.29.0 is not available in CloudProfile aws. Available versions: 1.32.3, 1.33.1",
+		},
+		"Should reject expired Kubernetes version for new shoot": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.31.0"
+			},
+			expectedReason:  imv1.ConditionReasonKubernetesVersionUnavailable,
+			expectedMessage: "Kubernetes version 1.31.0 is not available in CloudProfile aws. Available versions: 1.32.3, 1.33.1",
+		},
+		"Should accept expired Kubernetes version already used by the shoot": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.31.0"
+			},
+			current: &gardener.Shoot{Spec: gardener.ShootSpec{Kubernetes: gardener.Kubernetes{Version: "1.31.0"}}},
+		},
+		"Should reject unknown region": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Region = "us-east-1"
+			},
+			expectedReason:  imv1.ConditionReasonRegionUnavailable,
+			expectedMessage: "Region us-east-1 is not available in CloudProfile aws. Available regions: eu-central-1",
+		},
+		"Should reject unknown machine type": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "m5.large"
+			},
+			expectedReason:  imv1.ConditionReasonMachineTypeUnavailable,
+			expectedMessage: "Machine type m5.large of worker pool cpu-worker-0 is not available in CloudProfile aws. Available machine types: g6.xlarge, m6i.large",
+		},
+		"Should reject machine type unavailable in zone": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "g6.xlarge"
+			},
+			expectedReason:  imv1.ConditionReasonMachineTypeUnavailable,
+			expectedMessage: "Machine type g6.xlarge of worker pool cpu-worker-0 is not available in zone eu-central-1b. Zones offering the machine type: eu-central-1a",
+		},
+		"Should reject unknown zone": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-central-1c"}
+			},
+			expectedReason:  imv1.ConditionReasonZoneUnavailable,
+			expectedMessage: "Zone eu-central-1c of worker pool cpu-worker-0 is not available in region eu-central-1. Available zones: eu-central-1a, eu-central-1b",
+		},
+		"Should reject unknown machine image": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Image.Name = "ubuntu"
+			},
+			expectedReason:  imv1.ConditionReasonMachineImageUnavailable,
+			expectedMessage: "Machine image ubuntu of worker pool cpu-worker-0 is not available in CloudProfile aws. Available machine images: gardenlinux",
+		},
+		"Should reject expired machine image version": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = ptr.To("1443.3.0")
+			},
+			expectedReason:  imv1.ConditionReasonMachineImageUnavailable,
+			expectedMessage: "Version 1443.3.0 of machine image gardenlinux of worker pool cpu-worker-0 is not available in CloudProfile aws. Available versions: 1592.1.0",
+		},
+		"Should accept machine image without version": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = nil
+			},
+		},
+		"Should accept Kubernetes version removed from the CloudProfile which is already used by the shoot": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.30.2"
+			},
+			current: fixCurrentShoot(func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.30.2"
+			}),
+		},
+		"Should accept machine type and zones removed from the CloudProfile which are already used by the shoot": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "m5.large"
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-central-1a", "eu-central-1c"}
+			},
+			current: fixCurrentShoot(func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "m5.large"
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-central-1a", "eu-central-1c"}
+			}),
+		},
+		"Should accept region removed from the CloudProfile which is already used by the shoot": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Region = "eu-west-1"
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-west-1a"}
+			},
+			current: fixCurrentShoot(func(shoot *gardener.Shoot) {
+				shoot.Spec.Region = "eu-west-1"
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-west-1a"}
+			}),
+		},
+		"Should accept machine image version removed from the CloudProfile which is already used by the shoot": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = ptr.To("1312.2.0")
+			},
+			current: fixCurrentShoot(func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = ptr.To("1312.2.0")
+			}),
+		},
+		"Should reject zone added to a worker pool using a machine type removed from the CloudProfile": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "g6.xlarge"
+			},
+			current: fixCurrentShoot(func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "g6.xlarge"
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-central-1a"}
+			}),
+			expectedReason:  imv1.ConditionReasonMachineTypeUnavailable,
+			expectedMessage: "Machine type g6.xlarge of worker pool cpu-worker-0 is not available in zone eu-central-1b. Zones offering the machine type: eu-central-1a",
+		},
+		"Should reject changed machine type which is not offered when the shoot exists": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "m5.large"
+			},
+			current:         fixCurrentShoot(func(_ *gardener.Shoot) {}),
+			expectedReason:  imv1.ConditionReasonMachineTypeUnavailable,
+			expectedMessage: "Machine type m5.large of worker pool cpu-worker-0 is not available in CloudProfile aws. Available machine types: g6.xlarge, m6i.large",
+		},
+		"Should skip shoot referencing NamespacedCloudProfile": {
+			modifyShoot: func(shoot *gardener.Shoot) {
+				shoot.Spec.CloudProfile = &gardener.CloudProfileReference{Kind: "NamespacedCloudProfile", Name: "custom"}
+				shoot.Spec.Region = "us-east-1"
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := fixShoot()
+			tcase.modifyShoot(&shoot)
+			validator := NewValidator(fixReader(t))
+
+			// when
+			err := validator.Validate(context.Background(), shoot, tcase.current)
+
+			// then
+			if tcase.expectedReason == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tcase.expectedReason, validationErr.Reason)
+			assert.Equal(t, tcase.expectedMessage, validationErr.Message)
+		})
+	}
+
+	t.Run("Should return error which is not a validation error for missing CloudProfile", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+		shoot.Spec.CloudProfile.Name = "missing"
+		validator := NewValidator(fixReader(t))
+
+		// when
+		err := validator.Validate(context.Background(), shoot, nil)
+
+		// then
+		var validationErr *ValidationError
+		require.Error(t, err)
+		assert.False(t, errors.As(err, &validationErr))
+	})
+}
+
+func fixReader(t *testing.T) client.Reader {
+	scheme := runtime.NewScheme()
+	require.NoError(t, gardener.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(fixCloudProfile()).Build()
+}
+
+func fixCloudProfile() *gardener.CloudProfile {
+	expired := &metav1.Time{Time: time.Now().Add(-24 * time.Hour)}
+
+	return &gardener.CloudProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws"},
+		Spec: gardener.CloudProfileSpec{
+			Kubernetes: gardener.KubernetesSettings{
+				Versions: []gardener.ExpirableVersion{
+					{Version: "1.33.1"},
+					{Version: "1.32.3"},
+					{Version: "1.31.0", ExpirationDate: expired},
+				},
+			},
+			MachineImages: []gardener.MachineImage{
+				{
+					Name: "gardenlinux",
+					Versions: []gardener.MachineImageVersion{
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1592.1.0"}},
+						{ExpirableVersion: gardener.ExpirableVersion{Version: "1443.3.0", Classification: ptr.To(gardener.ClassificationExpired)}},
+					},
+				},
+			},
+			MachineTypes: []gardener.MachineType{
+				{Name: "m6i.large"},
+				{Name: "g6.xlarge"},
+				{Name: "m5.large", Usable: ptr.To(false)},
+			},
+			Regions: []gardener.Region{
+				{
+					Name: "eu-central-1",
+					Zones: []gardener.AvailabilityZone{
+						{Name: "eu-central-1a"},
+						{Name: "eu-central-1b", UnavailableMachineTypes: []string{"g6.xlarge"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func fixCurrentShoot(modify func(shoot *gardener.Shoot)) *gardener.Shoot {
+	shoot := fixShoot()
+	modify(&shoot)
+	return &shoot
+}
+
+func fixShoot() gardener.Shoot {
+	return gardener.Shoot{
+		Spec: gardener.ShootSpec{
+			CloudProfile: &gardener.CloudProfileReference{Kind: "CloudProfile", Name: "aws"},
+			Region:       "eu-central-1",
+			Kubernetes:   gardener.Kubernetes{Version: "1.32.3"},
+			Provider: gardener.Provider{
+				Workers: []gardener.Worker{
+					{
+						Name: "cpu-worker-0",
+						Machine: gardener.Machine{
+							Type:  "m6i.large",
+							Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")},
+						},
+						Zones: []string{"eu-central-1a", "eu-central-1b"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package cloudprofile
+
+import (
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// IsVersionUsable checks if a version offered by the CloudProfile can be used for new clusters.
+func IsVersionUsable(version gardener.ExpirableVersion, now time.Time) bool {
+	if version.Classification != nil {
+		switch *version.Classification {
+		case gardener.ClassificationExpired, gardener.ClassificationUnavailable:
+			return false
+		}
+	}
+
+	return version.ExpirationDate == nil || now.Before(version.ExpirationDate.Time)
+}
@@ -0,0 +1,262 @@
+package errorcatalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	imgardener "github.com/kyma-project/infrastructure-manager/pkg/gardener"
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the catalog file format supported by KIM
+const Version = "v1"
+
+// Reasons are the Runtime condition reasons the catalog entries can use
+var Reasons = []imv1.RuntimeConditionReason{
+	imv1.ConditionReasonInfrastructureUnauthenticated,
+	imv1.ConditionReasonInfrastructureUnauthorized,
+	imv1.ConditionReasonInfrastructureQuotaExceeded,
+	imv1.ConditionReasonInfrastructureRateLimited,
+	imv1.ConditionReasonInfrastructureDependencies,
+	imv1.ConditionReasonInfrastructureResourcesDepleted,
+	imv1.ConditionReasonShootConfigurationProblem,
+	imv1.ConditionReasonProblematicWebhook,
+	imv1.ConditionReasonClusterResourcesCleanup,
+	imv1.ConditionReasonGardenerError,
+}
+
+// Backoff defines the delay between the checks of a failed Shoot. The delay starts with Initial and is doubled
+// until it reaches the time for which the error has persisted, but it never exceeds Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay returns the delay for an error which has persisted for the elapsed time, or the fallback when no backoff is configured
+func (b Backoff) Delay(elapsed, fallback time.Duration) time.Duration {
+	if b.Initial <= 0 {
+		return fallback
+	}
+
+	delay := b.Initial
+	for delay < elapsed && (b.Max <= 0 || delay < b.Max) {
+		delay *= 2
+	}
+	if b.Max > 0 && delay > b.Max {
+		return b.Max
+	}
+	return delay
+}
+
+type Entry struct {
+	Reason imv1.RuntimeConditionReason
+	// Codes match the Gardener error codes of the last errors
+	Codes []gardener.ErrorCode
+	// DescriptionPattern matches the descriptions of the last errors
+	DescriptionPattern *regexp.Regexp
+	// Remediation is shown to the user in the condition message
+	Remediation string
+	Retryable   bool
+	Backoff     Backoff
+}
+
+func (e Entry) matches(lastError gardener.LastError) bool {
+	for _, code := range lastError.Codes {
+		if slices.Contains(e.Codes, code) {
+			return true
+		}
+	}
+	return e.DescriptionPattern != nil && e.DescriptionPattern.MatchString(lastError.Description)
+}
+
+// Catalog maps the last errors of a failed Shoot operation to Runtime condition reasons. The entries are checked in order, the first matching entry wins.
+type Catalog struct {
+	Version string
+	Entries []Entry
+}
+
+// Classification is the result of classifying the last errors of a failed Shoot operation
+type Classification struct {
+	Reason    imv1.RuntimeConditionReason
+	Retryable bool
+	// Message is the condition message with the remediation, or the Gardener error descriptions when no remediation is known
+	Message string
+	// Codes are the joined Gardener error codes
+	Codes   string
+	backoff Backoff
+	since   *time.Time
+}
+
+// Backoff returns the backoff of the matching catalog entry, it is empty when no entry matched
+func (c Classification) Backoff() Backoff {
+	return c.backoff
+}
+
+// RequeueAfter returns the delay after which the Shoot is checked again
+func (c Classification) RequeueAfter(now time.Time, fallback time.Duration) time.Duration {
+	var elapsed time.Duration
+	if c.since != nil {
+		elapsed = now.Sub(*c.since)
+	}
+	return c.backoff.Delay(elapsed, fallback)
+}
+
+// Classify returns the classification of the first entry matching one of the last errors.
+// Errors without a matching entry are retried unless Gardener considers them non-retryable.
+func (c *Catalog) Classify(lastErrors []gardener.LastError) Classification {
+	codes := string(imgardener.ToErrReason(lastErrors...))
+
+	for _, entry := range c.Entries {
+		var matched []gardener.LastError
+		for _, lastError := range lastErrors {
+			if entry.matches(lastError) {
+				matched = append(matched, lastError)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		return Classification{
+			Reason:    entry.Reason,
+			Retryable: entry.Retryable,
+			Message:   message(entry.Remediation, codes, matched),
+			Codes:     codes,
+			backoff:   entry.Backoff,
+			since:     firstUpdate(matched),
+		}
+	}
+
+	return Classification{
+		Reason:    imv1.ConditionReasonGardenerError,
+		Retryable: imgardener.IsRetryable(lastErrors),
+		Message:   message("", codes, lastErrors),
+		Codes:     codes,
+		since:     firstUpdate(lastErrors),
+	}
+}
+
+func message(remediation, codes string, lastErrors []gardener.LastError) string {
+	if remediation == "" {
+		return fmt.Sprintf("Gardener errors: %s", strings.TrimSpace(imgardener.CombineErrorDescriptions(lastErrors)))
+	}
+	if codes == "" {
+		return remediation
+	}
+	return fmt.Sprintf("%s (Gardener error codes: %s)", remediation, codes)
+}
+
+func firstUpdate(lastErrors []gardener.LastError) *time.Time {
+	var first *time.Time
+	for _, lastError := range lastErrors {
+		if lastError.LastUpdateTime == nil {
+			continue
+		}
+		if first == nil || lastError.LastUpdateTime.Time.Before(*first) {
+			first = &lastError.LastUpdateTime.Time
+		}
+	}
+	return first
+}
+
+type catalogFile struct {
+	Version string      `json:"version"`
+	Entries []entryFile `json:"entries"`
+}
+
+type entryFile struct {
+	Reason             string   `json:"reason"`
+	Codes              []string `json:"codes"`
+	DescriptionPattern string   `json:"descriptionPattern"`
+	Remediation        string   `json:"remediation"`
+	Retryable          bool     `json:"retryable"`
+	Backoff            struct {
+		Initial string `json:"initial"`
+		Max     string `json:"max"`
+	} `json:"backoff"`
+}
+
+// LoadFile reads the catalog from a JSON file
+func LoadFile(path string) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open the Gardener error catalog")
+	}
+	defer f.Close() //nolint:errcheck
+
+	return Load(f)
+}
+
+// Load reads and validates the catalog
+func Load(r io.Reader) (*Catalog, error) {
+	var file catalogFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the Gardener error catalog")
+	}
+
+	if file.Version != Version {
+		return nil, fmt.Errorf("unsupported Gardener error catalog version %q, expected %q", file.Version, Version)
+	}
+
+	catalog := &Catalog{Version: file.Version}
+	for i, e := range file.Entries {
+		entry, err := e.toEntry()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entry %d of the Gardener error catalog", i)
+		}
+		catalog.Entries = append(catalog.Entries, entry)
+	}
+	return catalog, nil
+}
+
+func (e entryFile) toEntry() (Entry, error) {
+	entry := Entry{
+		Reason:      imv1.RuntimeConditionReason(e.Reason),
+		Remediation: e.Remediation,
+		Retryable:   e.Retryable,
+	}
+
+	if !slices.Contains(Reasons, entry.Reason) {
+		return Entry{}, fmt.Errorf("unknown reason %q", e.Reason)
+	}
+
+	if len(e.Codes) == 0 && e.DescriptionPattern == "" {
+		return Entry{}, errors.New("codes or descriptionPattern must be set")
+	}
+
+	for _, code := range e.Codes {
+		entry.Codes = append(entry.Codes, gardener.ErrorCode(code))
+	}
+
+	if e.DescriptionPattern != "" {
+		pattern, err := regexp.Compile(e.DescriptionPattern)
+		if err != nil {
+			return Entry{}, errors.Wrap(err, "invalid descriptionPattern")
+		}
+		entry.DescriptionPattern = pattern
+	}
+
+	var err error
+	if e.Backoff.Initial != "" {
+		if entry.Backoff.Initial, err = time.ParseDuration(e.Backoff.Initial); err != nil {
+			return Entry{}, errors.Wrap(err, "invalid initial backoff")
+		}
+	}
+	if e.Backoff.Max != "" {
+		if entry.Backoff.Max, err = time.ParseDuration(e.Backoff.Max); err != nil {
+			return Entry{}, errors.Wrap(err, "invalid maximal backoff")
+		}
+	}
+	if entry.Backoff.Max > 0 && entry.Backoff.Max < entry.Backoff.Initial {
+		return Entry{}, errors.New("maximal backoff must not be lower than the initial backoff")
+	}
+	return entry, nil
+}
@@ -0,0 +1,227 @@
+package errorcatalog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	imgardener "github.com/kyma-project/infrastructure-manager/pkg/gardener"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClassify(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		lastErrors        []gardener.LastError
+		expectedReason    imv1.RuntimeConditionReason
+		expectedRetryable bool
+		expectedMessage   string
+	}{
+		{
+			name:              "Should classify a retryable error code",
+			lastErrors:        []gardener.LastError{{Description: "quota exceeded", Codes: []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded}}},
+			expectedReason:    imv1.ConditionReasonInfrastructureQuotaExceeded,
+			expectedRetryable: true,
+			expectedMessage:   "The quota of the cloud provider account is exceeded. Increase the quota or free up resources in the account (Gardener error codes: ERR_INFRA_QUOTA_EXCEEDED)",
+		},
+		{
+			name: "Should prefer non-retryable errors",
+			lastErrors: []gardener.LastError{
+				{Description: "quota exceeded", Codes: []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded}},
+				{Description: "invalid credentials", Codes: []gardener.ErrorCode{gardener.ErrorInfraUnauthenticated}},
+			},
+			expectedReason:    imv1.ConditionReasonInfrastructureUnauthenticated,
+			expectedRetryable: false,
+			expectedMessage:   "The cloud provider rejected the credentials of the runtime. Check that the credentials used for the Shoot are valid (Gardener error codes: ERR_INFRA_QUOTA_EXCEEDED, ERR_INFRA_UNAUTHENTICATED)",
+		},
+		{
+			name:              "Should classify an error by its description",
+			lastErrors:        []gardener.LastError{{Description: "We currently do not have sufficient capacity... Insufficient instance capacity"}},
+			expectedReason:    imv1.ConditionReasonInfrastructureResourcesDepleted,
+			expectedRetryable: true,
+			expectedMessage:   "The cloud provider has no capacity for the requested machine type in the zone. Choose another machine type or zone, or wait until capacity is available",
+		},
+		{
+			name:              "Should retry unknown errors",
+			lastErrors:        []gardener.LastError{{Description: "something new", Codes: []gardener.ErrorCode{"ERR_NEW"}}},
+			expectedReason:    imv1.ConditionReasonGardenerError,
+			expectedRetryable: true,
+			expectedMessage:   "Gardener errors: 1) something new",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			classification := Default().Classify(tc.lastErrors)
+
+			// then
+			assert.Equal(t, tc.expectedReason, classification.Reason)
+			assert.Equal(t, tc.expectedRetryable, classification.Retryable)
+			assert.Equal(t, tc.expectedMessage, classification.Message)
+		})
+	}
+}
+
+func TestDefaultCatalogRetriesAsWithoutCatalog(t *testing.T) {
+	codes := []gardener.ErrorCode{
+		gardener.ErrorInfraUnauthenticated,
+		gardener.ErrorInfraUnauthorized,
+		gardener.ErrorInfraQuotaExceeded,
+		gardener.ErrorInfraRateLimitsExceeded,
+		gardener.ErrorInfraDependencies,
+		gardener.ErrorRetryableInfraDependencies,
+		gardener.ErrorInfraResourcesDepleted,
+		gardener.ErrorCleanupClusterResources,
+		gardener.ErrorConfigurationProblem,
+		gardener.ErrorRetryableConfigurationProblem,
+		gardener.ErrorProblematicWebhook,
+		"ERR_UNKNOWN",
+	}
+
+	// every code alone and every pair of codes, reported in one or in two last errors, with and without a known description
+	var cases [][]gardener.LastError
+	for _, description := range []string{"failed", "insufficient instance capacity"} {
+		cases = append(cases, []gardener.LastError{{Description: description}})
+		for i, code := range codes {
+			cases = append(cases, []gardener.LastError{{Description: description, Codes: []gardener.ErrorCode{code}}})
+			for _, other := range codes[i+1:] {
+				cases = append(cases,
+					[]gardener.LastError{{Description: description, Codes: []gardener.ErrorCode{code, other}}},
+					[]gardener.LastError{{Description: description, Codes: []gardener.ErrorCode{code}}, {Description: "failed", Codes: []gardener.ErrorCode{other}}},
+				)
+			}
+		}
+	}
+
+	catalog := Default()
+	for _, lastErrors := range cases {
+		// when
+		classification := catalog.Classify(lastErrors)
+
+		// then
+		assert.Equal(t, imgardener.IsRetryable(lastErrors), classification.Retryable, "last errors: %v", lastErrors)
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Now()
+	lastErrors := func(since time.Duration) []gardener.LastError {
+		return []gardener.LastError{{
+			Codes:          []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded},
+			LastUpdateTime: &metav1.Time{Time: now.Add(-since)},
+		}}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		lastErrors    []gardener.LastError
+		expectedDelay time.Duration
+	}{
+		{
+			name:          "Should start with the initial backoff",
+			lastErrors:    lastErrors(time.Minute),
+			expectedDelay: 5 * time.Minute,
+		},
+		{
+			name:          "Should double the backoff while the error persists",
+			lastErrors:    lastErrors(12 * time.Minute),
+			expectedDelay: 20 * time.Minute,
+		},
+		{
+			name:          "Should not exceed the maximal backoff",
+			lastErrors:    lastErrors(5 * time.Hour),
+			expectedDelay: time.Hour,
+		},
+		{
+			name:          "Should use the fallback without backoff",
+			lastErrors:    []gardener.LastError{{Codes: []gardener.ErrorCode{"ERR_NEW"}}},
+			expectedDelay: 30 * time.Second,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			delay := Default().Classify(tc.lastErrors).RequeueAfter(now, 30*time.Second)
+
+			// then
+			assert.Equal(t, tc.expectedDelay, delay)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Should load the catalog", func(t *testing.T) {
+		// given
+		catalogFile := `{
+			"version": "v1",
+			"entries": [
+				{
+					"reason": "InfrastructureQuotaExceeded",
+					"codes": ["ERR_INFRA_QUOTA_EXCEEDED"],
+					"remediation": "Open a quota ticket",
+					"retryable": true,
+					"backoff": {"initial": "10m", "max": "2h"}
+				},
+				{
+					"reason": "InfrastructureResourcesDepleted",
+					"descriptionPattern": "(?i)out of capacity"
+				}
+			]
+		}`
+
+		// when
+		catalog, err := Load(strings.NewReader(catalogFile))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, catalog.Entries, 2)
+		assert.Equal(t, Backoff{Initial: 10 * time.Minute, Max: 2 * time.Hour}, catalog.Entries[0].Backoff)
+
+		classification := catalog.Classify([]gardener.LastError{{Description: "Zone is Out of Capacity"}})
+		assert.Equal(t, imv1.ConditionReasonInfrastructureResourcesDepleted, classification.Reason)
+		assert.False(t, classification.Retryable)
+	})
+
+	for _, tc := range []struct {
+		name        string
+		catalogFile string
+		expectedErr string
+	}{
+		{
+			name:        "Should reject an unsupported version",
+			catalogFile: `{"version": "v2", "entries": []}`,
+			expectedErr: `unsupported Gardener error catalog version "v2", expected "v1"`,
+		},
+		{
+			name:        "Should reject an unknown reason",
+			catalogFile: `{"version": "v1", "entries": [{"reason": "Other", "codes": ["ERR_NEW"]}]}`,
+			expectedErr: `invalid entry 0 of the Gardener error catalog: unknown reason "Other"`,
+		},
+		{
+			name:        "Should reject an entry without codes and pattern",
+			catalogFile: `{"version": "v1", "entries": [{"reason": "GardenerErr"}]}`,
+			expectedErr: "invalid entry 0 of the Gardener error catalog: codes or descriptionPattern must be set",
+		},
+		{
+			name:        "Should reject an invalid pattern",
+			catalogFile: `{"version": "v1", "entries": [{"reason": "GardenerErr", "descriptionPattern": "("}]}`,
+			expectedErr: "invalid entry 0 of the Gardener error catalog: invalid descriptionPattern",
+		},
+		{
+			name:        "Should reject a maximal backoff lower than the initial backoff",
+			catalogFile: `{"version": "v1", "entries": [{"reason": "GardenerErr", "codes": ["ERR_NEW"], "backoff": {"initial": "1h", "max": "1m"}}]}`,
+			expectedErr: "invalid entry 0 of the Gardener error catalog: maximal backoff must not be lower than the initial backoff",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := Load(strings.NewReader(tc.catalogFile))
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package errorcatalog
+
+import (
+	"regexp"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	imgardener "github.com/kyma-project/infrastructure-manager/pkg/gardener"
+)
+
+// Default returns the catalog used when no catalog file is configured.
+// The non-retryable entries come first, so that they win over retryable errors reported at the same time.
+// The default catalog only adds reasons and remediations, the errors are retried exactly as without a catalog.
+func Default() *Catalog {
+	return &Catalog{
+		Version: Version,
+		Entries: []Entry{
+			{
+				Reason:      imv1.ConditionReasonInfrastructureUnauthenticated,
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraUnauthenticated},
+				Remediation: "The cloud provider rejected the credentials of the runtime. Check that the credentials used for the Shoot are valid",
+				Retryable:   retryable(gardener.ErrorInfraUnauthenticated),
+			},
+			{
+				Reason:      imv1.ConditionReasonInfrastructureUnauthorized,
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraUnauthorized},
+				Remediation: "The credentials of the runtime lack permissions in the cloud provider account. Grant the permissions required by Gardener",
+				Retryable:   retryable(gardener.ErrorInfraUnauthorized),
+			},
+			{
+				Reason:      imv1.ConditionReasonInfrastructureQuotaExceeded,
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraQuotaExceeded},
+				Remediation: "The quota of the cloud provider account is exceeded. Increase the quota or free up resources in the account",
+				Retryable:   retryable(gardener.ErrorInfraQuotaExceeded),
+				Backoff:     Backoff{Initial: 5 * time.Minute, Max: time.Hour},
+			},
+			{
+				Reason:      imv1.ConditionReasonInfrastructureRateLimited,
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraRateLimitsExceeded},
+				Remediation: "The cloud provider throttles the requests of Gardener. The operation is retried automatically",
+				Retryable:   retryable(gardener.ErrorInfraRateLimitsExceeded),
+				Backoff:     Backoff{Initial: time.Minute, Max: 15 * time.Minute},
+			},
+			{
+				Reason:      imv1.ConditionReasonInfrastructureResourcesDepleted,
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraResourcesDepleted},
+				Remediation: "The cloud provider has no capacity for the requested machine type in the zone. Choose another machine type or zone, or wait until capacity is available",
+				Retryable:   retryable(gardener.ErrorInfraResourcesDepleted),
+				Backoff:     Backoff{Initial: 10 * time.Minute, Max: time.Hour},
+			},
+			{
+				Reason:             imv1.ConditionReasonInfrastructureResourcesDepleted,
+				DescriptionPattern: regexp.MustCompile(`(?i)insufficient\s+\w*\s*capacity`),
+				Remediation:        "The cloud provider has no capacity for the requested machine type in the zone. Choose another machine type or zone, or wait until capacity is available",
+				Retryable:          retryable(),
+				Backoff:            Backoff{Initial: 10 * time.Minute, Max: time.Hour},
+			},
+			{
+				Reason:      imv1.ConditionReasonInfrastructureDependencies,
+				Codes:       []gardener.ErrorCode{gardener.ErrorInfraDependencies, gardener.ErrorRetryableInfraDependencies},
+				Remediation: "Resources in the cloud provider account block the operation. Remove the resources which depend on the infrastructure of the Shoot",
+				Retryable:   retryable(gardener.ErrorInfraDependencies, gardener.ErrorRetryableInfraDependencies),
+				Backoff:     Backoff{Initial: 2 * time.Minute, Max: 30 * time.Minute},
+			},
+			{
+				Reason:      imv1.ConditionReasonShootConfigurationProblem,
+				Codes:       []gardener.ErrorCode{gardener.ErrorConfigurationProblem, gardener.ErrorRetryableConfigurationProblem},
+				Remediation: "Gardener reported a problem with the Shoot configuration. Check the Runtime specification",
+				Retryable:   retryable(gardener.ErrorConfigurationProblem, gardener.ErrorRetryableConfigurationProblem),
+				Backoff:     Backoff{Initial: 2 * time.Minute, Max: 30 * time.Minute},
+			},
+			{
+				Reason:      imv1.ConditionReasonProblematicWebhook,
+				Codes:       []gardener.ErrorCode{gardener.ErrorProblematicWebhook},
+				Remediation: "A webhook in the runtime blocks the operation. Fix or remove the failing webhook",
+				Retryable:   retryable(gardener.ErrorProblematicWebhook),
+				Backoff:     Backoff{Initial: 2 * time.Minute, Max: 30 * time.Minute},
+			},
+			{
+				Reason:      imv1.ConditionReasonClusterResourcesCleanup,
+				Codes:       []gardener.ErrorCode{gardener.ErrorCleanupClusterResources},
+				Remediation: "Resources in the runtime block the deletion. Remove the remaining resources, for example, volumes and load balancers",
+				Retryable:   retryable(gardener.ErrorCleanupClusterResources),
+				Backoff:     Backoff{Initial: 2 * time.Minute, Max: 30 * time.Minute},
+			},
+		},
+	}
+}
+
+// retryable returns whether the last errors with the codes are retried without a catalog
+func retryable(codes ...gardener.ErrorCode) bool {
+	return imgardener.IsRetryable([]gardener.LastError{{Codes: codes}})
+}
@@ -5,8 +5,8 @@ import (
 
 	authenticationv1alpha1 "github.com/gardener/gardener/pkg/apis/authentication/v1alpha1"
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
 	"github.com/pkg/errors"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gardenerClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -18,7 +18,7 @@ type Provider struct {
 }
 
 type ShootClient interface {
-	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.Shoot, error)
+	Get(ctx context.Context, key gardenerClient.ObjectKey, obj gardenerClient.Object, opts ...gardenerClient.GetOption) error
 }
 
 type DynamicKubeconfigAPI interface {
@@ -38,8 +38,15 @@ func NewKubeconfigProvider(
 	}
 }
 
-func (kp Provider) Fetch(ctx context.Context, shootName string) (string, error) {
-	shoot, err := kp.shootClient.Get(ctx, shootName, v1.GetOptions{})
+// Fetch requests a kubeconfig for the Shoot in the Gardener project. The Shoot is looked up in the default namespace when no project is given.
+func (kp Provider) Fetch(ctx context.Context, gardenerProject, shootName string) (string, error) {
+	namespace := kp.shootNamespace
+	if gardenerProject != "" {
+		namespace = project.Namespace(gardenerProject)
+	}
+
+	var shoot v1beta1.Shoot
+	err := kp.shootClient.Get(ctx, gardenerClient.ObjectKey{Name: shootName, Namespace: namespace}, &shoot)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get shoot")
 	}
@@ -50,7 +57,7 @@ func (kp Provider) Fetch(ctx context.Context, shootName string) (string, error)
 		},
 	}
 
-	err = kp.dynamicKubeconfigAPI.Create(ctx, shoot, &adminKubeconfigRequest)
+	err = kp.dynamicKubeconfigAPI.Create(ctx, &shoot, &adminKubeconfigRequest)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create AdminKubeconfigRequest")
 	}
@@ -0,0 +1,140 @@
+package landscape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shootcache"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultName is the name of the landscape configured with the `--gardener-*` flags
+const DefaultName = "default"
+
+// Config describes an additional Gardener landscape in the landscape configuration file
+type Config struct {
+	Name string `json:"name"`
+	// KubeconfigPath is the path of the mounted kubeconfig Secret of the landscape
+	KubeconfigPath string `json:"kubeconfigPath"`
+	ProjectName    string `json:"projectName"`
+	// ClientTimeout, ClientQPS and ClientBurst default to the values of the Gardener client flags
+	ClientTimeout string `json:"clientTimeout"`
+	ClientQPS     int    `json:"clientQPS"`
+	ClientBurst   int    `json:"clientBurst"`
+}
+
+// Timeout returns the client timeout of the landscape, or the fallback when it is not set
+func (c Config) Timeout(fallback time.Duration) (time.Duration, error) {
+	if c.ClientTimeout == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(c.ClientTimeout)
+}
+
+// LoadConfigs reads the additional landscapes from a JSON file
+func LoadConfigs(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read landscape configuration")
+	}
+
+	var configs []Config
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, errors.Wrap(err, "failed to decode landscape configuration")
+	}
+
+	for _, cfg := range configs {
+		if cfg.Name == "" || cfg.KubeconfigPath == "" || cfg.ProjectName == "" {
+			return nil, fmt.Errorf("landscape %q must have a name, kubeconfigPath and projectName", cfg.Name)
+		}
+		if _, err := cfg.Timeout(0); err != nil {
+			return nil, errors.Wrapf(err, "invalid client timeout of landscape %s", cfg.Name)
+		}
+	}
+	return configs, nil
+}
+
+type KubeconfigProvider interface {
+	Fetch(ctx context.Context, gardenerProject, shootName string) (string, error)
+}
+
+type Landscape struct {
+	Name string
+	// ProjectName is the Gardener project of the runtimes of the landscape, the default landscape uses the converter configuration instead
+	ProjectName        string
+	Client             client.Client
+	KubeconfigProvider KubeconfigProvider
+	// CloudProfileReader is set when the CloudProfiles are needed by the Runtime controller
+	CloudProfileReader client.Reader
+	// ShootCache is set when the Runtime controller is triggered by the Shoot watch events of the landscape
+	ShootCache *shootcache.Cache
+}
+
+// IsDefault returns true for the landscape configured with the `--gardener-*` flags
+func (l Landscape) IsDefault() bool {
+	return l.Name == DefaultName
+}
+
+type Registry struct {
+	landscapes map[string]Landscape
+}
+
+// NewRegistry creates a registry of the default landscape and the additional landscapes
+func NewRegistry(defaultLandscape Landscape, landscapes ...Landscape) (*Registry, error) {
+	defaultLandscape.Name = DefaultName
+	registry := &Registry{landscapes: map[string]Landscape{DefaultName: defaultLandscape}}
+
+	for _, landscape := range landscapes {
+		if _, found := registry.landscapes[landscape.Name]; found {
+			return nil, fmt.Errorf("landscape %s is configured more than once", landscape.Name)
+		}
+		registry.landscapes[landscape.Name] = landscape
+	}
+	return registry, nil
+}
+
+// Get returns the landscape with the name, an empty name stands for the default landscape
+func (r *Registry) Get(name string) (Landscape, error) {
+	if name == "" {
+		name = DefaultName
+	}
+
+	landscape, found := r.landscapes[name]
+	if !found {
+		return Landscape{}, fmt.Errorf("gardener landscape %s is not configured", name)
+	}
+	return landscape, nil
+}
+
+// Names returns the sorted names of all landscapes
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.landscapes))
+	for name := range r.landscapes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Fetch requests a kubeconfig for the Shoot from the Gardener landscape
+func (r *Registry) Fetch(ctx context.Context, landscapeName, gardenerProject, shootName string) (string, error) {
+	landscape, err := r.Get(landscapeName)
+	if err != nil {
+		return "", err
+	}
+	return landscape.KubeconfigProvider.Fetch(ctx, gardenerProject, shootName)
+}
+
+// NameFromLabels returns the landscape referenced in the labels of a Runtime or GardenerCluster CR
+func NameFromLabels(labels map[string]string) string {
+	if name := labels[imv1.LabelKymaGardenerLandscape]; name != "" {
+		return name
+	}
+	return DefaultName
+}
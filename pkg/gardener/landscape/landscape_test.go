@@ -0,0 +1,129 @@
+package landscape
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type kubeconfigProviderStub struct {
+	landscape string
+}
+
+func (p kubeconfigProviderStub) Fetch(_ context.Context, gardenerProject, shootName string) (string, error) {
+	return p.landscape + "/" + gardenerProject + "/" + shootName, nil
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Should resolve the default and additional landscapes", func(t *testing.T) {
+		// given
+		registry, err := NewRegistry(
+			Landscape{KubeconfigProvider: kubeconfigProviderStub{landscape: DefaultName}},
+			Landscape{Name: "canary", ProjectName: "kyma-canary", KubeconfigProvider: kubeconfigProviderStub{landscape: "canary"}},
+		)
+		require.NoError(t, err)
+
+		// when
+		defaultLandscape, defaultErr := registry.Get("")
+		canary, canaryErr := registry.Get("canary")
+		kubeconfig, fetchErr := registry.Fetch(context.Background(), "canary", "kyma-canary", "shoot")
+
+		// then
+		require.NoError(t, defaultErr)
+		require.NoError(t, canaryErr)
+		require.NoError(t, fetchErr)
+		assert.True(t, defaultLandscape.IsDefault())
+		assert.False(t, canary.IsDefault())
+		assert.Equal(t, "kyma-canary", canary.ProjectName)
+		assert.Equal(t, "canary/kyma-canary/shoot", kubeconfig)
+		assert.Equal(t, []string{"canary", DefaultName}, registry.Names())
+	})
+
+	t.Run("Should fail for a landscape which is not configured", func(t *testing.T) {
+		// given
+		registry, err := NewRegistry(Landscape{})
+		require.NoError(t, err)
+
+		// when
+		_, err = registry.Get("other")
+
+		// then
+		assert.EqualError(t, err, "gardener landscape other is not configured")
+	})
+
+	t.Run("Should reject landscapes configured more than once", func(t *testing.T) {
+		// when
+		_, err := NewRegistry(Landscape{}, Landscape{Name: DefaultName})
+
+		// then
+		assert.EqualError(t, err, "landscape default is configured more than once")
+	})
+}
+
+func TestLoadConfigs(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		content         string
+		expectedConfigs []Config
+		expectedErr     string
+	}{
+		{
+			name:    "Should load the landscape configuration",
+			content: `[{"name": "canary", "kubeconfigPath": "/gardener/canary/kubeconfig", "projectName": "kyma-canary", "clientTimeout": "5s", "clientQPS": 10}]`,
+			expectedConfigs: []Config{
+				{Name: "canary", KubeconfigPath: "/gardener/canary/kubeconfig", ProjectName: "kyma-canary", ClientTimeout: "5s", ClientQPS: 10},
+			},
+		},
+		{
+			name:        "Should reject a landscape without project",
+			content:     `[{"name": "canary", "kubeconfigPath": "/gardener/canary/kubeconfig"}]`,
+			expectedErr: `landscape "canary" must have a name, kubeconfigPath and projectName`,
+		},
+		{
+			name:        "Should reject an invalid client timeout",
+			content:     `[{"name": "canary", "kubeconfigPath": "/gardener/canary/kubeconfig", "projectName": "kyma-canary", "clientTimeout": "soon"}]`,
+			expectedErr: "invalid client timeout of landscape canary",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			path := filepath.Join(t.TempDir(), "landscapes.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			// when
+			configs, err := LoadConfigs(path)
+
+			// then
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfigs, configs)
+		})
+	}
+}
+
+func TestConfigTimeout(t *testing.T) {
+	// when
+	fallback, err := Config{}.Timeout(3 * time.Second)
+	require.NoError(t, err)
+	configured, err := Config{ClientTimeout: "5s"}.Timeout(3 * time.Second)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, 3*time.Second, fallback)
+	assert.Equal(t, 5*time.Second, configured)
+}
+
+func TestNameFromLabels(t *testing.T) {
+	assert.Equal(t, DefaultName, NameFromLabels(nil))
+	assert.Equal(t, "canary", NameFromLabels(map[string]string{imv1.LabelKymaGardenerLandscape: "canary"}))
+}
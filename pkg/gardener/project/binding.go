@@ -0,0 +1,94 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BindingResolver finds the binding of the hyperscaler account of a Runtime in its Gardener project.
+// KEB names the binding of the default project in spec.shoot.secretBindingName, a binding can't be shared
+// between projects, so the other projects must have their own binding of the same Secret.
+type BindingResolver struct {
+	Config       config.GardenerConfig
+	GardenClient client.Reader
+}
+
+type secretReference struct {
+	namespace string
+	name      string
+}
+
+// Resolve returns the name of the SecretBinding, or the CredentialsBinding when they are enabled,
+// which the Shoot of the Runtime uses in its Gardener project
+func (r BindingResolver) Resolve(ctx context.Context, runtime imv1.Runtime) (string, error) {
+	bindingName := runtime.Spec.Shoot.SecretBindingName
+	gardenerProject := FromRuntime(runtime, r.Config.ProjectName)
+	if gardenerProject == r.Config.ProjectName {
+		return bindingName, nil
+	}
+
+	secret, err := r.secretOf(ctx, Namespace(r.Config.ProjectName), bindingName)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := r.bindingOf(ctx, Namespace(gardenerProject), secret)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("gardener project %s has no binding of the secret %s/%s referenced by %s", gardenerProject, secret.namespace, secret.name, bindingName)
+	}
+	return name, nil
+}
+
+func (r BindingResolver) secretOf(ctx context.Context, namespace, name string) (secretReference, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+
+	if r.Config.EnableCredentialBinding {
+		var binding gardenersecurity.CredentialsBinding
+		if err := r.GardenClient.Get(ctx, key, &binding); err != nil {
+			return secretReference{}, errors.Wrapf(err, "failed to get CredentialsBinding %s", key)
+		}
+		return secretReference{namespace: binding.CredentialsRef.Namespace, name: binding.CredentialsRef.Name}, nil
+	}
+
+	var binding gardener.SecretBinding //nolint:staticcheck
+	if err := r.GardenClient.Get(ctx, key, &binding); err != nil {
+		return secretReference{}, errors.Wrapf(err, "failed to get SecretBinding %s", key)
+	}
+	return secretReference{namespace: binding.SecretRef.Namespace, name: binding.SecretRef.Name}, nil
+}
+
+func (r BindingResolver) bindingOf(ctx context.Context, namespace string, secret secretReference) (string, error) {
+	if r.Config.EnableCredentialBinding {
+		var bindings gardenersecurity.CredentialsBindingList
+		if err := r.GardenClient.List(ctx, &bindings, client.InNamespace(namespace)); err != nil {
+			return "", errors.Wrapf(err, "failed to list CredentialsBindings in %s", namespace)
+		}
+		for _, binding := range bindings.Items {
+			if binding.CredentialsRef.Kind == "Secret" && binding.CredentialsRef.Namespace == secret.namespace && binding.CredentialsRef.Name == secret.name {
+				return binding.Name, nil
+			}
+		}
+		return "", nil
+	}
+
+	var bindings gardener.SecretBindingList //nolint:staticcheck
+	if err := r.GardenClient.List(ctx, &bindings, client.InNamespace(namespace)); err != nil {
+		return "", errors.Wrapf(err, "failed to list SecretBindings in %s", namespace)
+	}
+	for _, binding := range bindings.Items {
+		if binding.SecretRef.Namespace == secret.namespace && binding.SecretRef.Name == secret.name {
+			return binding.Name, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,112 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenersecurity "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBindingResolver(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, gardener.AddToScheme(scheme))
+	require.NoError(t, gardenersecurity.AddToScheme(scheme))
+
+	secretBinding := func(namespace, name, secretName string) client.Object {
+		return &gardener.SecretBinding{ //nolint:staticcheck
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			SecretRef:  corev1.SecretReference{Namespace: "garden-kyma", Name: secretName},
+		}
+	}
+
+	credentialsBinding := func(namespace, name, secretName string) client.Object {
+		return &gardenersecurity.CredentialsBinding{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+			CredentialsRef: corev1.ObjectReference{Kind: "Secret", APIVersion: "v1", Namespace: "garden-kyma", Name: secretName},
+		}
+	}
+
+	for _, tc := range []struct {
+		name                    string
+		project                 string
+		enableCredentialBinding bool
+		objects                 []client.Object
+		expectedBinding         string
+		expectedErr             string
+	}{
+		{
+			name:            "Should use the binding of the Runtime in the default project",
+			project:         "kyma",
+			expectedBinding: "aws-account-1",
+		},
+		{
+			name:    "Should use the SecretBinding of the same Secret in the project of the Runtime",
+			project: "kyma-2",
+			objects: []client.Object{
+				secretBinding("garden-kyma", "aws-account-1", "aws-secret-1"),
+				secretBinding("garden-kyma-2", "aws-account-2", "aws-secret-2"),
+				secretBinding("garden-kyma-2", "aws-account-1-shared", "aws-secret-1"),
+			},
+			expectedBinding: "aws-account-1-shared",
+		},
+		{
+			name:                    "Should use the CredentialsBinding of the same Secret in the project of the Runtime",
+			project:                 "kyma-2",
+			enableCredentialBinding: true,
+			objects: []client.Object{
+				credentialsBinding("garden-kyma", "aws-account-1", "aws-secret-1"),
+				credentialsBinding("garden-kyma-2", "aws-account-1-shared", "aws-secret-1"),
+			},
+			expectedBinding: "aws-account-1-shared",
+		},
+		{
+			name:    "Should fail when the project of the Runtime has no binding of the Secret",
+			project: "kyma-2",
+			objects: []client.Object{
+				secretBinding("garden-kyma", "aws-account-1", "aws-secret-1"),
+				secretBinding("garden-kyma-2", "aws-account-2", "aws-secret-2"),
+			},
+			expectedErr: "gardener project kyma-2 has no binding of the secret garden-kyma/aws-secret-1 referenced by aws-account-1",
+		},
+		{
+			name:        "Should fail when the binding of the Runtime doesn't exist in the default project",
+			project:     "kyma-2",
+			expectedErr: `failed to get SecretBinding garden-kyma/aws-account-1: secretbindings.core.gardener.cloud "aws-account-1" not found`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			resolver := BindingResolver{
+				Config: config.GardenerConfig{
+					ProjectName:             "kyma",
+					Projects:                []string{"kyma-2"},
+					EnableCredentialBinding: tc.enableCredentialBinding,
+				},
+				GardenClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build(),
+			}
+
+			runtime := fixRuntimeInProject("runtime-a", tc.project)
+			runtime.Spec.Shoot.SecretBindingName = "aws-account-1"
+
+			// when
+			binding, err := resolver.Resolve(context.Background(), runtime)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBinding, binding)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Namespace returns the namespace of the Gardener project
+func Namespace(project string) string {
+	return fmt.Sprintf("garden-%s", project)
+}
+
+// FromRuntime returns the Gardener project persisted in the annotation of the Runtime CR.
+// Runtime CRs created before the project was persisted use the default project.
+func FromRuntime(runtime imv1.Runtime, defaultProject string) string {
+	if project := runtime.Annotations[imv1.AnnotationGardenerProject]; project != "" {
+		return project
+	}
+	return defaultProject
+}
+
+// NamespaceForRuntime returns the namespace of the Gardener project of the Runtime CR
+func NamespaceForRuntime(runtime imv1.Runtime, defaultProject string) string {
+	return Namespace(FromRuntime(runtime, defaultProject))
+}
+
+type Placer struct {
+	Config config.GardenerConfig
+	// KcpClient lists the Runtime CRs for the least-loaded policy
+	KcpClient client.Reader
+}
+
+// Place chooses the Gardener project of a new Runtime CR according to the placement policy.
+// The default project is used when no policy is configured or the policy doesn't select a project.
+func (p Placer) Place(ctx context.Context, runtime imv1.Runtime) (string, error) {
+	switch p.Config.Placement.Policy {
+	case config.PlacementByLabel:
+		return p.placeByLabel(runtime)
+	case config.PlacementByRegion:
+		return p.placeByRegion(runtime)
+	case config.PlacementLeastLoaded:
+		return p.placeLeastLoaded(ctx, runtime)
+	default:
+		return p.Config.ProjectName, nil
+	}
+}
+
+func (p Placer) placeByLabel(runtime imv1.Runtime) (string, error) {
+	project := runtime.Labels[p.Config.Placement.LabelKey]
+	if project == "" {
+		return p.Config.ProjectName, nil
+	}
+	return p.configured(project)
+}
+
+func (p Placer) placeByRegion(runtime imv1.Runtime) (string, error) {
+	project, found := p.Config.Placement.Regions[runtime.Spec.Shoot.Region]
+	if !found {
+		return p.Config.ProjectName, nil
+	}
+	return p.configured(project)
+}
+
+func (p Placer) placeLeastLoaded(ctx context.Context, placed imv1.Runtime) (string, error) {
+	var runtimes imv1.RuntimeList
+	if err := p.KcpClient.List(ctx, &runtimes); err != nil {
+		return "", errors.Wrap(err, "failed to list runtimes")
+	}
+
+	load := map[string]int{}
+	for _, runtime := range runtimes.Items {
+		if runtime.Name == placed.Name && runtime.Namespace == placed.Namespace {
+			continue
+		}
+		load[FromRuntime(runtime, p.Config.ProjectName)]++
+	}
+
+	// projects are checked in the configured order, so that ties are resolved in favour of the default project
+	projects := p.Config.AllProjects()
+	leastLoaded := projects[0]
+	for _, project := range projects[1:] {
+		if load[project] < load[leastLoaded] {
+			leastLoaded = project
+		}
+	}
+	return leastLoaded, nil
+}
+
+func (p Placer) configured(project string) (string, error) {
+	if !slices.Contains(p.Config.AllProjects(), project) {
+		return "", fmt.Errorf("gardener project %s is not configured", project)
+	}
+	return project, nil
+}
@@ -0,0 +1,146 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFromRuntime(t *testing.T) {
+	t.Run("Should return the project persisted on the Runtime", func(t *testing.T) {
+		// given
+		runtime := fixRuntimeInProject("runtime-a", "kyma-2")
+
+		// when
+		project := FromRuntime(runtime, "kyma")
+
+		// then
+		assert.Equal(t, "kyma-2", project)
+		assert.Equal(t, "garden-kyma-2", NamespaceForRuntime(runtime, "kyma"))
+	})
+
+	t.Run("Should return the default project for a Runtime without project", func(t *testing.T) {
+		// given
+		runtime := fixRuntime("runtime-a", nil, "")
+
+		// when
+		project := FromRuntime(runtime, "kyma")
+
+		// then
+		assert.Equal(t, "kyma", project)
+		assert.Equal(t, "garden-kyma", NamespaceForRuntime(runtime, "kyma"))
+	})
+}
+
+func TestPlacer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, imv1.AddToScheme(scheme))
+
+	existingRuntimes := []client.Object{
+		ptr.To(fixRuntime("existing-1", nil, "")),
+		ptr.To(fixRuntimeInProject("existing-2", "kyma")),
+		ptr.To(fixRuntimeInProject("existing-3", "kyma-2")),
+	}
+
+	for _, tc := range []struct {
+		name            string
+		placement       config.PlacementConfig
+		runtime         imv1.Runtime
+		expectedProject string
+		expectedErr     string
+	}{
+		{
+			name:            "Should use the default project without placement policy",
+			runtime:         fixRuntime("new", nil, "eu-west-1"),
+			expectedProject: "kyma",
+		},
+		{
+			name:            "Should use the project from the label",
+			placement:       config.PlacementConfig{Policy: config.PlacementByLabel, LabelKey: "example.com/project"},
+			runtime:         fixRuntime("new", map[string]string{"example.com/project": "kyma-3"}, "eu-west-1"),
+			expectedProject: "kyma-3",
+		},
+		{
+			name:            "Should use the default project when the label is missing",
+			placement:       config.PlacementConfig{Policy: config.PlacementByLabel, LabelKey: "example.com/project"},
+			runtime:         fixRuntime("new", nil, "eu-west-1"),
+			expectedProject: "kyma",
+		},
+		{
+			name:        "Should fail when the label names a project which is not configured",
+			placement:   config.PlacementConfig{Policy: config.PlacementByLabel, LabelKey: "example.com/project"},
+			runtime:     fixRuntime("new", map[string]string{"example.com/project": "other"}, "eu-west-1"),
+			expectedErr: "gardener project other is not configured",
+		},
+		{
+			name:            "Should use the project of the region",
+			placement:       config.PlacementConfig{Policy: config.PlacementByRegion, Regions: map[string]string{"eu-west-1": "kyma-2"}},
+			runtime:         fixRuntime("new", nil, "eu-west-1"),
+			expectedProject: "kyma-2",
+		},
+		{
+			name:            "Should use the default project for a region without project",
+			placement:       config.PlacementConfig{Policy: config.PlacementByRegion, Regions: map[string]string{"eu-west-1": "kyma-2"}},
+			runtime:         fixRuntime("new", nil, "us-east-1"),
+			expectedProject: "kyma",
+		},
+		{
+			name:            "Should use the project with the fewest runtimes",
+			placement:       config.PlacementConfig{Policy: config.PlacementLeastLoaded},
+			runtime:         fixRuntime("new", nil, "eu-west-1"),
+			expectedProject: "kyma-3",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			kcpClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingRuntimes...).WithObjects(&tc.runtime).Build()
+			placer := Placer{
+				Config: config.GardenerConfig{
+					ProjectName: "kyma",
+					Projects:    []string{"kyma-2", "kyma-3"},
+					Placement:   tc.placement,
+				},
+				KcpClient: kcpClient,
+			}
+
+			// when
+			project, err := placer.Place(context.Background(), tc.runtime)
+
+			// then
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProject, project)
+		})
+	}
+}
+
+func fixRuntime(name string, labels map[string]string, region string) imv1.Runtime {
+	return imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kcp-system",
+			Labels:    labels,
+		},
+		Spec: imv1.RuntimeSpec{
+			Shoot: imv1.RuntimeShoot{Region: region},
+		},
+	}
+}
+
+func fixRuntimeInProject(name, project string) imv1.Runtime {
+	runtime := fixRuntime(name, nil, "")
+	runtime.Annotations = map[string]string{imv1.AnnotationGardenerProject: project}
+	return runtime
+}
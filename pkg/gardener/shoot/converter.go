@@ -2,11 +2,11 @@ package shoot
 
 import (
 	"context"
-	"fmt"
 
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
 	extender2 "github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/extensions"
@@ -17,6 +17,7 @@ import (
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/token"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -54,8 +55,11 @@ type CreateOpts struct {
 	auditlogs.AuditLogData
 	*gardener.MaintenanceTimeWindow
 	KcpClient                       client.Client
+	MachineImages                   []gardener.MachineImage
 	ApiServerAclEnabled             bool
 	NetworkRestrictionGlobalEnabled bool
+	// BindingName is the binding of the hyperscaler account in the Gardener project of the Runtime
+	BindingName string
 }
 
 type PatchOpts struct {
@@ -63,6 +67,7 @@ type PatchOpts struct {
 	auditlogs.AuditLogData
 	*gardener.MaintenanceTimeWindow
 	KcpClient                       client.Client
+	MachineImages                   []gardener.MachineImage
 	ShootK8SVersion                 string
 	Workers                         []gardener.Worker
 	Extensions                      []gardener.Extension
@@ -72,7 +77,12 @@ type PatchOpts struct {
 	ApiServerAclEnabled             bool
 	NetworkRestrictionGlobalEnabled bool
 	ExistingDNS                     *gardener.DNS
+	Hibernation                     *gardener.Hibernation
 	RegistryCacheGardenSecretNames  map[string]string
+	// ExistingSecretBindingName keeps the SecretBinding of a Shoot which wasn't migrated to a CredentialsBinding yet
+	ExistingSecretBindingName *string
+	// BindingName is the binding of the hyperscaler account in the Gardener project of the Runtime
+	BindingName string
 }
 
 func NewConverterCreate(ctx context.Context, opts CreateOpts) Converter {
@@ -83,6 +93,7 @@ func NewConverterCreate(ctx context.Context, opts CreateOpts) Converter {
 			opts.Networking.EnableDualStackIP,
 			opts.Provider.AWS.EnableIMDSv2,
 			opts.MachineImage,
+			opts.MachineImages,
 			opts.Provider.Worker,
 			opts.Provider.GDCH,
 		),
@@ -98,6 +109,7 @@ func NewConverterCreate(ctx context.Context, opts CreateOpts) Converter {
 		extender2.NewKubernetesExtender(opts.Kubernetes.DefaultVersion, ""))
 
 	extendersForCreate = append(extendersForCreate, maintenance.NewMaintenanceExtender(opts.Kubernetes.EnableKubernetesVersionAutoUpdate, opts.Kubernetes.EnableMachineImageVersionAutoUpdate, opts.MaintenanceTimeWindow))
+	extendersForCreate = append(extendersForCreate, extender2.NewHibernationExtender(nil))
 
 	if opts.AuditLogData != (auditlogs.AuditLogData{}) {
 		extendersForCreate = append(extendersForCreate,
@@ -108,7 +120,7 @@ func NewConverterCreate(ctx context.Context, opts CreateOpts) Converter {
 
 	extendersForCreate = append(extendersForCreate, token.NewExpirationTimeExtender(opts.Kubernetes.KubeApiServer.MaxTokenExpiration))
 	extendersForCreate = append(extendersForCreate, networking.ExtendWithNetworking(opts.Networking.EnableDualStackIP))
-	extendersForCreate = append(extendersForCreate, extender2.ExtendWithCredentialsBinding(opts.Gardener.EnableCredentialBinding))
+	extendersForCreate = append(extendersForCreate, extender2.ExtendWithCredentialsBinding(opts.Gardener.EnableCredentialBinding, opts.BindingName))
 	return newConverter(opts.ConverterConfig, extendersForCreate...)
 }
 
@@ -120,6 +132,7 @@ func NewConverterPatch(ctx context.Context, opts PatchOpts) Converter {
 			opts.Provider.AWS.EnableIMDSv2,
 			opts.Workers,
 			opts.MachineImage,
+			opts.MachineImages,
 			opts.Provider.Worker,
 			opts.InfrastructureConfig,
 			opts.ControlPlaneConfig,
@@ -136,7 +149,10 @@ func NewConverterPatch(ctx context.Context, opts PatchOpts) Converter {
 
 	extendersForPatch = append(extendersForPatch, extender2.NewKubernetesExtender(opts.Kubernetes.DefaultVersion, opts.ShootK8SVersion))
 	extendersForPatch = append(extendersForPatch, maintenance.NewMaintenanceExtender(opts.Kubernetes.EnableKubernetesVersionAutoUpdate, opts.Kubernetes.EnableMachineImageVersionAutoUpdate, opts.MaintenanceTimeWindow))
-	extendersForPatch = append(extendersForPatch, extender2.ExtendWithCredentialsBinding(opts.Gardener.EnableCredentialBinding))
+	extendersForPatch = append(extendersForPatch, extender2.NewHibernationExtender(opts.Hibernation))
+	// Gardener doesn't accept the change from SecretBindingName to CredentialsBindingName in a patch, the Shoots are switched with the shoot-credentials-binding migration
+	credentialBindingEnabled := opts.Gardener.EnableCredentialBinding && ptr.Deref(opts.ExistingSecretBindingName, "") == ""
+	extendersForPatch = append(extendersForPatch, extender2.ExtendWithCredentialsBinding(credentialBindingEnabled, opts.BindingName))
 
 	if opts.AuditLogData != (auditlogs.AuditLogData{}) {
 		extendersForPatch = append(extendersForPatch,
@@ -162,7 +178,7 @@ func (c Converter) ToShoot(runtime imv1.Runtime) (gardener.Shoot, error) {
 		},
 		ObjectMeta: v1.ObjectMeta{
 			Name:      runtime.Spec.Shoot.Name,
-			Namespace: fmt.Sprintf("garden-%s", c.config.Gardener.ProjectName),
+			Namespace: project.Namespace(c.config.Gardener.ProjectName),
 		},
 		Spec: gardener.ShootSpec{
 			Purpose: &runtime.Spec.Shoot.Purpose,
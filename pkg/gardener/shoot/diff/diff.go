@@ -0,0 +1,255 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const workersPath = "spec.provider.workers"
+
+// worker settings which cause Gardener to replace the nodes of the worker pool
+var rollingWorkerSettings = []string{
+	"machine.type",
+	"machine.image",
+	"volume",
+	"dataVolumes",
+	"cri",
+	"kubernetes",
+	"providerConfig",
+}
+
+// Result describes the changes which patching the Shoot would introduce.
+type Result struct {
+	// ChangedPaths contains the paths of the changed fields; list items with a name are addressed as `path[name=value]`
+	ChangedPaths []string `json:"changedPaths"`
+	// RollingWorkerPools contains the names of the existing worker pools whose nodes would be replaced
+	RollingWorkerPools []string `json:"rollingWorkerPools"`
+}
+
+// Compute compares the Shoot generated by the converter with the current Shoot.
+// Only the labels, annotations and the spec set in the desired Shoot are compared,
+// as other fields are retained by the server-side apply patch.
+func Compute(current, desired gardener.Shoot) (Result, error) {
+	currentObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&current)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to convert current shoot: %w", err)
+	}
+
+	desiredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&desired)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to convert desired shoot: %w", err)
+	}
+
+	var paths []string
+	for _, field := range []string{"labels", "annotations"} {
+		compare("metadata."+field, nestedValue(currentObj, "metadata", field), nestedValue(desiredObj, "metadata", field), &paths)
+	}
+	compare("spec", currentObj["spec"], desiredObj["spec"], &paths)
+
+	sort.Strings(paths)
+
+	return Result{
+		ChangedPaths:       paths,
+		RollingWorkerPools: rollingWorkerPools(current, desired, paths),
+	}, nil
+}
+
+func compare(path string, current, desired interface{}, paths *[]string) {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		currentValue, ok := current.(map[string]interface{})
+		if !ok {
+			*paths = append(*paths, path)
+			return
+		}
+		for key, value := range desiredValue {
+			compare(path+"."+key, currentValue[key], value, paths)
+		}
+	case []interface{}:
+		currentValue, ok := current.([]interface{})
+		if !ok {
+			*paths = append(*paths, path)
+			return
+		}
+		compareList(path, currentValue, desiredValue, paths)
+	default:
+		if !reflect.DeepEqual(current, desired) {
+			*paths = append(*paths, path)
+		}
+	}
+}
+
+// compareList compares lists of named items by name, and all other lists as a whole.
+func compareList(path string, current, desired []interface{}, paths *[]string) {
+	currentByName, currentNamed := itemsByName(current)
+	desiredByName, desiredNamed := itemsByName(desired)
+
+	if !currentNamed || !desiredNamed {
+		if !reflect.DeepEqual(current, desired) {
+			*paths = append(*paths, path)
+		}
+		return
+	}
+
+	for name, desiredItem := range desiredByName {
+		itemPath := fmt.Sprintf("%s[name=%s]", path, name)
+		currentItem, found := currentByName[name]
+		if !found {
+			*paths = append(*paths, itemPath)
+			continue
+		}
+		compare(itemPath, currentItem, desiredItem, paths)
+	}
+
+	for name := range currentByName {
+		if _, found := desiredByName[name]; !found {
+			*paths = append(*paths, fmt.Sprintf("%s[name=%s]", path, name))
+		}
+	}
+}
+
+func itemsByName(items []interface{}) (map[string]interface{}, bool) {
+	result := make(map[string]interface{}, len(items))
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := itemMap["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		result[name] = itemMap
+	}
+	return result, true
+}
+
+func nestedValue(obj map[string]interface{}, fields ...string) interface{} {
+	var value interface{} = obj
+	for _, field := range fields {
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = valueMap[field]
+	}
+	return value
+}
+
+func rollingWorkerPools(current, desired gardener.Shoot, changedPaths []string) []string {
+	kubernetesMinorChanged := minorVersionChanged(current.Spec.Kubernetes.Version, desired.Spec.Kubernetes.Version)
+
+	desiredWorkers := make(map[string]bool, len(desired.Spec.Provider.Workers))
+	for _, worker := range desired.Spec.Provider.Workers {
+		desiredWorkers[worker.Name] = true
+	}
+
+	var result []string
+	for _, worker := range current.Spec.Provider.Workers {
+		if !desiredWorkers[worker.Name] {
+			continue
+		}
+
+		if kubernetesMinorChanged || hasRollingChange(worker.Name, changedPaths) {
+			result = append(result, worker.Name)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func hasRollingChange(workerName string, changedPaths []string) bool {
+	workerPath := fmt.Sprintf("%s[name=%s].", workersPath, workerName)
+	for _, path := range changedPaths {
+		setting, found := strings.CutPrefix(path, workerPath)
+		if !found {
+			continue
+		}
+		for _, rollingSetting := range rollingWorkerSettings {
+			if setting == rollingSetting || strings.HasPrefix(setting, rollingSetting+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func minorVersionChanged(currentVersion, desiredVersion string) bool {
+	current, err := semver.NewVersion(currentVersion)
+	if err != nil {
+		return false
+	}
+
+	desired, err := semver.NewVersion(desiredVersion)
+	if err != nil {
+		return false
+	}
+
+	return current.Major() != desired.Major() || current.Minor() != desired.Minor()
+}
+
+// fields set by the converter which are updated by Gardener afterwards and therefore must not be reported as drift;
+// `*` matches any name of a list item
+var gardenerOwnedPaths = []string{
+	"spec.kubernetes.version",
+	"spec.provider.workers[name=*].machine.image.version",
+	"spec.hibernation.enabled",
+}
+
+// ComputeDrift compares the Shoot generated by the converter with the current Shoot like Compute does,
+// but skips the fields which Gardener is allowed to change, for example during the maintenance time window.
+func ComputeDrift(current, desired gardener.Shoot) ([]string, error) {
+	result, err := Compute(current, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifted []string
+	for _, path := range result.ChangedPaths {
+		if !isGardenerOwned(path) {
+			drifted = append(drifted, path)
+		}
+	}
+
+	return drifted, nil
+}
+
+func isGardenerOwned(path string) bool {
+	for _, pattern := range gardenerOwnedPaths {
+		if matchesPath(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPath checks if the path is equal to the pattern or is nested below it.
+func matchesPath(pattern, path string) bool {
+	for {
+		before, after, found := strings.Cut(pattern, "*")
+		if !strings.HasPrefix(path, before) {
+			return false
+		}
+		path = path[len(before):]
+
+		if !found {
+			return path == "" || strings.HasPrefix(path, ".") || strings.HasPrefix(path, "[")
+		}
+
+		end := strings.Index(path, "]")
+		if end < 0 {
+			return false
+		}
+		path = path[end:]
+		pattern = after
+	}
+}
@@ -0,0 +1,178 @@
+package diff
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestCompute(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		modifyDesired        func(shoot *gardener.Shoot)
+		expectedChangedPaths []string
+		expectedRollingPools []string
+	}{
+		"Should not report changes for equal shoots": {
+			modifyDesired: func(_ *gardener.Shoot) {},
+		},
+		"Should ignore fields not set in the desired shoot": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Purpose = nil
+				shoot.Annotations = nil
+			},
+		},
+		"Should report changed annotation": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Annotations["infrastructuremanager.kyma-project.io/runtime-generation"] = "3"
+			},
+			expectedChangedPaths: []string{"metadata.annotations.infrastructuremanager.kyma-project.io/runtime-generation"},
+		},
+		"Should report rolling worker pool for changed machine type": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "m6i.xlarge"
+			},
+			expectedChangedPaths: []string{"spec.provider.workers[name=cpu-worker-0].machine.type"},
+			expectedRollingPools: []string{"cpu-worker-0"},
+		},
+		"Should report non rolling change of worker pool": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[1].Maximum = 10
+			},
+			expectedChangedPaths: []string{"spec.provider.workers[name=additional].maximum"},
+		},
+		"Should report added and removed worker pools": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[1].Name = "new"
+			},
+			expectedChangedPaths: []string{
+				"spec.provider.workers[name=additional]",
+				"spec.provider.workers[name=new]",
+			},
+		},
+		"Should report changed list without names as a whole": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Zones = []string{"eu-central-1a"}
+			},
+			expectedChangedPaths: []string{"spec.provider.workers[name=cpu-worker-0].zones"},
+		},
+		"Should report all worker pools rolling for Kubernetes minor version change": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.33.1"
+			},
+			expectedChangedPaths: []string{"spec.kubernetes.version"},
+			expectedRollingPools: []string{"additional", "cpu-worker-0"},
+		},
+		"Should not report rolling worker pools for Kubernetes patch version change": {
+			modifyDesired: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.32.5"
+			},
+			expectedChangedPaths: []string{"spec.kubernetes.version"},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			current := fixShoot()
+			desired := fixShoot()
+			tcase.modifyDesired(&desired)
+
+			// when
+			result, err := Compute(current, desired)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectedChangedPaths, result.ChangedPaths)
+			assert.Equal(t, tcase.expectedRollingPools, result.RollingWorkerPools)
+		})
+	}
+}
+
+func TestComputeDrift(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		modifyCurrent func(shoot *gardener.Shoot)
+		expectedPaths []string
+	}{
+		"Should not report drift for equal shoots": {
+			modifyCurrent: func(_ *gardener.Shoot) {},
+		},
+		"Should report drift for manually changed field": {
+			modifyCurrent: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[0].Machine.Type = "m6i.xlarge"
+			},
+			expectedPaths: []string{"spec.provider.workers[name=cpu-worker-0].machine.type"},
+		},
+		"Should not report Kubernetes version updated by Gardener": {
+			modifyCurrent: func(shoot *gardener.Shoot) {
+				shoot.Spec.Kubernetes.Version = "1.33.1"
+			},
+		},
+		"Should not report machine image version updated by Gardener": {
+			modifyCurrent: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[1].Machine.Image.Version = ptr.To("1592.2.0")
+			},
+		},
+		"Should report machine image name change": {
+			modifyCurrent: func(shoot *gardener.Shoot) {
+				shoot.Spec.Provider.Workers[1].Machine.Image.Name = "ubuntu"
+			},
+			expectedPaths: []string{"spec.provider.workers[name=additional].machine.image.name"},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			current := fixShoot()
+			desired := fixShoot()
+			tcase.modifyCurrent(&current)
+
+			// when
+			paths, err := ComputeDrift(current, desired)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectedPaths, paths)
+		})
+	}
+}
+
+func fixShoot() gardener.Shoot {
+	return gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-shoot",
+			Namespace: "garden-test",
+			Annotations: map[string]string{
+				"infrastructuremanager.kyma-project.io/runtime-generation": "2",
+			},
+		},
+		Spec: gardener.ShootSpec{
+			Purpose: ptr.To(gardener.ShootPurposeProduction),
+			Kubernetes: gardener.Kubernetes{
+				Version: "1.32.3",
+			},
+			Provider: gardener.Provider{
+				Type: "aws",
+				Workers: []gardener.Worker{
+					{
+						Name:    "cpu-worker-0",
+						Machine: gardener.Machine{Type: "m6i.large"},
+						Minimum: 1,
+						Maximum: 3,
+						Zones:   []string{"eu-central-1a", "eu-central-1b"},
+					},
+					{
+						Name: "additional",
+						Machine: gardener.Machine{
+							Type:  "m6i.large",
+							Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")},
+						},
+						Minimum: 1,
+						Maximum: 3,
+						Zones:   []string{"eu-central-1a"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -19,7 +19,7 @@ const (
 
 func ExtendWithCloudProfile(gdchCloudProfileOverride string) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
-		cloudProfileName, err := getCloudProfileName(runtime, gdchCloudProfileOverride)
+		cloudProfileName, err := GetCloudProfileName(runtime, gdchCloudProfileOverride)
 		if err != nil {
 			return err
 		}
@@ -37,7 +37,8 @@ func CreateCloudProfileReference(cloudProfileName string) *gardener.CloudProfile
 	}
 }
 
-func getCloudProfileName(runtime imv1.Runtime, gdchCloudProfileOverride string) (string, error) {
+// GetCloudProfileName returns the name of the CloudProfile used for the Shoot of the Runtime
+func GetCloudProfileName(runtime imv1.Runtime, gdchCloudProfileOverride string) (string, error) {
 	switch runtime.Spec.Shoot.Provider.Type {
 	case hyperscaler.TypeAWS:
 		return DefaultAWSCloudProfileName, nil
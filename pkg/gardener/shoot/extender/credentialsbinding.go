@@ -8,15 +8,20 @@ import (
 
 // ExtendWithCredentialsBinding extends the Shoot with CredentialsBindingName or SecretBindingName
 // This depends on the flag credentialBindingEnabled.
+// The binding named in the Runtime is used unless bindingName is set, which is the case for Runtimes outside of the default Gardener project.
 // Extender can be removed and setting CredentialBindingName can be moved to pkg/gardener/shoot/converter#ToShoot
 // after CredentialBinding migration is finished.
-func ExtendWithCredentialsBinding(credentialBindingEnabled bool) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+func ExtendWithCredentialsBinding(credentialBindingEnabled bool, bindingName string) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		if bindingName == "" {
+			bindingName = runtime.Spec.Shoot.SecretBindingName
+		}
+
 		if credentialBindingEnabled {
-			shoot.Spec.CredentialsBindingName = ptr.To(runtime.Spec.Shoot.SecretBindingName)
+			shoot.Spec.CredentialsBindingName = ptr.To(bindingName)
 			shoot.Spec.SecretBindingName = nil //nolint:staticcheck
 		} else {
-			shoot.Spec.SecretBindingName = ptr.To(runtime.Spec.Shoot.SecretBindingName) //nolint:staticcheck
+			shoot.Spec.SecretBindingName = ptr.To(bindingName) //nolint:staticcheck
 		}
 
 		return nil
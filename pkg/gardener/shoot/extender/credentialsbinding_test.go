@@ -13,7 +13,7 @@ func TestExtendWithCredentialsBinding_CredentialBindingEnabled(t *testing.T) {
 	runtime.Spec.Shoot.SecretBindingName = "my-secret"
 	shoot := &gardener.Shoot{}
 
-	err := ExtendWithCredentialsBinding(true)(runtime, shoot)
+	err := ExtendWithCredentialsBinding(true, "")(runtime, shoot)
 	assert.NoError(t, err)
 	assert.Equal(t, "my-secret", *shoot.Spec.CredentialsBindingName)
 	assert.Nil(t, shoot.Spec.SecretBindingName) //nolint:staticcheck
@@ -24,8 +24,19 @@ func TestExtendWithCredentialsBinding_CredentialBindingDisabled(t *testing.T) {
 	runtime.Spec.Shoot.SecretBindingName = "my-secret"
 	shoot := &gardener.Shoot{}
 
-	err := ExtendWithCredentialsBinding(false)(runtime, shoot)
+	err := ExtendWithCredentialsBinding(false, "")(runtime, shoot)
 	assert.NoError(t, err)
 	assert.Equal(t, "my-secret", *shoot.Spec.SecretBindingName) //nolint:staticcheck
 	assert.Nil(t, shoot.Spec.CredentialsBindingName)
 }
+
+func TestExtendWithCredentialsBinding_BindingOfGardenerProject(t *testing.T) {
+	runtime := imv1.Runtime{}
+	runtime.Spec.Shoot.SecretBindingName = "my-secret"
+	shoot := &gardener.Shoot{}
+
+	err := ExtendWithCredentialsBinding(true, "my-secret-in-project")(runtime, shoot)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-secret-in-project", *shoot.Spec.CredentialsBindingName)
+	assert.Nil(t, shoot.Spec.SecretBindingName) //nolint:staticcheck
+}
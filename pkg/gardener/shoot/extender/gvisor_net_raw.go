@@ -24,6 +24,12 @@ func ExtendWithGVisorNetRawDefault(_ imv1.Runtime, shoot *gardener.Shoot) error
 	return applyDefaultGVisorNetRaw(shoot.Spec.Provider.Workers)
 }
 
+// ApplyGVisorNetRawDefault sets the net-raw default on gVisor container runtimes of the given workers.
+// It is used outside the converter to materialise the default in the Runtime CR.
+func ApplyGVisorNetRawDefault(workers []gardener.Worker) error {
+	return applyDefaultGVisorNetRaw(workers)
+}
+
 func applyDefaultGVisorNetRaw(workers []gardener.Worker) error {
 	for i := range workers {
 		if workers[i].CRI == nil {
@@ -0,0 +1,39 @@
+package extender
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"k8s.io/utils/ptr"
+)
+
+// NewHibernationExtender creates a new hibernation extender function.
+// It sets the hibernation settings of the Shoot to the ones specified in the Runtime.
+// When hibernation schedules are defined, Gardener switches `hibernation.enabled` of the Shoot according to the schedules.
+// In that case the value from the existing Shoot (`currentHibernation`) is retained, so that patching the Shoot does not revert
+// a scheduled hibernation or wake-up.
+func NewHibernationExtender(currentHibernation *gardener.Hibernation) func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error {
+		hibernation := runtime.Spec.Shoot.Hibernation
+		if hibernation == nil {
+			return nil
+		}
+
+		shoot.Spec.Hibernation = &gardener.Hibernation{
+			Enabled: hibernation.Enabled,
+		}
+
+		for _, schedule := range hibernation.Schedules {
+			shoot.Spec.Hibernation.Schedules = append(shoot.Spec.Hibernation.Schedules, gardener.HibernationSchedule{
+				Start:    schedule.Start,
+				End:      schedule.End,
+				Location: schedule.Location,
+			})
+		}
+
+		if len(hibernation.Schedules) > 0 && currentHibernation != nil && currentHibernation.Enabled != nil {
+			shoot.Spec.Hibernation.Enabled = ptr.To(*currentHibernation.Enabled)
+		}
+
+		return nil
+	}
+}
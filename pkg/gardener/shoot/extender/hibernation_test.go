@@ -0,0 +1,72 @@
+package extender
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestHibernationExtender(t *testing.T) {
+	schedules := []imv1.HibernationSchedule{
+		{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 07 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+	}
+
+	for tname, tcase := range map[string]struct {
+		givenHibernation    *imv1.Hibernation
+		currentHibernation  *gardener.Hibernation
+		expectedHibernation *gardener.Hibernation
+	}{
+		"Should not set hibernation when not specified in the Runtime": {
+			givenHibernation:    nil,
+			expectedHibernation: nil,
+		},
+		"Should set hibernation enabled flag": {
+			givenHibernation:    &imv1.Hibernation{Enabled: ptr.To(true)},
+			currentHibernation:  &gardener.Hibernation{Enabled: ptr.To(false)},
+			expectedHibernation: &gardener.Hibernation{Enabled: ptr.To(true)},
+		},
+		"Should set hibernation schedules": {
+			givenHibernation: &imv1.Hibernation{Enabled: ptr.To(false), Schedules: schedules},
+			expectedHibernation: &gardener.Hibernation{
+				Enabled: ptr.To(false),
+				Schedules: []gardener.HibernationSchedule{
+					{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 07 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+				},
+			},
+		},
+		"Should retain enabled flag set by Gardener when schedules are defined": {
+			givenHibernation:   &imv1.Hibernation{Enabled: ptr.To(false), Schedules: schedules},
+			currentHibernation: &gardener.Hibernation{Enabled: ptr.To(true)},
+			expectedHibernation: &gardener.Hibernation{
+				Enabled: ptr.To(true),
+				Schedules: []gardener.HibernationSchedule{
+					{Start: ptr.To("00 20 * * 1,2,3,4,5"), End: ptr.To("00 07 * * 1,2,3,4,5"), Location: ptr.To("Europe/Berlin")},
+				},
+			},
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("test", "kcp-system")
+			runtime := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Hibernation: tcase.givenHibernation,
+					},
+				},
+			}
+
+			// when
+			err := NewHibernationExtender(tcase.currentHibernation)(runtime, &shoot)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tcase.expectedHibernation, shoot.Spec.Hibernation)
+		})
+	}
+}
@@ -6,17 +6,37 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// NewMaintenanceExtender sets the maintenance of the Shoot. The auto-update settings and the time window
+// specified in the Runtime CR take precedence over the values from `converter_config.json` and the regional maintenance window.
 func NewMaintenanceExtender(enableKubernetesVersionAutoUpdate, enableMachineImageVersionAutoUpdate bool, maintenanceTimeWindow *gardener.MaintenanceTimeWindow) func(runtime imv1.Runtime, shoot *gardener.Shoot) error { //nolint:revive
 	return func(runtime imv1.Runtime, shoot *gardener.Shoot) error { //nolint:revive
+		kubernetesVersionAutoUpdate := enableKubernetesVersionAutoUpdate
+		machineImageVersionAutoUpdate := enableMachineImageVersionAutoUpdate
+		timeWindow := maintenanceTimeWindow
+
+		if runtimeMaintenance := runtime.Spec.Shoot.Maintenance; runtimeMaintenance != nil {
+			if runtimeMaintenance.AutoUpdate != nil {
+				kubernetesVersionAutoUpdate = ptr.Deref(runtimeMaintenance.AutoUpdate.KubernetesVersion, kubernetesVersionAutoUpdate)
+				machineImageVersionAutoUpdate = ptr.Deref(runtimeMaintenance.AutoUpdate.MachineImageVersion, machineImageVersionAutoUpdate)
+			}
+
+			if runtimeMaintenance.TimeWindow != nil {
+				if err := ValidateTimeWindow(*runtimeMaintenance.TimeWindow); err != nil {
+					return err
+				}
+				timeWindow = runtimeMaintenance.TimeWindow
+			}
+		}
+
 		shoot.Spec.Maintenance = &gardener.Maintenance{
 			AutoUpdate: &gardener.MaintenanceAutoUpdate{
-				KubernetesVersion:   enableKubernetesVersionAutoUpdate,
-				MachineImageVersion: ptr.To(enableMachineImageVersionAutoUpdate),
+				KubernetesVersion:   kubernetesVersionAutoUpdate,
+				MachineImageVersion: ptr.To(machineImageVersionAutoUpdate),
 			},
 		}
 
-		if maintenanceTimeWindow != nil {
-			shoot.Spec.Maintenance.TimeWindow = maintenanceTimeWindow
+		if timeWindow != nil {
+			shoot.Spec.Maintenance.TimeWindow = timeWindow
 		}
 
 		return nil
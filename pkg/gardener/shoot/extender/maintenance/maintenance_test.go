@@ -7,6 +7,7 @@ import (
 	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 )
 
 func TestMaintenanceExtender(t *testing.T) {
@@ -72,3 +73,82 @@ func TestMaintenanceExtender(t *testing.T) {
 		})
 	}
 }
+
+func TestMaintenanceExtenderWithRuntimeOverride(t *testing.T) {
+	regionalTimeWindow := &gardener.MaintenanceTimeWindow{
+		Begin: "200000+0000",
+		End:   "230000+0000",
+	}
+	runtimeTimeWindow := &gardener.MaintenanceTimeWindow{
+		Begin: "010000+0100",
+		End:   "020000+0100",
+	}
+
+	for _, testCase := range []struct {
+		name                                  string
+		maintenance                           *imv1.Maintenance
+		expectedKubernetesVersionAutoUpdate   bool
+		expectedMachineImageVersionAutoUpdate bool
+		expectedTimeWindow                    *gardener.MaintenanceTimeWindow
+		expectedErr                           bool
+	}{
+		{
+			name:                                  "Should use the values from the configuration when the Runtime has no maintenance settings",
+			expectedKubernetesVersionAutoUpdate:   true,
+			expectedMachineImageVersionAutoUpdate: true,
+			expectedTimeWindow:                    regionalTimeWindow,
+		},
+		{
+			name:                                  "Should use the time window from the Runtime instead of the regional time window",
+			maintenance:                           &imv1.Maintenance{TimeWindow: runtimeTimeWindow},
+			expectedKubernetesVersionAutoUpdate:   true,
+			expectedMachineImageVersionAutoUpdate: true,
+			expectedTimeWindow:                    runtimeTimeWindow,
+		},
+		{
+			name: "Should override only the auto-update settings specified in the Runtime",
+			maintenance: &imv1.Maintenance{AutoUpdate: &imv1.MaintenanceAutoUpdate{
+				MachineImageVersion: ptr.To(false),
+			}},
+			expectedKubernetesVersionAutoUpdate:   true,
+			expectedMachineImageVersionAutoUpdate: false,
+			expectedTimeWindow:                    regionalTimeWindow,
+		},
+		{
+			name: "Should return error for invalid time window from the Runtime",
+			maintenance: &imv1.Maintenance{TimeWindow: &gardener.MaintenanceTimeWindow{
+				Begin: "010000+0100",
+				End:   "011000+0100",
+			}},
+			expectedErr: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			shoot := testutils.FixEmptyGardenerShoot("test", "dev")
+			runtimeShoot := imv1.Runtime{
+				Spec: imv1.RuntimeSpec{
+					Shoot: imv1.RuntimeShoot{
+						Name:        "test",
+						Maintenance: testCase.maintenance,
+					},
+				},
+			}
+
+			// when
+			extender := NewMaintenanceExtender(true, true, regionalTimeWindow)
+			err := extender(runtimeShoot, &shoot)
+
+			// then
+			if testCase.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedKubernetesVersionAutoUpdate, shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion)
+			assert.Equal(t, testCase.expectedMachineImageVersionAutoUpdate, *shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion)
+			assert.Equal(t, testCase.expectedTimeWindow, shoot.Spec.Maintenance.TimeWindow)
+		})
+	}
+}
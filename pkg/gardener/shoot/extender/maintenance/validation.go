@@ -0,0 +1,23 @@
+package maintenance
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/utils/timewindow"
+	"github.com/pkg/errors"
+)
+
+// ValidateTimeWindow checks the format of the maintenance time window and whether its length is accepted by Gardener.
+func ValidateTimeWindow(window gardener.MaintenanceTimeWindow) error {
+	timeWindow, err := timewindow.ParseMaintenanceTimeWindow(window.Begin, window.End)
+	if err != nil {
+		return errors.Wrap(err, "invalid maintenance time window")
+	}
+
+	duration := timeWindow.Duration()
+	if duration < gardener.MaintenanceTimeWindowDurationMinimum || duration > gardener.MaintenanceTimeWindowDurationMaximum {
+		return errors.Errorf("maintenance time window must be between %s and %s long, but is %s",
+			gardener.MaintenanceTimeWindowDurationMinimum, gardener.MaintenanceTimeWindowDurationMaximum, duration)
+	}
+
+	return nil
+}
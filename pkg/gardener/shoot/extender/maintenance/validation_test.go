@@ -0,0 +1,53 @@
+package maintenance
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTimeWindow(t *testing.T) {
+	for _, testCase := range []struct {
+		name        string
+		timeWindow  gardener.MaintenanceTimeWindow
+		expectedErr string
+	}{
+		{
+			name:       "Should accept one hour time window",
+			timeWindow: gardener.MaintenanceTimeWindow{Begin: "220000+0100", End: "230000+0100"},
+		},
+		{
+			name:       "Should accept time window passing midnight",
+			timeWindow: gardener.MaintenanceTimeWindow{Begin: "230000+0000", End: "030000+0000"},
+		},
+		{
+			name:        "Should reject time window in invalid format",
+			timeWindow:  gardener.MaintenanceTimeWindow{Begin: "22:00", End: "230000+0100"},
+			expectedErr: "invalid maintenance time window",
+		},
+		{
+			name:        "Should reject time window shorter than 30 minutes",
+			timeWindow:  gardener.MaintenanceTimeWindow{Begin: "220000+0100", End: "221500+0100"},
+			expectedErr: "maintenance time window must be between 30m0s and 6h0m0s long, but is 15m0s",
+		},
+		{
+			name:        "Should reject time window longer than 6 hours",
+			timeWindow:  gardener.MaintenanceTimeWindow{Begin: "200000+0000", End: "040000+0000"},
+			expectedErr: "maintenance time window must be between 30m0s and 6h0m0s long, but is 8h0m0s",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			err := ValidateTimeWindow(testCase.timeWindow)
+
+			// then
+			if testCase.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, testCase.expectedErr)
+		})
+	}
+}
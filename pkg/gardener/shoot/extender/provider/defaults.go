@@ -0,0 +1,37 @@
+package provider
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+)
+
+// ApplyWorkerDefaults sets the machine image and Machine Controller Manager settings of the workers
+// to the defaults from `converter_config.json` when they are not specified.
+// The same defaults are applied by the provider extender while converting a Runtime to a Shoot.
+// When the machine image version is resolved from the CloudProfile, only the machine image name is set,
+// so that the version is resolved with every conversion instead of being stored in the Runtime.
+func ApplyWorkerDefaults(workers []gardener.Worker, machineImageCfg config.MachineImageConfig, workerMachineCfg config.WorkerConfig) error {
+	if machineImageCfg.IsPinned() {
+		if err := setMachineImage(&gardener.Provider{Workers: workers}, machineImageCfg, nil); err != nil {
+			return err
+		}
+	} else {
+		setMachineImageName(workers, machineImageCfg.DefaultName)
+	}
+
+	return setWorkerMachineControllerManager(workers, workerMachineCfg.DefaultMachineDrainTimeout, workerMachineCfg.DefaultMaxEvictRetries)
+}
+
+func setMachineImageName(workers []gardener.Worker, defMachineImgName string) {
+	for i := range workers {
+		worker := &workers[i]
+
+		if worker.Machine.Image == nil {
+			worker.Machine.Image = &gardener.ShootMachineImage{}
+		}
+
+		if worker.Machine.Image.Name == "" {
+			worker.Machine.Image.Name = defMachineImgName
+		}
+	}
+}
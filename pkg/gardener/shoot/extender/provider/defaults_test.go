@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestApplyWorkerDefaults(t *testing.T) {
+	machineImageCfg := config.MachineImageConfig{DefaultName: "gardenlinux", DefaultVersion: "1592.1.0"}
+	workerCfg := config.WorkerConfig{DefaultMachineDrainTimeout: "30m", DefaultMaxEvictRetries: "5"}
+
+	t.Run("Should set defaults for worker without machine image and MCM settings", func(t *testing.T) {
+		// given
+		workers := []gardener.Worker{{Name: "worker", Machine: gardener.Machine{Type: "m6i.large"}}}
+
+		// when
+		err := ApplyWorkerDefaults(workers, machineImageCfg, workerCfg)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, workers[0].Machine.Image)
+		assert.Equal(t, "gardenlinux", workers[0].Machine.Image.Name)
+		assert.Equal(t, "1592.1.0", *workers[0].Machine.Image.Version)
+		require.NotNil(t, workers[0].MachineControllerManagerSettings)
+		assert.Equal(t, 30*time.Minute, workers[0].MachineControllerManagerSettings.MachineDrainTimeout.Duration)
+		assert.Equal(t, int32(5), *workers[0].MachineControllerManagerSettings.MaxEvictRetries)
+	})
+
+	t.Run("Should keep values specified in the worker", func(t *testing.T) {
+		// given
+		workers := []gardener.Worker{{
+			Name: "worker",
+			Machine: gardener.Machine{
+				Type:  "m6i.large",
+				Image: &gardener.ShootMachineImage{Name: "ubuntu", Version: ptr.To("22.4.0")},
+			},
+			MachineControllerManagerSettings: &gardener.MachineControllerManagerSettings{
+				MachineDrainTimeout: &metav1.Duration{Duration: time.Hour},
+				MaxEvictRetries:     ptr.To(int32(1)),
+			},
+		}}
+
+		// when
+		err := ApplyWorkerDefaults(workers, machineImageCfg, workerCfg)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "ubuntu", workers[0].Machine.Image.Name)
+		assert.Equal(t, "22.4.0", *workers[0].Machine.Image.Version)
+		assert.Equal(t, time.Hour, workers[0].MachineControllerManagerSettings.MachineDrainTimeout.Duration)
+		assert.Equal(t, int32(1), *workers[0].MachineControllerManagerSettings.MaxEvictRetries)
+	})
+
+	t.Run("Should set only machine image name when version is resolved from the CloudProfile", func(t *testing.T) {
+		// given
+		workers := []gardener.Worker{{Name: "worker", Machine: gardener.Machine{Type: "m6i.large"}}}
+		latestSupportedCfg := config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestSupported}
+
+		// when
+		err := ApplyWorkerDefaults(workers, latestSupportedCfg, workerCfg)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, workers[0].Machine.Image)
+		assert.Equal(t, "gardenlinux", workers[0].Machine.Image.Name)
+		assert.Nil(t, workers[0].Machine.Image.Version)
+	})
+
+	t.Run("Should return error for invalid drain timeout", func(t *testing.T) {
+		// given
+		workers := []gardener.Worker{{Name: "worker"}}
+
+		// when
+		err := ApplyWorkerDefaults(workers, machineImageCfg, config.WorkerConfig{DefaultMachineDrainTimeout: "invalid"})
+
+		// then
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/cloudprofile"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/pkg/errors"
+)
+
+// ResolveMachineImageVersion returns the version of the default machine image chosen with the `machineImage.versionStrategy` set in `converter_config.json`.
+// The pinned strategy returns `machineImage.defaultVersion`, the other strategies pick a version from the machine images offered by the CloudProfile.
+// Expired versions and versions classified as preview, expired or unavailable are never chosen.
+func ResolveMachineImageVersion(machineImageCfg config.MachineImageConfig, machineImages []gardener.MachineImage, now time.Time) (string, error) {
+	if machineImageCfg.IsPinned() {
+		return machineImageCfg.DefaultVersion, nil
+	}
+
+	if machineImages == nil {
+		return "", fmt.Errorf("machine images from the CloudProfile are required for the %s machine image version strategy", machineImageCfg.VersionStrategy)
+	}
+
+	var versions []gardener.MachineImageVersion
+	for _, machineImage := range machineImages {
+		if machineImage.Name == machineImageCfg.DefaultName {
+			versions = machineImage.Versions
+			break
+		}
+	}
+
+	var matches func(version gardener.MachineImageVersion) bool
+
+	switch machineImageCfg.VersionStrategy {
+	case config.MachineImageVersionLatestSupported:
+		matches = func(version gardener.MachineImageVersion) bool {
+			return version.Classification == nil || *version.Classification == gardener.ClassificationSupported
+		}
+	case config.MachineImageVersionLatestPatch:
+		matches = func(version gardener.MachineImageVersion) bool {
+			return version.Version == machineImageCfg.MinorVersion || strings.HasPrefix(version.Version, machineImageCfg.MinorVersion+".")
+		}
+	default:
+		return "", fmt.Errorf("unsupported machine image version strategy %s", machineImageCfg.VersionStrategy)
+	}
+
+	latest := ""
+	for _, version := range versions {
+		if !cloudprofile.IsVersionUsable(version.ExpirableVersion, now) || isPreview(version) || !matches(version) {
+			continue
+		}
+
+		if latest == "" {
+			latest = version.Version
+			continue
+		}
+
+		result, err := extender.CompareVersions(version.Version, latest)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to compare versions of machine image %s", machineImageCfg.DefaultName)
+		}
+		if result > 0 {
+			latest = version.Version
+		}
+	}
+
+	if latest == "" {
+		return "", fmt.Errorf("no version of machine image %s matching the %s machine image version strategy is available in the CloudProfile", machineImageCfg.DefaultName, machineImageCfg.VersionStrategy)
+	}
+
+	return latest, nil
+}
+
+func isPreview(version gardener.MachineImageVersion) bool {
+	return version.Classification != nil && *version.Classification == gardener.ClassificationPreview
+}
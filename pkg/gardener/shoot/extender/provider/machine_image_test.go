@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestResolveMachineImageVersion(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	machineImages := []gardener.MachineImage{
+		{
+			Name: "gardenlinux",
+			Versions: []gardener.MachineImageVersion{
+				fixMachineImageVersion("1443.10.0", gardener.ClassificationDeprecated, nil),
+				fixMachineImageVersion("1592.1.0", gardener.ClassificationSupported, nil),
+				fixMachineImageVersion("1592.12.0", gardener.ClassificationSupported, nil),
+				fixMachineImageVersion("1592.2.0", gardener.ClassificationSupported, nil),
+				fixMachineImageVersion("1592.13.0", gardener.ClassificationSupported, ptr.To(now.Add(-time.Hour))),
+				fixMachineImageVersion("1877.0.0", gardener.ClassificationPreview, nil),
+				fixMachineImageVersion("1443.11.0", gardener.ClassificationExpired, nil),
+			},
+		},
+		{
+			Name: "ubuntu",
+			Versions: []gardener.MachineImageVersion{
+				fixMachineImageVersion("2204.0.0", gardener.ClassificationSupported, nil),
+			},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name            string
+		machineImageCfg config.MachineImageConfig
+		machineImages   []gardener.MachineImage
+		expectedVersion string
+		expectedErr     string
+	}{
+		{
+			name:            "Should return default version when strategy is not set",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", DefaultVersion: "1312.3.0"},
+			expectedVersion: "1312.3.0",
+		},
+		{
+			name:            "Should return default version for pinned strategy without looking at the CloudProfile",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", DefaultVersion: "1312.3.0", VersionStrategy: config.MachineImageVersionPinned},
+			machineImages:   machineImages,
+			expectedVersion: "1312.3.0",
+		},
+		{
+			name:            "Should return highest supported and not expired version for latest-supported strategy",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestSupported},
+			machineImages:   machineImages,
+			expectedVersion: "1592.12.0",
+		},
+		{
+			name:            "Should return highest not expired patch version of the minor version for latest-patch strategy",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestPatch, MinorVersion: "1443"},
+			machineImages:   machineImages,
+			expectedVersion: "1443.10.0",
+		},
+		{
+			name:            "Should not match minor version which is only a prefix of another minor version",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestPatch, MinorVersion: "159"},
+			machineImages:   machineImages,
+			expectedErr:     "no version of machine image gardenlinux matching the latest-patch machine image version strategy is available in the CloudProfile",
+		},
+		{
+			name:            "Should not return preview versions",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestPatch, MinorVersion: "1877"},
+			machineImages:   machineImages,
+			expectedErr:     "no version of machine image gardenlinux matching the latest-patch machine image version strategy is available in the CloudProfile",
+		},
+		{
+			name:            "Should return error when machine image is not offered by the CloudProfile",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "suse-chost", VersionStrategy: config.MachineImageVersionLatestSupported},
+			machineImages:   machineImages,
+			expectedErr:     "no version of machine image suse-chost matching the latest-supported machine image version strategy is available in the CloudProfile",
+		},
+		{
+			name:            "Should return error when machine images from the CloudProfile are missing",
+			machineImageCfg: config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestSupported},
+			expectedErr:     "machine images from the CloudProfile are required for the latest-supported machine image version strategy",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			version, err := ResolveMachineImageVersion(testCase.machineImageCfg, testCase.machineImages, now)
+
+			// then
+			if testCase.expectedErr != "" {
+				require.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+		})
+	}
+}
+
+func TestSetMachineImageWithVersionStrategy(t *testing.T) {
+	machineImageCfg := config.MachineImageConfig{DefaultName: "gardenlinux", VersionStrategy: config.MachineImageVersionLatestSupported}
+	machineImages := []gardener.MachineImage{
+		{
+			Name: "gardenlinux",
+			Versions: []gardener.MachineImageVersion{
+				fixMachineImageVersion("1592.1.0", gardener.ClassificationSupported, nil),
+				fixMachineImageVersion("1592.2.0", gardener.ClassificationSupported, nil),
+			},
+		},
+		{
+			Name: "ubuntu",
+			Versions: []gardener.MachineImageVersion{
+				fixMachineImageVersion("22.4.0", gardener.ClassificationSupported, nil),
+				fixMachineImageVersion("24.4.0", gardener.ClassificationPreview, nil),
+			},
+		},
+	}
+
+	t.Run("Should set resolved version for workers without machine image version", func(t *testing.T) {
+		// given
+		provider := &gardener.Provider{Workers: []gardener.Worker{
+			{Name: "main"},
+			{Name: "additional", Machine: gardener.Machine{Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")}}},
+		}}
+
+		// when
+		err := setMachineImage(provider, machineImageCfg, machineImages)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gardenlinux", provider.Workers[0].Machine.Image.Name)
+		assert.Equal(t, "1592.2.0", *provider.Workers[0].Machine.Image.Version)
+		assert.Equal(t, "1592.1.0", *provider.Workers[1].Machine.Image.Version)
+	})
+
+	t.Run("Should resolve version of another machine image than the default one", func(t *testing.T) {
+		// given
+		provider := &gardener.Provider{Workers: []gardener.Worker{
+			{Name: "main"},
+			{Name: "additional", Machine: gardener.Machine{Image: &gardener.ShootMachineImage{Name: "ubuntu"}}},
+		}}
+
+		// when
+		err := setMachineImage(provider, machineImageCfg, machineImages)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gardenlinux", provider.Workers[0].Machine.Image.Name)
+		assert.Equal(t, "1592.2.0", *provider.Workers[0].Machine.Image.Version)
+		assert.Equal(t, "ubuntu", provider.Workers[1].Machine.Image.Name)
+		assert.Equal(t, "22.4.0", *provider.Workers[1].Machine.Image.Version)
+	})
+
+	t.Run("Should fail for another machine image without version with the pinned strategy", func(t *testing.T) {
+		// given
+		pinnedCfg := config.MachineImageConfig{DefaultName: "gardenlinux", DefaultVersion: "1592.1.0"}
+		provider := &gardener.Provider{Workers: []gardener.Worker{
+			{Name: "main"},
+			{Name: "additional", Machine: gardener.Machine{Image: &gardener.ShootMachineImage{Name: "ubuntu"}}},
+		}}
+
+		// when
+		err := setMachineImage(provider, pinnedCfg, nil)
+
+		// then
+		require.EqualError(t, err, "worker additional uses machine image ubuntu without a version, which can't be resolved with the pinned machine image version strategy")
+		assert.Equal(t, "1592.1.0", *provider.Workers[0].Machine.Image.Version)
+	})
+
+	t.Run("Should not require CloudProfile when all workers specify the machine image version", func(t *testing.T) {
+		// given
+		provider := &gardener.Provider{Workers: []gardener.Worker{
+			{Name: "main", Machine: gardener.Machine{Image: &gardener.ShootMachineImage{Name: "gardenlinux", Version: ptr.To("1592.1.0")}}},
+		}}
+
+		// when
+		err := setMachineImage(provider, machineImageCfg, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1592.1.0", *provider.Workers[0].Machine.Image.Version)
+	})
+}
+
+func fixMachineImageVersion(version string, classification gardener.VersionClassification, expirationDate *time.Time) gardener.MachineImageVersion {
+	machineImageVersion := gardener.MachineImageVersion{
+		ExpirableVersion: gardener.ExpirableVersion{
+			Version:        version,
+			Classification: ptr.To(classification),
+		},
+	}
+
+	if expirationDate != nil {
+		machineImageVersion.ExpirationDate = &metav1.Time{Time: *expirationDate}
+	}
+
+	return machineImageVersion
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/kyma-project/infrastructure-manager/pkg/config"
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
@@ -20,18 +21,18 @@ import (
 	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler/openstack"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 // InfrastructureConfig and ControlPlaneConfig are generated unless they are specified in the RuntimeCR
-func NewProviderExtenderForCreateOperation(infraSupportsDualStack bool, enableIMDSv2 bool, machineImageCfg config.MachineImageConfig, workerMachineCfg config.WorkerConfig, gdhcConfig config.GDCHConfig) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
+func NewProviderExtenderForCreateOperation(infraSupportsDualStack bool, enableIMDSv2 bool, machineImageCfg config.MachineImageConfig, machineImages []gardener.MachineImage, workerMachineCfg config.WorkerConfig, gdhcConfig config.GDCHConfig) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 		provider := &shoot.Spec.Provider
 		provider.Type = rt.Spec.Shoot.Provider.Type
 		provider.Workers = rt.Spec.Shoot.Provider.Workers
 
-		//NOTE: we can have this code moved to validation webhook later on
-		if len(rt.Spec.Shoot.Provider.Workers) != 1 {
-			return errors.New("single main worker is required")
+		if err := validateMainWorker(rt); err != nil {
+			return err
 		}
 
 		if rt.Spec.Shoot.Provider.AdditionalWorkers != nil {
@@ -50,7 +51,9 @@ func NewProviderExtenderForCreateOperation(infraSupportsDualStack bool, enableIM
 		provider.ControlPlaneConfig = controlPlaneConf
 		provider.InfrastructureConfig = infraConfig
 
-		setMachineImage(provider, machineImageCfg.DefaultName, machineImageCfg.DefaultVersion)
+		if err = setMachineImage(provider, machineImageCfg, machineImages); err != nil {
+			return err
+		}
 		if err = setWorkerConfig(provider, provider.Type, enableIMDSv2); err != nil {
 			return err
 		}
@@ -67,7 +70,7 @@ func NewProviderExtenderForCreateOperation(infraSupportsDualStack bool, enableIM
 }
 
 // Zones for patching workes are taken from existing shoot workers
-func NewProviderExtenderPatchOperation(enableIMDSv2 bool, shootWorkers []gardener.Worker, machineImageCfg config.MachineImageConfig, workerMachineCfg config.WorkerConfig, existingInfraConfig, existingControlPlaneConfig *runtime.RawExtension, gdhcOptions config.GDCHConfig) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
+func NewProviderExtenderPatchOperation(enableIMDSv2 bool, shootWorkers []gardener.Worker, machineImageCfg config.MachineImageConfig, machineImages []gardener.MachineImage, workerMachineCfg config.WorkerConfig, existingInfraConfig, existingControlPlaneConfig *runtime.RawExtension, gdhcOptions config.GDCHConfig) func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 	return func(rt imv1.Runtime, shoot *gardener.Shoot) error {
 		provider := &shoot.Spec.Provider
 		provider.Type = rt.Spec.Shoot.Provider.Type
@@ -115,7 +118,9 @@ func NewProviderExtenderPatchOperation(enableIMDSv2 bool, shootWorkers []gardene
 			provider.InfrastructureConfig = infraConfig
 		}
 
-		setMachineImage(provider, machineImageCfg.DefaultName, machineImageCfg.DefaultVersion)
+		if err = setMachineImage(provider, machineImageCfg, machineImages); err != nil {
+			return err
+		}
 
 		if err := setWorkerConfig(provider, provider.Type, enableIMDSv2); err != nil {
 			return err
@@ -327,25 +332,59 @@ func setWorkerSettings(provider *gardener.Provider, podsCIDR string) error {
 }
 
 // It sets the machine image name and version to the values specified in the Runtime worker configuration.
-// If any value is not specified in the Runtime, it sets it as `machineImage.defaultVersion` or `machineImage.defaultName`, set in `converter_config.json`.
-func setMachineImage(provider *gardener.Provider, defMachineImgName, defMachineImgVer string) {
+// If any value is not specified in the Runtime, it sets it as `machineImage.defaultName` set in `converter_config.json`
+// and the version resolved with the `machineImage.versionStrategy` (see ResolveMachineImageVersion).
+// A worker with another machine image and without a version gets the latest supported version of its own machine image.
+func setMachineImage(provider *gardener.Provider, machineImageCfg config.MachineImageConfig, machineImages []gardener.MachineImage) error {
+	resolvedVersions := map[string]string{}
+
 	for i := 0; i < len(provider.Workers); i++ {
 		worker := &provider.Workers[i]
 
+		if worker.Machine.Image != nil && worker.Machine.Image.Name != "" && worker.Machine.Image.Version != nil && *worker.Machine.Image.Version != "" {
+			continue
+		}
+
 		if worker.Machine.Image == nil {
-			worker.Machine.Image = &gardener.ShootMachineImage{
-				Name:    defMachineImgName,
-				Version: &defMachineImgVer,
-			}
+			worker.Machine.Image = &gardener.ShootMachineImage{}
+		}
+		if worker.Machine.Image.Name == "" {
+			worker.Machine.Image.Name = machineImageCfg.DefaultName
 		}
-		if worker.Machine.Image.Version == nil || *worker.Machine.Image.Version == "" {
-			worker.Machine.Image.Version = &defMachineImgVer
+		if worker.Machine.Image.Version != nil && *worker.Machine.Image.Version != "" {
+			continue
 		}
 
-		if worker.Machine.Image.Name == "" {
-			worker.Machine.Image.Name = defMachineImgName
+		imageName := worker.Machine.Image.Name
+		version, found := resolvedVersions[imageName]
+		if !found {
+			var err error
+			version, err = resolveWorkerMachineImageVersion(worker.Name, imageName, machineImageCfg, machineImages)
+			if err != nil {
+				return err
+			}
+			resolvedVersions[imageName] = version
 		}
+		worker.Machine.Image.Version = ptr.To(version)
+	}
+
+	return nil
+}
+
+// resolveWorkerMachineImageVersion resolves the version of the machine image of a worker, the version strategy applies only to the default machine image
+func resolveWorkerMachineImageVersion(workerName, imageName string, machineImageCfg config.MachineImageConfig, machineImages []gardener.MachineImage) (string, error) {
+	if imageName == machineImageCfg.DefaultName {
+		return ResolveMachineImageVersion(machineImageCfg, machineImages, time.Now())
 	}
+
+	if machineImageCfg.IsPinned() {
+		return "", errors.Errorf("worker %s uses machine image %s without a version, which can't be resolved with the %s machine image version strategy", workerName, imageName, config.MachineImageVersionPinned)
+	}
+
+	return ResolveMachineImageVersion(config.MachineImageConfig{
+		DefaultName:     imageName,
+		VersionStrategy: config.MachineImageVersionLatestSupported,
+	}, machineImages, time.Now())
 }
 
 // We can't predict what will be the order of zones stored by Gardener.
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// ValidateProvider checks if the provider section of the Runtime can be converted into the Shoot provider configuration.
+// It runs the same worker checks and hyperscaler specific zone generators which are used by NewProviderExtenderForCreateOperation,
+// so that invalid worker counts, zone numbers or node CIDRs are reported without creating the Shoot.
+func ValidateProvider(rt imv1.Runtime, infraSupportsDualStack bool, gdchConfig config.GDCHConfig) error {
+	if err := validateMainWorker(rt); err != nil {
+		return err
+	}
+
+	workers := rt.Spec.Shoot.Provider.Workers
+	if rt.Spec.Shoot.Provider.AdditionalWorkers != nil {
+		workers = append(append([]gardener.Worker{}, workers...), *rt.Spec.Shoot.Provider.AdditionalWorkers...)
+	}
+
+	canEnableDualStack := rt.Spec.Shoot.Networking.DualStack != nil && *rt.Spec.Shoot.Networking.DualStack && infraSupportsDualStack
+
+	_, _, err := getConfig(rt.Spec.Shoot, getNetworkingZonesFromWorkers(workers), canEnableDualStack, nil, gdchConfig)
+	return err
+}
+
+func validateMainWorker(rt imv1.Runtime) error {
+	if len(rt.Spec.Shoot.Provider.Workers) != 1 {
+		return errors.New("single main worker is required")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/hyperscaler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProvider(t *testing.T) {
+	fixRuntime := func(provider imv1.Provider, nodesCIDR string) imv1.Runtime {
+		return imv1.Runtime{
+			Spec: imv1.RuntimeSpec{
+				Shoot: imv1.RuntimeShoot{
+					Provider: provider,
+					Networking: imv1.Networking{
+						Nodes:    nodesCIDR,
+						Pods:     "100.64.0.0/12",
+						Services: "100.104.0.0/13",
+					},
+				},
+			},
+		}
+	}
+
+	for tname, tcase := range map[string]struct {
+		givenRuntime  imv1.Runtime
+		expectedError string
+	}{
+		"Should accept AWS runtime with three zones": {
+			givenRuntime: fixRuntime(fixProvider(hyperscaler.TypeAWS, "gardenlinux", "1312.3.0", []string{"eu-central-1a", "eu-central-1b", "eu-central-1c"}), "10.250.0.0/16"),
+		},
+		"Should accept AWS runtime with zones spread over additional workers": {
+			givenRuntime: fixRuntime(fixProviderWithMultipleWorkers(hyperscaler.TypeAWS, fixMultipleWorkers([]workerConfig{
+				{Name: "main", MachineType: "m6i.large", Zones: []string{"eu-central-1a"}},
+				{Name: "additional", MachineType: "m6i.large", Zones: []string{"eu-central-1b"}},
+			})), "10.250.0.0/16"),
+		},
+		"Should reject runtime without main worker": {
+			givenRuntime:  fixRuntime(imv1.Provider{Type: hyperscaler.TypeAWS}, "10.250.0.0/16"),
+			expectedError: "single main worker is required",
+		},
+		"Should reject runtime with more than one main worker": {
+			givenRuntime: fixRuntime(imv1.Provider{
+				Type:    hyperscaler.TypeAWS,
+				Workers: append(fixWorkers("worker-1", "m6i.large", "", "", 1, 3, []string{"eu-central-1a"}), fixWorkers("worker-2", "m6i.large", "", "", 1, 3, []string{"eu-central-1a"})...),
+			}, "10.250.0.0/16"),
+			expectedError: "single main worker is required",
+		},
+		"Should reject AWS runtime without zones": {
+			givenRuntime:  fixRuntime(fixProvider(hyperscaler.TypeAWS, "gardenlinux", "1312.3.0", nil), "10.250.0.0/16"),
+			expectedError: "Number of networking zones must be between 1 and 8",
+		},
+		"Should reject AWS runtime with too small nodes CIDR": {
+			givenRuntime:  fixRuntime(fixProvider(hyperscaler.TypeAWS, "gardenlinux", "1312.3.0", []string{"eu-central-1a"}), "10.250.0.0/25"),
+			expectedError: "CIDR prefix length must be between 16 and 24",
+		},
+		"Should reject Azure runtime with invalid zone name": {
+			givenRuntime:  fixRuntime(fixProvider(hyperscaler.TypeAzure, "gardenlinux", "1312.3.0", []string{"9"}), "10.250.0.0/16"),
+			expectedError: "zone name 9 is not valid",
+		},
+		"Should reject unknown provider": {
+			givenRuntime:  fixRuntime(fixProvider("unknown", "gardenlinux", "1312.3.0", []string{"zone-a"}), "10.250.0.0/16"),
+			expectedError: "provider not supported",
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			// when
+			err := ValidateProvider(tcase.givenRuntime, false, config.GDCHConfig{})
+
+			// then
+			if tcase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorContains(t, err, tcase.expectedError)
+		})
+	}
+
+	t.Run("Should not modify workers of the Runtime", func(t *testing.T) {
+		// given
+		workers := fixMultipleWorkers([]workerConfig{
+			{Name: "main", MachineType: "m6i.large", Zones: []string{"eu-central-1a"}},
+			{Name: "additional", MachineType: "m6i.large", Zones: []string{"eu-central-1b"}},
+		})
+		rt := fixRuntime(fixProviderWithMultipleWorkers(hyperscaler.TypeAWS, workers), "10.250.0.0/16")
+
+		// when
+		err := ValidateProvider(rt, false, config.GDCHConfig{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, rt.Spec.Shoot.Provider.Workers, 1)
+		assert.Equal(t, []gardener.Worker{workers[1]}, *rt.Spec.Shoot.Provider.AdditionalWorkers)
+	})
+}
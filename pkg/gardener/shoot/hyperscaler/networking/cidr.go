@@ -0,0 +1,46 @@
+package networking
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/pkg/errors"
+)
+
+// NamedCIDR is a CIDR block annotated with the name of the field it comes from, used for error reporting.
+type NamedCIDR struct {
+	Name string
+	CIDR string
+}
+
+// ValidateCIDR verifies if the given value is a valid CIDR block.
+func ValidateCIDR(cidr string) error {
+	_, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return errors.Wrapf(err, "invalid CIDR %q", cidr)
+	}
+	return nil
+}
+
+// ValidateNonOverlappingCIDRs verifies if all given CIDR blocks are valid and no two of them overlap.
+func ValidateNonOverlappingCIDRs(cidrs ...NamedCIDR) error {
+	prefixes := make([]netip.Prefix, len(cidrs))
+
+	for i, named := range cidrs {
+		prefix, err := netip.ParsePrefix(named.CIDR)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s CIDR %q", named.Name, named.CIDR)
+		}
+		prefixes[i] = prefix.Masked()
+	}
+
+	for i := range prefixes {
+		for j := i + 1; j < len(prefixes); j++ {
+			if prefixes[i].Overlaps(prefixes[j]) {
+				return fmt.Errorf("%s CIDR %q overlaps with %s CIDR %q", cidrs[i].Name, cidrs[i].CIDR, cidrs[j].Name, cidrs[j].CIDR)
+			}
+		}
+	}
+
+	return nil
+}
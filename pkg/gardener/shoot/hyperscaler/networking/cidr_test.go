@@ -0,0 +1,67 @@
+package networking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCIDR(t *testing.T) {
+	t.Run("Should accept valid CIDR", func(t *testing.T) {
+		assert.NoError(t, ValidateCIDR("10.250.0.0/16"))
+	})
+
+	t.Run("Should reject invalid CIDR", func(t *testing.T) {
+		err := ValidateCIDR("10.250.0.0")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid CIDR")
+	})
+}
+
+func TestValidateNonOverlappingCIDRs(t *testing.T) {
+	for tname, tcase := range map[string]struct {
+		givenCIDRs    []NamedCIDR
+		expectedError string
+	}{
+		"Should accept default Kyma networking": {
+			givenCIDRs: []NamedCIDR{
+				{Name: "nodes", CIDR: "10.250.0.0/16"},
+				{Name: "pods", CIDR: "100.64.0.0/12"},
+				{Name: "services", CIDR: "100.104.0.0/13"},
+			},
+		},
+		"Should reject overlapping nodes and pods": {
+			givenCIDRs: []NamedCIDR{
+				{Name: "nodes", CIDR: "10.250.0.0/16"},
+				{Name: "pods", CIDR: "10.250.128.0/17"},
+			},
+			expectedError: `nodes CIDR "10.250.0.0/16" overlaps with pods CIDR "10.250.128.0/17"`,
+		},
+		"Should reject overlapping CIDRs with host bits set": {
+			givenCIDRs: []NamedCIDR{
+				{Name: "pods", CIDR: "100.64.0.0/12"},
+				{Name: "services", CIDR: "100.65.1.1/16"},
+			},
+			expectedError: `pods CIDR "100.64.0.0/12" overlaps with services CIDR "100.65.1.1/16"`,
+		},
+		"Should reject malformed CIDR": {
+			givenCIDRs: []NamedCIDR{
+				{Name: "nodes", CIDR: "10.250.0.0/16"},
+				{Name: "services", CIDR: "not-a-cidr"},
+			},
+			expectedError: `invalid services CIDR "not-a-cidr"`,
+		},
+	} {
+		t.Run(tname, func(t *testing.T) {
+			err := ValidateNonOverlappingCIDRs(tcase.givenCIDRs...)
+
+			if tcase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tcase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package shoot
+
+import (
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/kyma-project/infrastructure-manager/pkg/config"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/project"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/auditlogs"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender/maintenance"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OptsInputs are the inputs of the conversion which are read from the KCP and the Gardener cluster
+type OptsInputs struct {
+	ConverterConfig                 config.ConverterConfig
+	AuditLogData                    auditlogs.AuditLogData
+	KcpClient                       client.Client
+	MachineImages                   []gardener.MachineImage
+	ApiServerAclEnabled             bool
+	NetworkRestrictionGlobalEnabled bool
+	// RegistryCacheGardenSecretNames maps the registry caches to their Secrets in the Gardener project, used only for patching
+	RegistryCacheGardenSecretNames map[string]string
+	// BindingName is the binding of the hyperscaler account in the Gardener project of the Runtime, see project.BindingResolver
+	BindingName string
+}
+
+// NewCreateOpts returns the options creating the Shoot of the Runtime in its Gardener project.
+// A failure to read the regional maintenance time window is returned together with the options, which are usable without it.
+func NewCreateOpts(runtime imv1.Runtime, inputs OptsInputs) (CreateOpts, error) {
+	maintenanceTimeWindow, err := RegionalMaintenanceTimeWindow(runtime, inputs.ConverterConfig)
+
+	return CreateOpts{
+		ConverterConfig:                 converterConfigForRuntime(runtime, inputs.ConverterConfig),
+		AuditLogData:                    inputs.AuditLogData,
+		MaintenanceTimeWindow:           maintenanceTimeWindow,
+		KcpClient:                       inputs.KcpClient,
+		MachineImages:                   inputs.MachineImages,
+		ApiServerAclEnabled:             inputs.ApiServerAclEnabled,
+		NetworkRestrictionGlobalEnabled: inputs.NetworkRestrictionGlobalEnabled,
+		BindingName:                     inputs.BindingName,
+	}, err
+}
+
+// NewPatchOpts returns the options patching the existing Shoot of the Runtime.
+// A failure to read the regional maintenance time window is returned together with the options, which are usable without it.
+func NewPatchOpts(runtime imv1.Runtime, shoot gardener.Shoot, inputs OptsInputs) (PatchOpts, error) {
+	maintenanceTimeWindow, err := RegionalMaintenanceTimeWindow(runtime, inputs.ConverterConfig)
+
+	return PatchOpts{
+		ConverterConfig:                 converterConfigForRuntime(runtime, inputs.ConverterConfig),
+		AuditLogData:                    inputs.AuditLogData,
+		MaintenanceTimeWindow:           maintenanceTimeWindow,
+		KcpClient:                       inputs.KcpClient,
+		MachineImages:                   inputs.MachineImages,
+		Workers:                         shoot.Spec.Provider.Workers,
+		ShootK8SVersion:                 shoot.Spec.Kubernetes.Version,
+		Extensions:                      shoot.Spec.Extensions,
+		Resources:                       shoot.Spec.Resources,
+		InfrastructureConfig:            shoot.Spec.Provider.InfrastructureConfig,
+		ControlPlaneConfig:              shoot.Spec.Provider.ControlPlaneConfig,
+		ApiServerAclEnabled:             inputs.ApiServerAclEnabled,
+		ExistingDNS:                     shoot.Spec.DNS,
+		Hibernation:                     shoot.Spec.Hibernation,
+		NetworkRestrictionGlobalEnabled: inputs.NetworkRestrictionGlobalEnabled,
+		RegistryCacheGardenSecretNames:  inputs.RegistryCacheGardenSecretNames,
+		ExistingSecretBindingName:       shoot.Spec.SecretBindingName, //nolint:staticcheck
+		BindingName:                     inputs.BindingName,
+	}, err
+}
+
+// RegionalMaintenanceTimeWindow returns the regional maintenance time window for production runtimes.
+// The regional time window is not read when the Runtime specifies its own one, which is applied by the maintenance extender.
+func RegionalMaintenanceTimeWindow(runtime imv1.Runtime, cfg config.ConverterConfig) (*gardener.MaintenanceTimeWindow, error) {
+	if runtime.Spec.Shoot.Maintenance != nil && runtime.Spec.Shoot.Maintenance.TimeWindow != nil {
+		return nil, nil
+	}
+
+	if runtime.Spec.Shoot.Purpose != "production" || cfg.MaintenanceWindow.WindowMapPath == "" {
+		return nil, nil
+	}
+
+	return maintenance.GetMaintenanceWindow(cfg.MaintenanceWindow.WindowMapPath, runtime.Spec.Shoot.Region)
+}
+
+// converterConfigForRuntime creates the Shoot in the Gardener project of the Runtime
+func converterConfigForRuntime(runtime imv1.Runtime, cfg config.ConverterConfig) config.ConverterConfig {
+	cfg.Gardener.ProjectName = project.FromRuntime(runtime, cfg.Gardener.ProjectName)
+	return cfg
+}
@@ -0,0 +1,57 @@
+package shoot
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpts(t *testing.T) {
+	t.Run("Should create the Shoot in the Gardener project of the Runtime", func(t *testing.T) {
+		// given
+		runtime := fixRuntime(gardener.ShootPurposeEvaluation)
+		runtime.Annotations = map[string]string{imv1.AnnotationGardenerProject: "kyma-dev-2"}
+
+		// when
+		opts, err := NewCreateOpts(runtime, OptsInputs{ConverterConfig: fixConverterConfig()})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "kyma-dev-2", opts.Gardener.ProjectName)
+		assert.Nil(t, opts.MaintenanceTimeWindow)
+	})
+
+	t.Run("Should keep the existing Shoot settings and the registry cache Secrets when patching", func(t *testing.T) {
+		// given
+		runtime := fixRuntime(gardener.ShootPurposeEvaluation)
+		shoot := gardener.Shoot{Spec: gardener.ShootSpec{Kubernetes: gardener.Kubernetes{Version: "1.33.5"}}}
+		secretNames := map[string]string{"cache-uid": "reg-cache-abcde"}
+
+		// when
+		opts, err := NewPatchOpts(runtime, shoot, OptsInputs{ConverterConfig: fixConverterConfig(), RegistryCacheGardenSecretNames: secretNames})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "1.33.5", opts.ShootK8SVersion)
+		assert.Equal(t, secretNames, opts.RegistryCacheGardenSecretNames)
+		assert.Equal(t, fixConverterConfig().Gardener.ProjectName, opts.Gardener.ProjectName)
+	})
+
+	t.Run("Should return the options without the regional maintenance time window when it can't be read", func(t *testing.T) {
+		// given
+		runtime := fixRuntime(gardener.ShootPurposeProduction)
+		cfg := fixConverterConfig()
+		cfg.MaintenanceWindow.WindowMapPath = "testdata/missing.json"
+
+		// when
+		opts, err := NewCreateOpts(runtime, OptsInputs{ConverterConfig: cfg})
+
+		// then
+		require.Error(t, err)
+		assert.Nil(t, opts.MaintenanceTimeWindow)
+		assert.Equal(t, cfg.Gardener.ProjectName, opts.Gardener.ProjectName)
+	})
+}
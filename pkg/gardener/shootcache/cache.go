@@ -0,0 +1,101 @@
+package shootcache
+
+import (
+	"context"
+	"reflect"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Cache is the cache of the Shoots in the namespaces of the Gardener projects which is kept up to date with a watch.
+// The namespaces are fixed when the cache is created, the Shoots of Gardener projects added later
+// by a reload of the configuration aren't watched.
+type Cache struct {
+	cache.Cache
+	namespaces map[string]struct{}
+}
+
+// Watches returns true when the Shoots of the namespace are kept in the cache
+func (c *Cache) Watches(namespace string) bool {
+	_, ok := c.namespaces[namespace]
+	return ok
+}
+
+// NewCache creates the cache of the Shoots in the namespaces of the Gardener projects.
+// The cache has to be added to the manager, so that it is started together with the controllers.
+func NewCache(ctx context.Context, gardenRestConfig *rest.Config, namespaces []string) (*Cache, error) {
+	if len(namespaces) == 0 {
+		return nil, errors.New("at least one Gardener project namespace is required")
+	}
+
+	scheme := runtime.NewScheme()
+	if err := gardener.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrap(err, "failed to register Gardener schema")
+	}
+
+	defaultNamespaces := make(map[string]cache.Config, len(namespaces))
+	watchedNamespaces := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		defaultNamespaces[namespace] = cache.Config{}
+		watchedNamespaces[namespace] = struct{}{}
+	}
+
+	shootCache, err := cache.New(gardenRestConfig, cache.Options{
+		Scheme:            scheme,
+		DefaultNamespaces: defaultNamespaces,
+		// the managed fields are the largest part of a Shoot and are never read by KIM
+		DefaultTransform: cache.TransformStripManagedFields(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Shoot cache")
+	}
+
+	// register the informer upfront, so that the watch is started when the cache is started and not with the first lookup
+	if _, err = shootCache.GetInformer(ctx, &gardener.Shoot{}); err != nil {
+		return nil, errors.Wrap(err, "failed to create Shoot informer")
+	}
+
+	return &Cache{Cache: shootCache, namespaces: watchedNamespaces}, nil
+}
+
+// RuntimeID returns the runtime ID annotated on a Shoot created by KIM, or an empty string for other Shoots
+func RuntimeID(shoot *gardener.Shoot) string {
+	return shoot.Annotations[extender.ShootRuntimeIDAnnotation]
+}
+
+// ProgressPredicate passes the Shoot events relevant for the Runtime controller: creations of Shoots whose last
+// operation hasn't succeeded yet, changes of the last operation, the generation or the observed generation, and deletions.
+// The creations of Shoots which are already reconciled are dropped, so that the initial listing of the Shoots
+// after a restart doesn't trigger the reconciliation of all runtimes.
+func ProgressPredicate() predicate.TypedPredicate[*gardener.Shoot] {
+	return predicate.TypedFuncs[*gardener.Shoot]{
+		CreateFunc: func(e event.TypedCreateEvent[*gardener.Shoot]) bool {
+			if e.Object == nil || RuntimeID(e.Object) == "" {
+				return false
+			}
+			lastOperation := e.Object.Status.LastOperation
+			return lastOperation == nil || lastOperation.State != gardener.LastOperationStateSucceeded
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*gardener.Shoot]) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil || RuntimeID(e.ObjectNew) == "" {
+				return false
+			}
+			return e.ObjectOld.Generation != e.ObjectNew.Generation ||
+				e.ObjectOld.Status.ObservedGeneration != e.ObjectNew.Status.ObservedGeneration ||
+				!reflect.DeepEqual(e.ObjectOld.Status.LastOperation, e.ObjectNew.Status.LastOperation)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*gardener.Shoot]) bool {
+			return e.Object != nil && RuntimeID(e.Object) != ""
+		},
+		GenericFunc: func(event.TypedGenericEvent[*gardener.Shoot]) bool {
+			return false
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package shootcache
+
+import (
+	"testing"
+
+	gardener "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/kyma-project/infrastructure-manager/pkg/gardener/shoot/extender"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestProgressPredicate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		modify   func(shoot *gardener.Shoot)
+		expected bool
+	}{
+		{
+			name:     "Should pass changed last operation",
+			modify:   func(shoot *gardener.Shoot) { shoot.Status.LastOperation.State = gardener.LastOperationStateSucceeded },
+			expected: true,
+		},
+		{
+			name:     "Should pass changed progress of last operation",
+			modify:   func(shoot *gardener.Shoot) { shoot.Status.LastOperation.Progress = 80 },
+			expected: true,
+		},
+		{
+			name:     "Should pass changed generation",
+			modify:   func(shoot *gardener.Shoot) { shoot.Generation = 3 },
+			expected: true,
+		},
+		{
+			name:     "Should pass changed observed generation",
+			modify:   func(shoot *gardener.Shoot) { shoot.Status.ObservedGeneration = 2 },
+			expected: true,
+		},
+		{
+			name:     "Should drop changed labels",
+			modify:   func(shoot *gardener.Shoot) { shoot.Labels = map[string]string{"foo": "bar"} },
+			expected: false,
+		},
+		{
+			name: "Should drop Shoots not created by KIM",
+			modify: func(shoot *gardener.Shoot) {
+				shoot.Annotations = nil
+				shoot.Status.LastOperation.State = gardener.LastOperationStateSucceeded
+			},
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			oldShoot := fixShoot()
+			newShoot := oldShoot.DeepCopy()
+			tc.modify(newShoot)
+
+			// when
+			passed := ProgressPredicate().Update(event.TypedUpdateEvent[*gardener.Shoot]{ObjectOld: oldShoot, ObjectNew: newShoot})
+
+			// then
+			assert.Equal(t, tc.expected, passed)
+		})
+	}
+
+	t.Run("Should pass delete events", func(t *testing.T) {
+		// given
+		shoot := fixShoot()
+
+		// when
+		deleted := ProgressPredicate().Delete(event.TypedDeleteEvent[*gardener.Shoot]{Object: shoot})
+
+		// then
+		assert.True(t, deleted)
+	})
+}
+
+func TestProgressPredicateCreate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		modify   func(shoot *gardener.Shoot)
+		expected bool
+	}{
+		{
+			name:     "Should pass created Shoot without last operation",
+			modify:   func(shoot *gardener.Shoot) { shoot.Status.LastOperation = nil },
+			expected: true,
+		},
+		{
+			name:     "Should pass created Shoot with last operation in progress",
+			modify:   func(shoot *gardener.Shoot) {},
+			expected: true,
+		},
+		{
+			name:     "Should pass created Shoot with failed last operation",
+			modify:   func(shoot *gardener.Shoot) { shoot.Status.LastOperation.State = gardener.LastOperationStateFailed },
+			expected: true,
+		},
+		{
+			name:     "Should drop reconciled Shoot listed after a restart",
+			modify:   func(shoot *gardener.Shoot) { shoot.Status.LastOperation.State = gardener.LastOperationStateSucceeded },
+			expected: false,
+		},
+		{
+			name:     "Should drop Shoots not created by KIM",
+			modify:   func(shoot *gardener.Shoot) { shoot.Annotations = nil },
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			shoot := fixShoot()
+			tc.modify(shoot)
+
+			// when
+			passed := ProgressPredicate().Create(event.TypedCreateEvent[*gardener.Shoot]{Object: shoot})
+
+			// then
+			assert.Equal(t, tc.expected, passed)
+		})
+	}
+}
+
+func TestCacheWatches(t *testing.T) {
+	// given
+	shootCache := &Cache{namespaces: map[string]struct{}{"garden-kyma": {}}}
+
+	// then
+	assert.True(t, shootCache.Watches("garden-kyma"))
+	assert.False(t, shootCache.Watches("garden-kyma-2"))
+}
+
+func fixShoot() *gardener.Shoot {
+	return &gardener.Shoot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-shoot",
+			Namespace:   "garden-test",
+			Generation:  2,
+			Annotations: map[string]string{extender.ShootRuntimeIDAnnotation: "runtime-id"},
+		},
+		Status: gardener.ShootStatus{
+			ObservedGeneration: 1,
+			LastOperation: &gardener.LastOperation{
+				Type:     gardener.LastOperationTypeReconcile,
+				State:    gardener.LastOperationStateProcessing,
+				Progress: 50,
+			},
+		},
+	}
+}
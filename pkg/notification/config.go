@@ -0,0 +1,106 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMaxAttempts  = 10
+	defaultInitialDelay = 10 * time.Second
+	defaultMaxDelay     = 30 * time.Minute
+)
+
+// Config is the notification configuration file
+type Config struct {
+	Sinks []SinkConfig `json:"sinks"`
+	// MaxAttempts is the number of delivery attempts of a notification before it is dropped, defaults to 10
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialRetryDelay and MaxRetryDelay limit the exponential backoff between the delivery attempts, default to 10s and 30m
+	InitialRetryDelay string `json:"initialRetryDelay"`
+	MaxRetryDelay     string `json:"maxRetryDelay"`
+}
+
+// SinkConfig describes an HTTP endpoint receiving the notifications
+type SinkConfig struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// SigningKeyPath is the path of the mounted Secret key used to sign the notifications with HMAC-SHA256
+	SigningKeyPath string `json:"signingKeyPath"`
+	// EventTypes limits the notifications sent to the sink, all event types are sent when it is empty
+	EventTypes []EventType `json:"eventTypes"`
+}
+
+// Subscribed returns true when the sink receives notifications of the event type
+func (c SinkConfig) Subscribed(eventType EventType) bool {
+	return len(c.EventTypes) == 0 || slices.Contains(c.EventTypes, eventType)
+}
+
+// RetryPolicy returns the number of delivery attempts and the backoff between them
+func (c Config) RetryPolicy() (RetryPolicy, error) {
+	policy := RetryPolicy{MaxAttempts: c.MaxAttempts, InitialDelay: defaultInitialDelay, MaxDelay: defaultMaxDelay}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = defaultMaxAttempts
+	}
+
+	var err error
+	if c.InitialRetryDelay != "" {
+		if policy.InitialDelay, err = time.ParseDuration(c.InitialRetryDelay); err != nil {
+			return RetryPolicy{}, errors.Wrap(err, "invalid initial retry delay")
+		}
+	}
+	if c.MaxRetryDelay != "" {
+		if policy.MaxDelay, err = time.ParseDuration(c.MaxRetryDelay); err != nil {
+			return RetryPolicy{}, errors.Wrap(err, "invalid maximal retry delay")
+		}
+	}
+
+	if policy.MaxAttempts < 0 || policy.InitialDelay <= 0 || policy.MaxDelay < policy.InitialDelay {
+		return RetryPolicy{}, fmt.Errorf("invalid retry policy: the number of attempts must not be negative, the initial delay must be greater than zero and not greater than the maximal delay")
+	}
+	return policy, nil
+}
+
+// LoadConfig reads the notification configuration from a JSON file
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "failed to read notification configuration")
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, errors.Wrap(err, "failed to decode notification configuration")
+	}
+
+	names := map[string]bool{}
+	for _, sink := range cfg.Sinks {
+		if sink.Name == "" || sink.URL == "" || sink.SigningKeyPath == "" {
+			return Config{}, fmt.Errorf("notification sink %q must have a name, url and signingKeyPath", sink.Name)
+		}
+		if names[sink.Name] {
+			return Config{}, fmt.Errorf("notification sink %s is configured more than once", sink.Name)
+		}
+		names[sink.Name] = true
+
+		if _, err := url.ParseRequestURI(sink.URL); err != nil {
+			return Config{}, errors.Wrapf(err, "invalid url of notification sink %s", sink.Name)
+		}
+		for _, eventType := range sink.EventTypes {
+			if !slices.Contains(EventTypes, eventType) {
+				return Config{}, fmt.Errorf("unknown event type %s of notification sink %s", eventType, sink.Name)
+			}
+		}
+	}
+
+	if _, err := cfg.RetryPolicy(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
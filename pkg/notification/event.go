@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"encoding/json"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// EventType is the lifecycle transition of a Runtime a notification is sent for
+type EventType string
+
+const (
+	EventTypeCreated         EventType = "created"
+	EventTypeShootCreated    EventType = "shoot-created"
+	EventTypeKubeconfigReady EventType = "kubeconfig-ready"
+	EventTypeReady           EventType = "ready"
+	EventTypeFailed          EventType = "failed"
+	EventTypeDeleting        EventType = "deleting"
+	EventTypeDeleted         EventType = "deleted"
+)
+
+// EventTypes lists all event types in the order of the Runtime lifecycle
+var EventTypes = []EventType{ //nolint:gochecknoglobals
+	EventTypeCreated,
+	EventTypeShootCreated,
+	EventTypeKubeconfigReady,
+	EventTypeReady,
+	EventTypeFailed,
+	EventTypeDeleting,
+	EventTypeDeleted,
+}
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsSource      = "kyma-infrastructure-manager"
+	cloudEventsTypePrefix  = "io.kyma-project.infrastructure-manager.runtime."
+	// ContentType is the media type of CloudEvents in the structured content mode
+	ContentType = "application/cloudevents+json"
+)
+
+// CloudEvent is a notification in the structured content mode of the CloudEvents specification 1.0
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// Data is the payload of a notification
+type Data struct {
+	RuntimeID       string `json:"runtimeId"`
+	GlobalAccountID string `json:"globalAccountId,omitempty"`
+	SubaccountID    string `json:"subaccountId,omitempty"`
+	ShootName       string `json:"shootName,omitempty"`
+	State           string `json:"state,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// NewEvent creates the notification of a lifecycle transition of the Runtime
+func NewEvent(runtime imv1.Runtime, eventType EventType, reason string, now time.Time) CloudEvent {
+	runtimeID := runtime.Labels[imv1.LabelKymaRuntimeID]
+	if runtimeID == "" {
+		runtimeID = runtime.Name
+	}
+
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          cloudEventsSource,
+		Type:            cloudEventsTypePrefix + string(eventType),
+		Subject:         runtimeID,
+		Time:            now.UTC(),
+		DataContentType: "application/json",
+		Data: Data{
+			RuntimeID:       runtimeID,
+			GlobalAccountID: runtime.Labels[imv1.LabelKymaGlobalAccountID],
+			SubaccountID:    runtime.Labels[imv1.LabelKymaSubaccountID],
+			ShootName:       runtime.Spec.Shoot.Name,
+			State:           string(runtime.Status.State),
+			Reason:          reason,
+		},
+	}
+}
+
+func (e CloudEvent) Marshal() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode notification")
+	}
+	return data, nil
+}
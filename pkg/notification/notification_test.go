@@ -0,0 +1,216 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoadConfig(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "notifications.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("Should load sinks and retry policy", func(t *testing.T) {
+		// given
+		path := writeConfig(t, `{
+			"sinks": [{"name": "broker", "url": "https://broker.example.com/runtimes", "signingKeyPath": "/notification/broker/key", "eventTypes": ["ready", "failed"]}],
+			"maxAttempts": 5,
+			"initialRetryDelay": "30s"
+		}`)
+
+		// when
+		cfg, err := LoadConfig(path)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, cfg.Sinks, 1)
+		assert.True(t, cfg.Sinks[0].Subscribed(EventTypeReady))
+		assert.False(t, cfg.Sinks[0].Subscribed(EventTypeCreated))
+
+		policy, err := cfg.RetryPolicy()
+		require.NoError(t, err)
+		assert.Equal(t, RetryPolicy{MaxAttempts: 5, InitialDelay: 30 * time.Second, MaxDelay: 30 * time.Minute}, policy)
+	})
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "Should reject sink without signing key",
+			content: `{"sinks": [{"name": "broker", "url": "https://broker.example.com"}]}`,
+			err:     `notification sink "broker" must have a name, url and signingKeyPath`,
+		},
+		{
+			name:    "Should reject sinks configured more than once",
+			content: `{"sinks": [{"name": "broker", "url": "https://a.example.com", "signingKeyPath": "/key"}, {"name": "broker", "url": "https://b.example.com", "signingKeyPath": "/key"}]}`,
+			err:     "notification sink broker is configured more than once",
+		},
+		{
+			name:    "Should reject unknown event type",
+			content: `{"sinks": [{"name": "broker", "url": "https://broker.example.com", "signingKeyPath": "/key", "eventTypes": ["hibernated"]}]}`,
+			err:     "unknown event type hibernated of notification sink broker",
+		},
+		{
+			name:    "Should reject invalid retry delay",
+			content: `{"sinks": [], "initialRetryDelay": "1h", "maxRetryDelay": "1m"}`,
+			err:     "invalid retry policy: the number of attempts must not be negative, the initial delay must be greater than zero and not greater than the maximal delay",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := LoadConfig(writeConfig(t, tc.content))
+
+			// then
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialDelay: 10 * time.Second, MaxDelay: time.Minute}
+
+	assert.Equal(t, 10*time.Second, policy.Delay(1))
+	assert.Equal(t, 20*time.Second, policy.Delay(2))
+	assert.Equal(t, 40*time.Second, policy.Delay(3))
+	assert.Equal(t, time.Minute, policy.Delay(4))
+	assert.Equal(t, time.Minute, policy.Delay(10))
+}
+
+func TestSink(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyPath, []byte("secret\n"), 0o600))
+
+	t.Run("Should deliver signed CloudEvent", func(t *testing.T) {
+		// given
+		var contentType, signature string
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			signature = r.Header.Get(SignatureHeader)
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		sink, err := NewSink(SinkConfig{Name: "broker", URL: server.URL, SigningKeyPath: keyPath})
+		require.NoError(t, err)
+
+		event := []byte(`{"specversion":"1.0"}`)
+
+		// when
+		err = sink.Deliver(context.Background(), event)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, ContentType, contentType)
+		assert.Equal(t, event, body)
+		assert.Equal(t, Sign([]byte("secret"), event), signature)
+	})
+
+	t.Run("Should fail when sink doesn't accept the event", func(t *testing.T) {
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sink, err := NewSink(SinkConfig{Name: "broker", URL: server.URL, SigningKeyPath: keyPath})
+		require.NoError(t, err)
+
+		// when
+		err = sink.Deliver(context.Background(), []byte(`{}`))
+
+		// then
+		assert.EqualError(t, err, "notification sink broker responded with status 503")
+	})
+}
+
+func TestOutbox(t *testing.T) {
+	// given
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	kcpClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	outbox := &Outbox{
+		KcpClient: kcpClient,
+		Namespace: "kcp-system",
+		Sinks: []SinkConfig{
+			{Name: "broker", EventTypes: []EventType{EventTypeReady}},
+			{Name: "audit"},
+			{Name: "other", EventTypes: []EventType{EventTypeFailed}},
+		},
+		Now: func() time.Time { return now },
+	}
+
+	runtime := imv1.Runtime{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runtime-id",
+			Namespace: "kcp-system",
+			Labels: map[string]string{
+				imv1.LabelKymaRuntimeID:       "runtime-id",
+				imv1.LabelKymaGlobalAccountID: "global-account-id",
+			},
+		},
+		Spec:   imv1.RuntimeSpec{Shoot: imv1.RuntimeShoot{Name: "shoot"}},
+		Status: imv1.RuntimeStatus{State: imv1.RuntimeStateReady},
+	}
+
+	readyNotification := imv1.RuntimeNotification{Sequence: 3, EventType: string(EventTypeReady), Reason: "ConfigurationCompleted"}
+
+	// when
+	err := outbox.Publish(context.Background(), runtime, readyNotification)
+	require.NoError(t, err)
+	err = outbox.Publish(context.Background(), runtime, readyNotification)
+
+	// then
+	require.NoError(t, err)
+
+	var entries corev1.ConfigMapList
+	require.NoError(t, kcpClient.List(context.Background(), &entries, client.MatchingLabels{OutboxLabel: "true"}))
+	require.Len(t, entries.Items, 2)
+
+	var sinks []string
+	for _, entry := range entries.Items {
+		sinks = append(sinks, SinkName(&entry))
+		assert.Equal(t, "runtime-id", entry.Labels[imv1.LabelKymaRuntimeID])
+		assert.Equal(t, 0, Attempts(&entry))
+		assert.Equal(t, int64(3), Sequence(&entry))
+
+		var event CloudEvent
+		require.NoError(t, json.Unmarshal(Event(&entry), &event))
+		assert.Equal(t, "1.0", event.SpecVersion)
+		assert.Equal(t, "io.kyma-project.infrastructure-manager.runtime.ready", event.Type)
+		assert.Equal(t, "runtime-id", event.Subject)
+		assert.Equal(t, now, event.Time)
+		assert.NotEmpty(t, event.ID)
+		assert.Equal(t, Data{
+			RuntimeID:       "runtime-id",
+			GlobalAccountID: "global-account-id",
+			ShootName:       "shoot",
+			State:           "Ready",
+			Reason:          "ConfigurationCompleted",
+		}, event.Data)
+	}
+	assert.ElementsMatch(t, []string{"broker", "audit"}, sinks)
+}
@@ -0,0 +1,144 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	imv1 "github.com/kyma-project/infrastructure-manager/api/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OutboxLabel marks the ConfigMaps of the outbox, every ConfigMap holds the notification for a single sink
+	OutboxLabel = "operator.kyma-project.io/notification-outbox"
+
+	AttemptsAnnotation    = "operator.kyma-project.io/notification-attempts"
+	NextAttemptAnnotation = "operator.kyma-project.io/notification-next-attempt"
+	// SequenceAnnotation orders the notifications of a Runtime, they are delivered to a sink in its order
+	SequenceAnnotation = "operator.kyma-project.io/notification-sequence"
+
+	outboxNamePrefix = "runtime-notification-"
+	sinkKey          = "sink"
+	eventKey         = "event"
+)
+
+// Outbox stores the notifications in ConfigMaps until they are delivered, so that they survive restarts of KIM
+type Outbox struct {
+	KcpClient client.Client
+	Namespace string
+	Sinks     []SinkConfig
+	// Now returns the time of the notifications, defaults to time.Now
+	Now func() time.Time
+}
+
+// Publish stores the notification of the lifecycle transition for every sink subscribed to the event type.
+// The outbox entries are named after the Runtime and the sequence of the notification, so a notification
+// published again after a failure is stored only once.
+func (o *Outbox) Publish(ctx context.Context, runtime imv1.Runtime, runtimeNotification imv1.RuntimeNotification) error {
+	now := time.Now
+	if o.Now != nil {
+		now = o.Now
+	}
+
+	eventType := EventType(runtimeNotification.EventType)
+	event, err := NewEvent(runtime, eventType, runtimeNotification.Reason, now()).Marshal()
+	if err != nil {
+		return err
+	}
+
+	for _, sink := range o.Sinks {
+		if !sink.Subscribed(eventType) {
+			continue
+		}
+
+		entry := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      entryName(runtime, runtimeNotification.Sequence, sink.Name),
+				Namespace: o.Namespace,
+				Labels: map[string]string{
+					OutboxLabel: "true",
+					// the runtime ID assigns the notification to the shard of the Runtime
+					imv1.LabelKymaRuntimeID: runtime.Labels[imv1.LabelKymaRuntimeID],
+				},
+				Annotations: map[string]string{
+					AttemptsAnnotation: "0",
+					SequenceAnnotation: strconv.FormatInt(runtimeNotification.Sequence, 10),
+				},
+			},
+			Data: map[string]string{
+				sinkKey:  sink.Name,
+				eventKey: string(event),
+			},
+		}
+
+		if err := o.KcpClient.Create(ctx, &entry); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to store %s notification for sink %s", eventType, sink.Name)
+		}
+	}
+	return nil
+}
+
+// entryName returns the name of the outbox entry, the sink is hashed as its name isn't restricted to a DNS label
+func entryName(runtime imv1.Runtime, sequence int64, sinkName string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(sinkName))
+	return fmt.Sprintf("%s%s-%d-%08x", outboxNamePrefix, runtime.Name, sequence, hash.Sum32())
+}
+
+// SinkName returns the name of the sink the outbox entry is delivered to
+func SinkName(entry *corev1.ConfigMap) string {
+	return entry.Data[sinkKey]
+}
+
+// Event returns the encoded CloudEvent of the outbox entry
+func Event(entry *corev1.ConfigMap) []byte {
+	return []byte(entry.Data[eventKey])
+}
+
+// Sequence returns the sequence of the notification among the notifications of its Runtime
+func Sequence(entry *corev1.ConfigMap) int64 {
+	sequence, err := strconv.ParseInt(entry.Annotations[SequenceAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return sequence
+}
+
+// RuntimeID returns the runtime ID of the Runtime the outbox entry notifies about
+func RuntimeID(entry *corev1.ConfigMap) string {
+	return entry.Labels[imv1.LabelKymaRuntimeID]
+}
+
+// Attempts returns the number of failed delivery attempts of the outbox entry
+func Attempts(entry *corev1.ConfigMap) int {
+	attempts, err := strconv.Atoi(entry.Annotations[AttemptsAnnotation])
+	if err != nil {
+		return 0
+	}
+	return attempts
+}
+
+// NextAttempt returns the time of the next delivery attempt, the zero time when the entry is due
+func NextAttempt(entry *corev1.ConfigMap) time.Time {
+	next, err := time.Parse(time.RFC3339, entry.Annotations[NextAttemptAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return next
+}
+
+// SetFailedAttempt records a failed delivery attempt and the time of the next attempt
+func SetFailedAttempt(entry *corev1.ConfigMap, attempts int, next time.Time) {
+	if entry.Annotations == nil {
+		entry.Annotations = map[string]string{}
+	}
+	entry.Annotations[AttemptsAnnotation] = strconv.Itoa(attempts)
+	entry.Annotations[NextAttemptAnnotation] = next.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the request body, for example sha256=5d5b09f6...
+	SignatureHeader = "X-Kim-Signature"
+	signaturePrefix = "sha256="
+
+	defaultSinkTimeout = 10 * time.Second
+)
+
+// RetryPolicy limits the delivery attempts of a notification
+type RetryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// Delay returns the delay after the failed attempt, it is doubled with every attempt up to MaxDelay
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.InitialDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Sink delivers signed notifications to an HTTP endpoint
+type Sink struct {
+	Config     SinkConfig
+	signingKey []byte
+	httpClient *http.Client
+}
+
+// NewSink creates the sink with the signing key read from the file of the sink configuration
+func NewSink(cfg SinkConfig) (*Sink, error) {
+	signingKey, err := os.ReadFile(cfg.SigningKeyPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read signing key of notification sink %s", cfg.Name)
+	}
+
+	key := []byte(strings.TrimSpace(string(signingKey)))
+	if len(key) == 0 {
+		return nil, fmt.Errorf("signing key of notification sink %s is empty", cfg.Name)
+	}
+
+	return &Sink{
+		Config:     cfg,
+		signingKey: key,
+		httpClient: &http.Client{Timeout: defaultSinkTimeout},
+	}, nil
+}
+
+// Deliver sends the encoded CloudEvent to the sink, every response status other than 2xx is an error
+func (s *Sink) Deliver(ctx context.Context, event []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Config.URL, bytes.NewReader(event))
+	if err != nil {
+		return errors.Wrap(err, "failed to create notification request")
+	}
+	request.Header.Set("Content-Type", ContentType)
+	request.Header.Set(SignatureHeader, Sign(s.signingKey, event))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send notification to sink %s", s.Config.Name)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("notification sink %s responded with status %d", s.Config.Name, response.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the value of the signature header for the request body
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
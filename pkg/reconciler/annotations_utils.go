@@ -1,8 +1,17 @@
 package reconciler
 
 const (
-	ForceReconcileAnnotation   = "operator.kyma-project.io/force-patch-reconciliation"
-	SuspendReconcileAnnotation = "operator.kyma-project.io/suspend-patch-reconciliation"
+	ForceReconcileAnnotation     = "operator.kyma-project.io/force-patch-reconciliation"
+	SuspendReconcileAnnotation   = "operator.kyma-project.io/suspend-patch-reconciliation"
+	DeletionProtectionAnnotation = "operator.kyma-project.io/deletion-protection"
+	DryRunPatchAnnotation        = "operator.kyma-project.io/dry-run-patch"
+	ShootDriftPolicyAnnotation   = "operator.kyma-project.io/shoot-drift-policy"
+
+	// ConverterConfigRevisionAnnotation selects the staged revision of the converter configuration which is rolled out to the Runtime
+	ConverterConfigRevisionAnnotation = "operator.kyma-project.io/converter-config-revision"
+
+	// ShootDriftPolicyAutoCorrect makes KIM patch the Shoot when a drift is detected
+	ShootDriftPolicyAutoCorrect = "auto-correct"
 )
 
 func ShouldSuspendReconciliation(annotations map[string]string) bool {
@@ -20,3 +29,27 @@ func ShouldForceReconciliation(annotations map[string]string) bool {
 	}
 	return false
 }
+
+func IsDeletionProtected(annotations map[string]string) bool {
+	deletionProtection, found := annotations[DeletionProtectionAnnotation]
+	if found && deletionProtection == "true" {
+		return true
+	}
+	return false
+}
+
+func ShouldDryRunPatch(annotations map[string]string) bool {
+	dryRun, found := annotations[DryRunPatchAnnotation]
+	if found && dryRun == "true" {
+		return true
+	}
+	return false
+}
+
+func ShouldAutoCorrectShootDrift(annotations map[string]string) bool {
+	policy, found := annotations[ShootDriftPolicyAnnotation]
+	if found && policy == ShootDriftPolicyAutoCorrect {
+		return true
+	}
+	return false
+}
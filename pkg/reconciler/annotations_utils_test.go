@@ -72,3 +72,105 @@ func TestShouldSuspendReconciliation(t *testing.T) {
 		})
 	}
 }
+
+func TestIsDeletionProtected(t *testing.T) {
+	for _, testCase := range []struct {
+		name           string
+		annotations    map[string]string
+		expectedResult bool
+	}{
+		{
+			name:           "Should protect from deletion for `operator.kyma-project.io/deletion-protection` set to `true",
+			annotations:    map[string]string{"operator.kyma-project.io/deletion-protection": "true"},
+			expectedResult: true,
+		},
+		{
+			name:           "Should not protect from deletion for `operator.kyma-project.io/deletion-protection` set to `false",
+			annotations:    map[string]string{"operator.kyma-project.io/deletion-protection": "false"},
+			expectedResult: false,
+		},
+		{
+			name:           "Should not protect from deletion for nil annotations",
+			annotations:    nil,
+			expectedResult: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+
+			// when
+			deletionProtected := IsDeletionProtected(testCase.annotations)
+
+			// then
+			assert.Equal(t, testCase.expectedResult, deletionProtected)
+		})
+	}
+}
+
+func TestShouldDryRunPatch(t *testing.T) {
+	for _, testCase := range []struct {
+		name           string
+		annotations    map[string]string
+		expectedResult bool
+	}{
+		{
+			name:           "Should dry-run patch for `operator.kyma-project.io/dry-run-patch` set to `true",
+			annotations:    map[string]string{"operator.kyma-project.io/dry-run-patch": "true"},
+			expectedResult: true,
+		},
+		{
+			name:           "Should not dry-run patch for `operator.kyma-project.io/dry-run-patch` set to `false",
+			annotations:    map[string]string{"operator.kyma-project.io/dry-run-patch": "false"},
+			expectedResult: false,
+		},
+		{
+			name:           "Should not dry-run patch for nil annotations",
+			annotations:    nil,
+			expectedResult: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+
+			// when
+			dryRun := ShouldDryRunPatch(testCase.annotations)
+
+			// then
+			assert.Equal(t, testCase.expectedResult, dryRun)
+		})
+	}
+}
+
+func TestShouldAutoCorrectShootDrift(t *testing.T) {
+	for _, testCase := range []struct {
+		name           string
+		annotations    map[string]string
+		expectedResult bool
+	}{
+		{
+			name:           "Should auto-correct drift for `operator.kyma-project.io/shoot-drift-policy` set to `auto-correct`",
+			annotations:    map[string]string{"operator.kyma-project.io/shoot-drift-policy": "auto-correct"},
+			expectedResult: true,
+		},
+		{
+			name:           "Should not auto-correct drift for `operator.kyma-project.io/shoot-drift-policy` set to `report`",
+			annotations:    map[string]string{"operator.kyma-project.io/shoot-drift-policy": "report"},
+			expectedResult: false,
+		},
+		{
+			name:           "Should not auto-correct drift for nil annotations",
+			annotations:    nil,
+			expectedResult: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+
+			// when
+			autoCorrect := ShouldAutoCorrectShootDrift(testCase.annotations)
+
+			// then
+			assert.Equal(t, testCase.expectedResult, autoCorrect)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	tracerName  = "github.com/kyma-project/infrastructure-manager"
+	serviceName = "infrastructure-manager"
+)
+
+// Config configures the export of the traces
+type Config struct {
+	// OTLPEndpoint is the URL of the OTLP/HTTP endpoint receiving the spans, for example http://otel-collector:4318.
+	// The tracing is disabled when it is empty.
+	OTLPEndpoint string
+	// SamplingRatio is the ratio of the reconciliations which are traced
+	SamplingRatio float64
+}
+
+func (c Config) Enabled() bool {
+	return c.OTLPEndpoint != ""
+}
+
+// Setup registers the global tracer provider which exports the spans to the OTLP endpoint.
+// The returned function flushes the pending spans and must be called before the process exits.
+// When the tracing is disabled, the global no-op tracer provider is kept.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	tracerProvider := NewTracerProvider(exporter, cfg.SamplingRatio)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tracerProvider.Shutdown, nil
+}
+
+// NewTracerProvider creates a tracer provider exporting the spans with the given exporter.
+// The sampling decision of the parent span is respected, so a reconciliation is traced completely or not at all.
+func NewTracerProvider(exporter sdktrace.SpanExporter, samplingRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+}
+
+// Tracer returns the tracer of KIM from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// InstrumentRestConfig adds a span for every API call of the clients created with the rest config.
+// Only the calls made within a traced reconciliation are recorded, so that the watches of the informers
+// and the leader election don't produce spans on their own.
+func InstrumentRestConfig(restConfig *rest.Config) *rest.Config {
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return otelhttp.NewTransport(rt,
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return trace.SpanContextFromContext(r.Context()).IsValid()
+			}),
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method + " " + r.URL.Host
+			}),
+		)
+	})
+	return restConfig
+}
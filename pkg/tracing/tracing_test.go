@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/client-go/rest"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("Should keep the tracing disabled without OTLP endpoint", func(t *testing.T) {
+		// when
+		shutdown, err := Setup(context.Background(), Config{})
+
+		// then
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+
+		_, span := Tracer().Start(context.Background(), "Runtime reconciliation")
+		assert.False(t, span.SpanContext().IsValid())
+	})
+
+	t.Run("Should export spans to OTLP endpoint", func(t *testing.T) {
+		// given
+		previous := otel.GetTracerProvider()
+		defer otel.SetTracerProvider(previous)
+
+		received := make(chan *coltracepb.ExportTraceServiceRequest, 1)
+		collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			request := &coltracepb.ExportTraceServiceRequest{}
+			assert.NoError(t, proto.Unmarshal(body, request))
+			received <- request
+		}))
+		defer collector.Close()
+
+		shutdown, err := Setup(context.Background(), Config{OTLPEndpoint: collector.URL, SamplingRatio: 1})
+		require.NoError(t, err)
+
+		// when
+		_, span := Tracer().Start(context.Background(), "Runtime reconciliation")
+		span.End()
+		require.NoError(t, shutdown(context.Background()))
+
+		// then
+		request := <-received
+		require.Len(t, request.ResourceSpans, 1)
+		require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+		require.Len(t, request.ResourceSpans[0].ScopeSpans[0].Spans, 1)
+		assert.Equal(t, "Runtime reconciliation", request.ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+	})
+}
+
+func TestInstrumentRestConfig(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer apiServer.Close()
+
+	httpClient, err := rest.HTTPClientFor(InstrumentRestConfig(&rest.Config{Host: apiServer.URL}))
+	require.NoError(t, err)
+
+	get := func(ctx context.Context) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, apiServer.URL+"/api/v1/namespaces/kcp-system/secrets/test", nil)
+		require.NoError(t, err)
+
+		response, err := httpClient.Do(request)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+	}
+
+	t.Run("Should record API call within a trace with its status code", func(t *testing.T) {
+		// given
+		exporter.Reset()
+		ctx, parent := Tracer().Start(context.Background(), "sFnTakeSnapshot")
+
+		// when
+		get(ctx)
+		parent.End()
+
+		// then
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Contains(t, spans[0].Attributes, attribute.Int("http.response.status_code", http.StatusNotFound))
+	})
+
+	t.Run("Should not record API call outside of a trace", func(t *testing.T) {
+		// given
+		exporter.Reset()
+
+		// when
+		get(context.Background())
+
+		// then
+		assert.Empty(t, exporter.GetSpans())
+	})
+}